@@ -4,3 +4,6 @@ import "errors"
 
 // ErrNilHttpServer signals that a nil http server has been provided
 var ErrNilHttpServer = errors.New("nil http server")
+
+// ErrNilMetricsProvider signals that a nil metrics provider has been provided
+var ErrNilMetricsProvider = errors.New("nil metrics provider")
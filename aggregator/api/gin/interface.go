@@ -6,3 +6,9 @@ type server interface {
 	ListenAndServe() error
 	Shutdown(ctx context.Context) error
 }
+
+// MetricsProvider defines a component that exposes its metrics on the /metrics route
+type MetricsProvider interface {
+	Metrics() map[string]interface{}
+	IsInterfaceNil() bool
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
+	apiErrors "github.com/klever-io/klv-oracles-go/aggregator/api/errors"
+	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-core-go/marshal"
 	"github.com/multiversx/mx-chain-go/api/logs"
 	mxChainShared "github.com/multiversx/mx-chain-go/api/shared"
@@ -19,20 +21,35 @@ var log = logger.GetOrCreate("api")
 
 type webServer struct {
 	sync.RWMutex
-	httpServer   mxChainShared.HttpServerCloser
-	apiInterface string
-	cancelFunc   func()
+	httpServer       mxChainShared.HttpServerCloser
+	apiInterface     string
+	cancelFunc       func()
+	metricsProviders map[string]MetricsProvider
 }
 
 // NewWebServerHandler returns a new instance of webServer
 func NewWebServerHandler(apiInterface string) (*webServer, error) {
 	gws := &webServer{
-		apiInterface: apiInterface,
+		apiInterface:     apiInterface,
+		metricsProviders: make(map[string]MetricsProvider),
 	}
 
 	return gws, nil
 }
 
+// AddMetricsProvider adds a component whose metrics will be returned, under the provided name, by the /metrics route
+func (ws *webServer) AddMetricsProvider(name string, provider MetricsProvider) error {
+	if check.IfNil(provider) {
+		return fmt.Errorf("%w for %s", apiErrors.ErrNilMetricsProvider, name)
+	}
+
+	ws.Lock()
+	ws.metricsProviders[name] = provider
+	ws.Unlock()
+
+	return nil
+}
+
 // StartHttpServer will create a new instance of http.Server and populate it with all the routes
 func (ws *webServer) StartHttpServer() error {
 	ws.Lock()
@@ -72,6 +89,19 @@ func (ws *webServer) StartHttpServer() error {
 func (ws *webServer) registerRoutes(ginRouter *gin.Engine) {
 	marshalizerForLogs := &marshal.GogoProtoMarshalizer{}
 	registerLoggerWsRoute(ginRouter, marshalizerForLogs)
+	ginRouter.GET("/metrics", ws.metricsHandler)
+}
+
+// metricsHandler returns the metrics of all the providers, grouped by the providers' names
+func (ws *webServer) metricsHandler(c *gin.Context) {
+	ws.RLock()
+	metrics := make(map[string]interface{}, len(ws.metricsProviders))
+	for name, provider := range ws.metricsProviders {
+		metrics[name] = provider.Metrics()
+	}
+	ws.RUnlock()
+
+	c.JSON(http.StatusOK, metrics)
 }
 
 // registerLoggerWsRoute will register the log route
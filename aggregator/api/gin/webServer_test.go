@@ -1,14 +1,32 @@
 package gin
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/gin-gonic/gin"
+	apiErrors "github.com/klever-io/klv-oracles-go/aggregator/api/errors"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+type metricsProviderStub struct {
+	metrics map[string]interface{}
+}
+
+func (stub *metricsProviderStub) Metrics() map[string]interface{} {
+	return stub.metrics
+}
+
+func (stub *metricsProviderStub) IsInterfaceNil() bool {
+	return stub == nil
+}
+
 func TestNewWebServerHandler(t *testing.T) {
 	t.Parallel()
 
@@ -68,3 +86,41 @@ func TestWebServer_StartHttpServer(t *testing.T) {
 		assert.Nil(t, err)
 	})
 }
+
+func TestWebServer_AddMetricsProvider(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil provider should error", func(t *testing.T) {
+		t.Parallel()
+
+		ws, _ := NewWebServerHandler("127.0.0.1:8080")
+		err := ws.AddMetricsProvider("provider", nil)
+		assert.True(t, errors.Is(err, apiErrors.ErrNilMetricsProvider))
+	})
+	t.Run("metrics route should return the metrics of all providers", func(t *testing.T) {
+		t.Parallel()
+
+		ws, _ := NewWebServerHandler("127.0.0.1:8080")
+		err := ws.AddMetricsProvider("first", &metricsProviderStub{metrics: map[string]interface{}{"num_requests": 3}})
+		require.Nil(t, err)
+		err = ws.AddMetricsProvider("second", &metricsProviderStub{metrics: map[string]interface{}{"available_tokens": 1.5}})
+		require.Nil(t, err)
+
+		gin.SetMode(gin.TestMode)
+		engine := gin.New()
+		ws.registerRoutes(engine)
+
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		engine.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		response := make(map[string]map[string]float64)
+		err = json.Unmarshal(recorder.Body.Bytes(), &response)
+		require.Nil(t, err)
+		assert.Equal(t, map[string]map[string]float64{
+			"first":  {"num_requests": 3},
+			"second": {"available_tokens": 1.5},
+		}, response)
+	})
+}
@@ -19,8 +19,6 @@ var (
 	ErrNilArgsPair = errors.New("nil pair argument")
 	// ErrNilPriceNotifee signals that a nil price notifee was provided
 	ErrNilPriceNotifee = errors.New("nil price notifee")
-	// ErrInvalidNumOfElementsToComputeMedian signals that an invalid number of elements to compute the median was provided
-	ErrInvalidNumOfElementsToComputeMedian = errors.New("invalid number of elements to compute the median")
 	// ErrInvalidDecimals signals that an invalid number of decimals was provided
 	ErrInvalidDecimals = errors.New("invalid decimals")
 	// ErrNilBaseName signals that an invalid base name was provided
@@ -31,10 +29,40 @@ var (
 	ErrNilExchanges = errors.New("nil exchanges map")
 	// ErrInvalidAutoSendInterval signals that an invalid auto send interval value was provided
 	ErrInvalidAutoSendInterval = errors.New("invalid auto send interval")
+	// ErrInvalidPollInterval signals that an invalid poll interval value was provided
+	ErrInvalidPollInterval = errors.New("invalid poll interval")
+	// ErrNotAGasPair signals that a gas setting was provided for a pair not handled by the gas price service
+	ErrNotAGasPair = errors.New("not a gas pair")
+	// ErrPostNotSupported signals that the response getter is not able to send post requests
+	ErrPostNotSupported = errors.New("post requests not supported")
 	// ErrPairNotSupported signals that the pair is not supported by the fetcher
 	ErrPairNotSupported = errors.New("pair not supported")
 	// ErrNilAuthClient signals that a nil auth client was provided
 	ErrNilAuthClient = errors.New("nil auth client")
+	// ErrInvalidQuoteConversion signals that an invalid quote conversion was provided
+	ErrInvalidQuoteConversion = errors.New("invalid quote conversion")
+	// ErrUnexpectedHTTPStatus signals that a server responded with a non 2xx status code
+	ErrUnexpectedHTTPStatus = errors.New("unexpected HTTP status")
+	// ErrInvalidHTTPClientConfig signals that an invalid HTTP client configuration was provided
+	ErrInvalidHTTPClientConfig = errors.New("invalid HTTP client config")
+	// ErrInvalidHTTPTransportConfig signals that an invalid HTTP transport configuration was provided
+	ErrInvalidHTTPTransportConfig = errors.New("invalid HTTP transport config")
+	// ErrNilResponseGetter signals that a nil response getter was provided
+	ErrNilResponseGetter = errors.New("nil response getter")
+	// ErrInvalidRateLimit signals that an invalid rate limit was provided
+	ErrInvalidRateLimit = errors.New("invalid rate limit")
+	// ErrNilCassetteWriter signals that a nil cassette writer was provided
+	ErrNilCassetteWriter = errors.New("nil cassette writer")
+	// ErrNilCassetteReader signals that a nil cassette reader was provided
+	ErrNilCassetteReader = errors.New("nil cassette reader")
+	// ErrInvalidCassette signals that a cassette entry could not be decoded
+	ErrInvalidCassette = errors.New("invalid cassette")
+	// ErrNoRecordedResponse signals that the cassette holds no more responses for the requested URL
+	ErrNoRecordedResponse = errors.New("no recorded response")
+	// ErrRecordedRequestFailed signals that the replayed request failed when it was recorded
+	ErrRecordedRequestFailed = errors.New("recorded request failed")
 	// ErrNilGasPriceService signals that a nil gas price service was provided
 	ErrNilGasPriceService = errors.New("nil gas price service")
+	// ErrInvalidDeviationThreshold signals that an invalid deviation threshold was provided
+	ErrInvalidDeviationThreshold = errors.New("invalid deviation threshold")
 )
@@ -2,11 +2,6 @@ package aggregator
 
 import "time"
 
-// ComputeMedian -
-func ComputeMedian(nums []float64) (float64, error) {
-	return computeMedian(nums)
-}
-
 // SetLastNotifiedPrices -
 func (pn *priceNotifier) SetLastNotifiedPrices(lastNotifiedPrices []float64) {
 	pn.mut.Lock()
@@ -19,7 +14,10 @@ func (pn *priceNotifier) SetTimeSinceHandler(handler func(time time.Time) time.D
 	pn.timeSinceHandler = handler
 }
 
-// LastTimeAutoSent -
-func (pn *priceNotifier) LastTimeAutoSent() time.Time {
-	return pn.lastTimeAutoSent
+// LastTimesSent -
+func (pn *priceNotifier) LastTimesSent() []time.Time {
+	pn.mut.Lock()
+	defer pn.mut.Unlock()
+
+	return append(make([]time.Time, 0, len(pn.lastTimesSent)), pn.lastTimesSent...)
 }
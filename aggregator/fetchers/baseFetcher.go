@@ -1,33 +1,69 @@
 package fetchers
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"strings"
+	"sort"
 	"sync"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
 )
 
+// SymbolMapping holds the exchange specific naming of the canonical asset IDs and markets
+type SymbolMapping struct {
+	// Assets maps a canonical asset ID to the exchange symbol, e.g. BTC -> XBT
+	Assets map[string]string
+	// Markets maps a canonical BASE-QUOTE pair to the exchange market ID, e.g. KLV-USDT -> KLV_USDT.
+	// The quote is the one resulted after the quote mappings were applied
+	Markets map[string]string
+}
+
+type marketIDBuilder func(baseSymbol string, quoteSymbol string) string
+
+type fetchPriceHandler func(ctx context.Context, base string, quote string) (float64, error)
+
+type knownPair struct {
+	base  string
+	quote string
+}
+
 type baseFetcher struct {
-	knownPairs    map[string]struct{}
+	knownPairs    map[string]knownPair
 	knownPairsMut sync.RWMutex
+	quoteMappings map[string]string
+	assets        map[string]string
+	markets       map[string]string
 }
 
-func newBaseFetcher() baseFetcher {
+func newBaseFetcher(quoteMappings map[string]string, symbolMapping SymbolMapping) baseFetcher {
 	return baseFetcher{
-		knownPairs:    make(map[string]struct{}),
+		knownPairs:    make(map[string]knownPair),
 		knownPairsMut: sync.RWMutex{},
+		quoteMappings: copyMap(quoteMappings),
+		assets:        copyMap(symbolMapping.Assets),
+		markets:       copyMap(symbolMapping.Markets),
 	}
 }
 
-func (b *baseFetcher) normalizeQuoteName(quote string, fetcherName string) string {
-	if strings.Contains(quote, quoteUSDFiat) {
-		switch fetcherName {
-		case BinanceName, CryptocomName, HitbtcName, HuobiName, OkxName:
-			return quoteUSDT
-		default:
-			return quoteUSDFiat
-		}
+func copyMap(source map[string]string) map[string]string {
+	result := make(map[string]string, len(source))
+	for key, value := range source {
+		result[key] = value
+	}
+
+	return result
+}
+
+// MappedQuote returns the quote that will actually be queried on the exchange for the provided quote. Only exact
+// matches from the configured quote mappings are replaced, all other quotes are returned unchanged
+func (b *baseFetcher) MappedQuote(quote string) string {
+	mappedQuote, found := b.quoteMappings[quote]
+	if !found {
+		return quote
 	}
-	return quote
+
+	return mappedQuote
 }
 
 // AddPair adds the specified base-quote pair to the internal cache
@@ -35,7 +71,10 @@ func (b *baseFetcher) AddPair(base, quote string) {
 	key := b.getPairKey(base, quote)
 
 	b.knownPairsMut.Lock()
-	b.knownPairs[key] = struct{}{}
+	b.knownPairs[key] = knownPair{
+		base:  base,
+		quote: quote,
+	}
 	b.knownPairsMut.Unlock()
 }
 
@@ -52,3 +91,99 @@ func (b *baseFetcher) hasPair(base, quote string) bool {
 func (b *baseFetcher) getPairKey(base, quote string) string {
 	return fmt.Sprintf("%s-%s", base, quote)
 }
+
+// exchangeSymbol returns the symbol used by the exchange for the provided canonical asset ID
+func (b *baseFetcher) exchangeSymbol(asset string) string {
+	symbol, found := b.assets[asset]
+	if !found {
+		return asset
+	}
+
+	return symbol
+}
+
+// marketID returns the configured market ID of the provided pair. If the market is not mapped, the ID is built
+// from the exchange symbols of the base and quote
+func (b *baseFetcher) marketID(base string, quote string, builder marketIDBuilder) string {
+	market, found := b.markets[b.getPairKey(base, quote)]
+	if found {
+		return market
+	}
+
+	return builder(b.exchangeSymbol(base), b.exchangeSymbol(quote))
+}
+
+func formatMarketID(format string) marketIDBuilder {
+	return func(baseSymbol string, quoteSymbol string) string {
+		return fmt.Sprintf(format, baseSymbol, quoteSymbol)
+	}
+}
+
+// unusedSymbolMappings returns the configured asset and market mappings that are not used by any of the known pairs
+func (b *baseFetcher) unusedSymbolMappings() []string {
+	usedAssets := make(map[string]struct{})
+	usedMarkets := make(map[string]struct{})
+
+	b.knownPairsMut.RLock()
+	for _, pair := range b.knownPairs {
+		mappedQuote := b.MappedQuote(pair.quote)
+		usedAssets[pair.base] = struct{}{}
+		usedAssets[mappedQuote] = struct{}{}
+		usedMarkets[b.getPairKey(pair.base, mappedQuote)] = struct{}{}
+	}
+	b.knownPairsMut.RUnlock()
+
+	unused := make([]string, 0)
+	for asset := range b.assets {
+		if _, found := usedAssets[asset]; !found {
+			unused = append(unused, fmt.Sprintf("asset %s", asset))
+		}
+	}
+	for market := range b.markets {
+		if _, found := usedMarkets[market]; !found {
+			unused = append(unused, fmt.Sprintf("market %s", market))
+		}
+	}
+	sort.Strings(unused)
+
+	return unused
+}
+
+// fetchPricesConcurrently is used by the sources without a multi-symbol endpoint: each pair is fetched with its own
+// request, all the requests being sent concurrently
+func fetchPricesConcurrently(
+	ctx context.Context,
+	fetchPrice fetchPriceHandler,
+	pairs []aggregator.BaseQuote,
+) map[aggregator.BaseQuote]aggregator.PriceResult {
+	var wg sync.WaitGroup
+	var mut sync.Mutex
+	results := make(map[aggregator.BaseQuote]aggregator.PriceResult, len(pairs))
+
+	wg.Add(len(pairs))
+	for _, p := range pairs {
+		go func(pair aggregator.BaseQuote) {
+			defer wg.Done()
+
+			price, err := fetchPrice(ctx, pair.Base, pair.Quote)
+
+			mut.Lock()
+			results[pair] = aggregator.PriceResult{Price: price, Err: err}
+			mut.Unlock()
+		}(p)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// decodeErrorResponse casts the body of a non 2xx response over the response object, for the APIs describing their
+// errors in the body. Returns false when err is not an HTTP status error or its body is not the expected JSON
+func decodeErrorResponse(err error, response interface{}) bool {
+	statusErr := &aggregator.HTTPStatusError{}
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+
+	return statusErr.DecodeBody(response) == nil
+}
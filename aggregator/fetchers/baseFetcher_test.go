@@ -6,33 +6,35 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func Test_normalizeQuoteName(t *testing.T) {
+func TestBaseFetcher_MappedQuote(t *testing.T) {
 	t.Parallel()
 
-	t.Run("updating to usdt", func(t *testing.T) {
+	t.Run("mapped quote should be replaced", func(t *testing.T) {
 		t.Parallel()
 
-		base := baseFetcher{}
-		quote := "AAA USD AAA"
-		quote = base.normalizeQuoteName(quote, BinanceName)
-		assert.Equal(t, quoteUSDT, quote)
+		base := newBaseFetcher(map[string]string{quoteUSDFiat: "USDT"}, SymbolMapping{})
+		assert.Equal(t, "USDT", base.MappedQuote(quoteUSDFiat))
 	})
-	t.Run("updating to usd", func(t *testing.T) {
+	t.Run("quote only containing a mapped quote should not be replaced", func(t *testing.T) {
 		t.Parallel()
 
-		base := baseFetcher{}
-		quote := "AAA USD AAA"
-		quote = base.normalizeQuoteName(quote, "other fetcher name")
-		assert.Equal(t, quoteUSDFiat, quote)
+		base := newBaseFetcher(map[string]string{quoteUSDFiat: "USDT"}, SymbolMapping{})
+		providedQuote := "AAA USD AAA"
+		assert.Equal(t, providedQuote, base.MappedQuote(providedQuote))
 	})
-	t.Run("update not needed", func(t *testing.T) {
+	t.Run("no mappings should not replace", func(t *testing.T) {
 		t.Parallel()
 
-		base := baseFetcher{}
-		providedQuote := "custom quote"
-		quote := providedQuote
-		quote = base.normalizeQuoteName(quote, "other fetcher name")
-		assert.Equal(t, providedQuote, quote)
+		base := newBaseFetcher(nil, SymbolMapping{})
+		assert.Equal(t, quoteUSDFiat, base.MappedQuote(quoteUSDFiat))
+	})
+	t.Run("changing the provided mappings should not affect the fetcher", func(t *testing.T) {
+		t.Parallel()
+
+		mappings := map[string]string{quoteUSDFiat: "USDT"}
+		base := newBaseFetcher(mappings, SymbolMapping{})
+		mappings[quoteUSDFiat] = "USDC"
+		assert.Equal(t, "USDT", base.MappedQuote(quoteUSDFiat))
 	})
 }
 
@@ -40,7 +42,7 @@ func TestBaseFetcher_knownPairs(t *testing.T) {
 	t.Parallel()
 
 	b := baseFetcher{
-		knownPairs: make(map[string]struct{}),
+		knownPairs: make(map[string]knownPair),
 	}
 	base := "base"
 	quote := "quote"
@@ -48,3 +50,35 @@ func TestBaseFetcher_knownPairs(t *testing.T) {
 	b.AddPair(base, quote)
 	assert.True(t, b.hasPair(base, quote))
 }
+
+func TestBaseFetcher_marketID(t *testing.T) {
+	t.Parallel()
+
+	symbolMapping := SymbolMapping{
+		Assets:  map[string]string{"BTC": "XBT"},
+		Markets: map[string]string{"KLV-USDT": "KLEVER_USDT"},
+	}
+	b := newBaseFetcher(nil, symbolMapping)
+	builder := formatMarketID("%s_%s")
+
+	assert.Equal(t, "XBT", b.exchangeSymbol("BTC"))
+	assert.Equal(t, "ETH", b.exchangeSymbol("ETH"))
+	assert.Equal(t, "XBT_USD", b.marketID("BTC", "USD", builder))
+	assert.Equal(t, "ETH_USD", b.marketID("ETH", "USD", builder))
+	assert.Equal(t, "KLEVER_USDT", b.marketID("KLV", "USDT", builder))
+}
+
+func TestBaseFetcher_unusedSymbolMappings(t *testing.T) {
+	t.Parallel()
+
+	symbolMapping := SymbolMapping{
+		Assets:  map[string]string{"BTC": "XBT", "USDT": "UST", "KLV": "KLEVER"},
+		Markets: map[string]string{"ETH-USDT": "tETHUST", "ETH-EUR": "tETHEUR"},
+	}
+	b := newBaseFetcher(map[string]string{"USD": "USDT"}, symbolMapping)
+	assert.Equal(t, []string{"asset BTC", "asset KLV", "asset USDT", "market ETH-EUR", "market ETH-USDT"}, b.unusedSymbolMappings())
+
+	b.AddPair("ETH", "USD")
+	b.AddPair("BTC", "EUR")
+	assert.Equal(t, []string{"asset KLV", "market ETH-EUR"}, b.unusedSymbolMappings())
+}
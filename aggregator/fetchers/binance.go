@@ -2,13 +2,20 @@ package fetchers
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
 
 	"github.com/klever-io/klv-oracles-go/aggregator"
 )
 
 const (
-	binancePriceUrl = "https://api.binance.com/api/v3/ticker/price?symbol=%s%s"
+	binancePriceUrl      = "https://api.binance.com/api/v3/ticker/price?symbol=%s"
+	binanceBatchPriceUrl = "https://api.binance.com/api/v3/ticker/price?symbols=%s"
+	binanceMarketFormat  = "%s%s"
 )
 
 type binancePriceRequest struct {
@@ -19,6 +26,9 @@ type binancePriceRequest struct {
 type binance struct {
 	aggregator.ResponseGetter
 	baseFetcher
+
+	batchRejectedMut sync.RWMutex
+	batchRejected    bool
 }
 
 // FetchPrice will fetch the price using the http client
@@ -27,10 +37,8 @@ func (b *binance) FetchPrice(ctx context.Context, base string, quote string) (fl
 		return 0, aggregator.ErrPairNotSupported
 	}
 
-	quote = b.normalizeQuoteName(quote, BinanceName)
-
 	var bpr binancePriceRequest
-	err := b.ResponseGetter.Get(ctx, fmt.Sprintf(binancePriceUrl, base, quote), &bpr)
+	err := b.ResponseGetter.Get(ctx, fmt.Sprintf(binancePriceUrl, b.market(base, quote)), &bpr)
 	if err != nil {
 		return 0, err
 	}
@@ -41,6 +49,91 @@ func (b *binance) FetchPrice(ctx context.Context, base string, quote string) (fl
 	return StrToPositiveFloat64(bpr.Price)
 }
 
+// FetchPrices fetches the prices of all the provided pairs with a single multi-symbol request. The pairs missing
+// from the response are fetched with single symbol requests
+func (b *binance) FetchPrices(ctx context.Context, pairs []aggregator.BaseQuote) map[aggregator.BaseQuote]aggregator.PriceResult {
+	results := make(map[aggregator.BaseQuote]aggregator.PriceResult, len(pairs))
+	markets := make(map[aggregator.BaseQuote]string)
+	marketIDs := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		if !b.hasPair(pair.Base, pair.Quote) {
+			results[pair] = aggregator.PriceResult{Err: aggregator.ErrPairNotSupported}
+			continue
+		}
+
+		market := b.market(pair.Base, pair.Quote)
+		markets[pair] = market
+		marketIDs = append(marketIDs, market)
+	}
+
+	batchPrices := make(map[string]string)
+	if len(marketIDs) > 1 {
+		batchPrices = b.fetchBatchPrices(ctx, marketIDs)
+	}
+
+	remainingPairs := make([]aggregator.BaseQuote, 0)
+	for pair, market := range markets {
+		price, found := batchPrices[market]
+		if !found {
+			remainingPairs = append(remainingPairs, pair)
+			continue
+		}
+
+		convertedPrice, err := StrToPositiveFloat64(price)
+		results[pair] = aggregator.PriceResult{Price: convertedPrice, Err: err}
+	}
+
+	for pair, result := range fetchPricesConcurrently(ctx, b.FetchPrice, remainingPairs) {
+		results[pair] = result
+	}
+
+	return results
+}
+
+// fetchBatchPrices returns the prices of the provided markets, fetched with a single multi-symbol request. Binance
+// rejects the whole request if any of the symbols is invalid, in which case the multi-symbol requests are disabled
+func (b *binance) fetchBatchPrices(ctx context.Context, markets []string) map[string]string {
+	b.batchRejectedMut.RLock()
+	batchRejected := b.batchRejected
+	b.batchRejectedMut.RUnlock()
+	if batchRejected {
+		return nil
+	}
+
+	symbols, err := json.Marshal(markets)
+	if err != nil {
+		return nil
+	}
+
+	var response []binancePriceRequest
+	err = b.ResponseGetter.Get(ctx, fmt.Sprintf(binanceBatchPriceUrl, url.QueryEscape(string(symbols))), &response)
+	statusErr := &aggregator.HTTPStatusError{}
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusBadRequest {
+		log.Warn("binance rejected the multi-symbol request, using single symbol requests", "markets", markets, "error", err)
+		b.batchRejectedMut.Lock()
+		b.batchRejected = true
+		b.batchRejectedMut.Unlock()
+		return nil
+	}
+	if err != nil {
+		log.Debug("binance multi-symbol request failed, using single symbol requests", "error", err)
+		return nil
+	}
+
+	prices := make(map[string]string, len(response))
+	for _, ticker := range response {
+		if ticker.Price != "" {
+			prices[ticker.Symbol] = ticker.Price
+		}
+	}
+
+	return prices
+}
+
+func (b *binance) market(base string, quote string) string {
+	return b.marketID(base, b.MappedQuote(quote), formatMarketID(binanceMarketFormat))
+}
+
 // Name returns the name
 func (b *binance) Name() string {
 	return BinanceName
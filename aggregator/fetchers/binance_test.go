@@ -0,0 +1,160 @@
+package fetchers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createBinanceFetcher(t *testing.T, serverURL string) *binance {
+	httpResponseGetter, err := aggregator.NewHttpResponseGetter(aggregator.DefaultArgsHttpResponseGetter())
+	require.Nil(t, err)
+
+	args := createMockArgsPriceFetcher()
+	args.FetcherName = BinanceName
+	args.ResponseGetter = &redirectResponseGetter{
+		serverURL:      serverURL,
+		responseGetter: httpResponseGetter,
+	}
+	args.QuoteMappings = map[string]string{quoteUSDFiat: "USDT"}
+	pf, err := NewPriceFetcher(args)
+	require.Nil(t, err)
+
+	return pf.(*binance)
+}
+
+type requestsRecorder struct {
+	mut  sync.Mutex
+	uris []string
+}
+
+func (recorder *requestsRecorder) add(uri string) {
+	recorder.mut.Lock()
+	recorder.uris = append(recorder.uris, uri)
+	recorder.mut.Unlock()
+}
+
+func (recorder *requestsRecorder) requests() []string {
+	recorder.mut.Lock()
+	defer recorder.mut.Unlock()
+
+	return append([]string{}, recorder.uris...)
+}
+
+func TestBinance_FetchPrices(t *testing.T) {
+	t.Parallel()
+
+	btcUSD := aggregator.BaseQuote{Base: "BTC", Quote: quoteUSDFiat}
+	ethUSD := aggregator.BaseQuote{Base: "ETH", Quote: quoteUSDFiat}
+	klvUSD := aggregator.BaseQuote{Base: "KLV", Quote: quoteUSDFiat}
+	xyzUSD := aggregator.BaseQuote{Base: "XYZ", Quote: quoteUSDFiat}
+
+	t.Run("should fetch all the pairs in one request", func(t *testing.T) {
+		t.Parallel()
+
+		recorder := &requestsRecorder{}
+		httpServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			recorder.add(req.URL.RequestURI())
+			assert.Equal(t, `["BTCUSDT","ETHUSDT","KLVUSDT"]`, req.URL.Query().Get("symbols"))
+
+			rw.WriteHeader(http.StatusOK)
+			_, _ = rw.Write([]byte(`[{"symbol":"BTCUSDT","price":"62330.01000000"},{"symbol":"ETHUSDT","price":"2446.20000000"},` +
+				`{"symbol":"KLVUSDT","price":"0.00239000"}]`))
+		}))
+		defer httpServer.Close()
+
+		fetcher := createBinanceFetcher(t, httpServer.URL)
+		fetcher.AddPair("BTC", quoteUSDFiat)
+		fetcher.AddPair("ETH", quoteUSDFiat)
+		fetcher.AddPair("KLV", quoteUSDFiat)
+
+		results := fetcher.FetchPrices(context.Background(), []aggregator.BaseQuote{btcUSD, ethUSD, klvUSD, xyzUSD})
+		assert.Equal(t, map[aggregator.BaseQuote]aggregator.PriceResult{
+			btcUSD: {Price: 62330.01},
+			ethUSD: {Price: 2446.2},
+			klvUSD: {Price: 0.00239},
+			xyzUSD: {Err: aggregator.ErrPairNotSupported},
+		}, results)
+		assert.Equal(t, 1, len(recorder.requests()))
+	})
+	t.Run("pairs missing from the response should be fetched one by one", func(t *testing.T) {
+		t.Parallel()
+
+		recorder := &requestsRecorder{}
+		httpServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			recorder.add(req.URL.RequestURI())
+
+			rw.WriteHeader(http.StatusOK)
+			if req.URL.Query().Get("symbols") != "" {
+				_, _ = rw.Write([]byte(`[{"symbol":"BTCUSDT","price":"62330.01000000"}]`))
+				return
+			}
+			_, _ = rw.Write([]byte(`{"symbol":"ETHUSDT","price":"2446.20000000"}`))
+		}))
+		defer httpServer.Close()
+
+		fetcher := createBinanceFetcher(t, httpServer.URL)
+		fetcher.AddPair("BTC", quoteUSDFiat)
+		fetcher.AddPair("ETH", quoteUSDFiat)
+
+		results := fetcher.FetchPrices(context.Background(), []aggregator.BaseQuote{btcUSD, ethUSD})
+		assert.Equal(t, map[aggregator.BaseQuote]aggregator.PriceResult{
+			btcUSD: {Price: 62330.01},
+			ethUSD: {Price: 2446.2},
+		}, results)
+		assert.Equal(t, []string{
+			"/api/v3/ticker/price?symbols=%5B%22BTCUSDT%22%2C%22ETHUSDT%22%5D",
+			"/api/v3/ticker/price?symbol=ETHUSDT",
+		}, recorder.requests())
+	})
+	t.Run("rejected batch request should fall back to single symbol requests", func(t *testing.T) {
+		t.Parallel()
+
+		recorder := &requestsRecorder{}
+		httpServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			recorder.add(req.URL.RequestURI())
+			if req.URL.Query().Get("symbols") != "" {
+				rw.WriteHeader(http.StatusBadRequest)
+				_, _ = rw.Write([]byte(`{"code":-1121,"msg":"Invalid symbol."}`))
+				return
+			}
+			if req.URL.Query().Get("symbol") != "BTCUSDT" {
+				rw.WriteHeader(http.StatusBadRequest)
+				_, _ = rw.Write([]byte(`{"code":-1121,"msg":"Invalid symbol."}`))
+				return
+			}
+
+			rw.WriteHeader(http.StatusOK)
+			_, _ = rw.Write([]byte(`{"symbol":"BTCUSDT","price":"62330.01000000"}`))
+		}))
+		defer httpServer.Close()
+
+		fetcher := createBinanceFetcher(t, httpServer.URL)
+		fetcher.AddPair("BTC", quoteUSDFiat)
+		fetcher.AddPair("XYZ", quoteUSDFiat)
+
+		for i := 0; i < 2; i++ {
+			results := fetcher.FetchPrices(context.Background(), []aggregator.BaseQuote{btcUSD, xyzUSD})
+			require.Nil(t, results[btcUSD].Err)
+			assert.Equal(t, 62330.01, results[btcUSD].Price)
+			assert.ErrorIs(t, results[xyzUSD].Err, aggregator.ErrUnexpectedHTTPStatus)
+		}
+
+		// the multi-symbol request is not sent again once rejected
+		numBatchRequests := 0
+		for _, uri := range recorder.requests() {
+			if strings.Contains(uri, "symbols=") {
+				numBatchRequests++
+			}
+		}
+		assert.Equal(t, 1, numBatchRequests)
+		assert.Equal(t, 5, len(recorder.requests()))
+	})
+}
@@ -8,9 +8,10 @@ import (
 )
 
 const (
-	bitfinexPriceUrl     = "https://api.bitfinex.com/v1/pubticker/%s%s"
-	bitfinexPriceLongUrl = "https://api.bitfinex.com/v1/pubticker/%s:%s"
-	maxBaseLength        = 3
+	bitfinexPriceUrl         = "https://api.bitfinex.com/v1/pubticker/%s"
+	bitfinexMarketFormat     = "%s%s"
+	bitfinexLongMarketFormat = "%s:%s"
+	maxBaseLength            = 3
 )
 
 type bitfinexPriceRequest struct {
@@ -28,14 +29,12 @@ func (b *bitfinex) FetchPrice(ctx context.Context, base, quote string) (float64,
 		return 0, aggregator.ErrPairNotSupported
 	}
 
-	quote = b.normalizeQuoteName(quote, BitfinexName)
+	quote = b.MappedQuote(quote)
+
+	market := b.marketID(base, quote, buildBitfinexMarketID)
 
-	priceUrl := bitfinexPriceUrl
-	if len(base) > maxBaseLength {
-		priceUrl = bitfinexPriceLongUrl
-	}
 	var bit bitfinexPriceRequest
-	err := b.ResponseGetter.Get(ctx, fmt.Sprintf(priceUrl, base, quote), &bit)
+	err := b.ResponseGetter.Get(ctx, fmt.Sprintf(bitfinexPriceUrl, market), &bit)
 	if err != nil {
 		return 0, err
 	}
@@ -45,6 +44,19 @@ func (b *bitfinex) FetchPrice(ctx context.Context, base, quote string) (float64,
 	return StrToPositiveFloat64(bit.Price)
 }
 
+func buildBitfinexMarketID(baseSymbol string, quoteSymbol string) string {
+	if len(baseSymbol) > maxBaseLength {
+		return fmt.Sprintf(bitfinexLongMarketFormat, baseSymbol, quoteSymbol)
+	}
+
+	return fmt.Sprintf(bitfinexMarketFormat, baseSymbol, quoteSymbol)
+}
+
+// FetchPrices fetches the prices of the provided pairs, with one request for each pair
+func (b *bitfinex) FetchPrices(ctx context.Context, pairs []aggregator.BaseQuote) map[aggregator.BaseQuote]aggregator.PriceResult {
+	return fetchPricesConcurrently(ctx, b.FetchPrice, pairs)
+}
+
 // Name returns the name
 func (b *bitfinex) Name() string {
 	return BitfinexName
@@ -0,0 +1,76 @@
+package fetchers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+)
+
+const (
+	bybitPriceUrl     = "https://api.bybit.com/v5/market/tickers?category=spot&symbol=%s"
+	bybitMarketFormat = "%s%s"
+	bybitSuccessCode  = 0
+)
+
+type bybitPriceRequest struct {
+	RetCode int         `json:"retCode"`
+	RetMsg  string      `json:"retMsg"`
+	Result  bybitResult `json:"result"`
+}
+
+type bybitResult struct {
+	List []bybitTicker `json:"list"`
+}
+
+type bybitTicker struct {
+	Symbol    string `json:"symbol"`
+	LastPrice string `json:"lastPrice"`
+}
+
+type bybit struct {
+	aggregator.ResponseGetter
+	baseFetcher
+}
+
+// FetchPrice will fetch the price using the http client
+func (b *bybit) FetchPrice(ctx context.Context, base string, quote string) (float64, error) {
+	if !b.hasPair(base, quote) {
+		return 0, aggregator.ErrPairNotSupported
+	}
+
+	quote = b.MappedQuote(quote)
+	market := b.marketID(base, quote, formatMarketID(bybitMarketFormat))
+
+	var bpr bybitPriceRequest
+	err := b.ResponseGetter.Get(ctx, fmt.Sprintf(bybitPriceUrl, market), &bpr)
+	if err != nil {
+		return 0, err
+	}
+	if bpr.RetCode != bybitSuccessCode {
+		return 0, fmt.Errorf("%w, code: %d, message: %s", errInvalidResponseData, bpr.RetCode, bpr.RetMsg)
+	}
+	if len(bpr.Result.List) == 0 {
+		return 0, errInvalidResponseData
+	}
+	if bpr.Result.List[0].LastPrice == "" {
+		return 0, errInvalidResponseData
+	}
+
+	return StrToPositiveFloat64(bpr.Result.List[0].LastPrice)
+}
+
+// FetchPrices fetches the prices of the provided pairs, with one request for each pair
+func (b *bybit) FetchPrices(ctx context.Context, pairs []aggregator.BaseQuote) map[aggregator.BaseQuote]aggregator.PriceResult {
+	return fetchPricesConcurrently(ctx, b.FetchPrice, pairs)
+}
+
+// Name returns the name
+func (b *bybit) Name() string {
+	return BybitName
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (b *bybit) IsInterfaceNil() bool {
+	return b == nil
+}
@@ -0,0 +1,130 @@
+package fetchers
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// btcUsdtCassette holds two polling rounds captured from the exchanges for the BTC/USDT pair. In the second round
+// KuCoin rejected the request with an error code and HTX answered with a 503
+const btcUsdtCassette = "testdata/btc_usdt_cassette.jsonl"
+
+// evmGasCassette holds the JSON-RPC batches posted to an EVM node. The second MAXFEE batch got a JSON-RPC error for
+// the priority fee call and the third one a 503
+const evmGasCassette = "testdata/evm_gas_cassette.jsonl"
+
+func createReplayResponseGetter(t *testing.T, cassette string) aggregator.ResponseGetter {
+	file, err := os.Open(cassette)
+	require.Nil(t, err)
+	defer func() {
+		_ = file.Close()
+	}()
+
+	replayResponseGetter, err := aggregator.NewReplayResponseGetter(file)
+	require.Nil(t, err)
+
+	return replayResponseGetter
+}
+
+func createCassetteFetchers(t *testing.T, cassette string, exchanges []string) []aggregator.PriceFetcher {
+	replayResponseGetter := createReplayResponseGetter(t, cassette)
+
+	priceFetchers := make([]aggregator.PriceFetcher, 0, len(exchanges))
+	for _, exchange := range exchanges {
+		args := createMockArgsPriceFetcher()
+		args.FetcherName = exchange
+		args.ResponseGetter = replayResponseGetter
+		priceFetcher, errCreate := NewPriceFetcher(args)
+		require.Nil(t, errCreate)
+
+		priceFetcher.AddPair("BTC", "USDT")
+		priceFetchers = append(priceFetchers, priceFetcher)
+	}
+
+	return priceFetchers
+}
+
+func TestCassette_FetchersShouldParseTheRecordedResponses(t *testing.T) {
+	t.Parallel()
+
+	exchanges := []string{BybitName, KucoinName, GateioName, HTXName, MexcName}
+	priceFetchers := createCassetteFetchers(t, btcUsdtCassette, exchanges)
+
+	expectedFirstRound := []float64{62325.9, 62340.2, 62338.1, 62340.01, 62343.99}
+	for i, priceFetcher := range priceFetchers {
+		price, err := priceFetcher.FetchPrice(context.Background(), "BTC", "USDT")
+		require.Nil(t, err, priceFetcher.Name())
+		assert.Equal(t, expectedFirstRound[i], price, priceFetcher.Name())
+	}
+
+	_, err := priceFetchers[1].FetchPrice(context.Background(), "BTC", "USDT")
+	assert.True(t, errors.Is(err, errInvalidResponseData))
+	assert.Contains(t, err.Error(), "429000")
+
+	_, err = priceFetchers[3].FetchPrice(context.Background(), "BTC", "USDT")
+	assert.True(t, errors.Is(err, aggregator.ErrUnexpectedHTTPStatus))
+}
+
+func TestCassette_ShouldReplayThePriceAggregatorRounds(t *testing.T) {
+	t.Parallel()
+
+	exchanges := []string{BybitName, KucoinName, GateioName, HTXName, MexcName}
+	priceAggregator, err := aggregator.NewPriceAggregator(aggregator.ArgsPriceAggregator{
+		PriceFetchers: createCassetteFetchers(t, btcUsdtCassette, exchanges),
+		MinResultsNum: 3,
+	})
+	require.Nil(t, err)
+
+	price, err := priceAggregator.FetchPrice(context.Background(), "BTC", "USDT")
+	require.Nil(t, err)
+	assert.Equal(t, 62340.01, price)
+
+	price, err = priceAggregator.FetchPrice(context.Background(), "BTC", "USDT")
+	require.Nil(t, err)
+	assert.Equal(t, 62407.2, price)
+
+	// the cassette is exhausted
+	price, err = priceAggregator.FetchPrice(context.Background(), "BTC", "USDT")
+	assert.Equal(t, aggregator.ErrNotEnoughResponses, err)
+	assert.Zero(t, price)
+}
+
+func TestCassette_EVMJSONRPCFetcherShouldParseTheRecordedBatches(t *testing.T) {
+	t.Parallel()
+
+	fetcher, err := NewPriceFetcher(ArgsPriceFetcher{
+		FetcherName:    EVMJSONRPCName,
+		ResponseGetter: createReplayResponseGetter(t, evmGasCassette),
+		EVMJSONRPCConfig: EVMJSONRPCGasFetcherConfig{
+			NodeURL:  "https://ethereum-rpc.publicnode.com",
+			Selector: MaxFeeFeed,
+		},
+	})
+	require.Nil(t, err)
+
+	price, err := fetcher.FetchPrice(context.Background(), MaxFeeFeed, gweiTicker)
+	require.Nil(t, err)
+	assert.InDelta(t, 32, price, 1e-9)
+
+	// the batches are matched on their request body, so the gas price one is served regardless of the order
+	price, err = fetcher.FetchPrice(context.Background(), GasPriceFeed, gweiTicker)
+	require.Nil(t, err)
+	assert.InDelta(t, 20, price, 1e-9)
+
+	_, err = fetcher.FetchPrice(context.Background(), MaxFeeFeed, gweiTicker)
+	assert.True(t, errors.Is(err, errJSONRPC))
+	assert.Contains(t, err.Error(), "eth_maxPriorityFeePerGas does not exist")
+
+	_, err = fetcher.FetchPrice(context.Background(), MaxFeeFeed, gweiTicker)
+	assert.True(t, errors.Is(err, aggregator.ErrUnexpectedHTTPStatus))
+
+	// no batch was recorded for the base fee feed
+	_, err = fetcher.FetchPrice(context.Background(), BaseFeeFeed, gweiTicker)
+	assert.True(t, errors.Is(err, aggregator.ErrNoRecordedResponse))
+}
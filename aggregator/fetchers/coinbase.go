@@ -0,0 +1,63 @@
+package fetchers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+)
+
+const (
+	coinbasePriceUrl     = "https://api.exchange.coinbase.com/products/%s/ticker"
+	coinbaseMarketFormat = "%s-%s"
+)
+
+type coinbasePriceRequest struct {
+	Price   string `json:"price"`
+	Message string `json:"message"`
+}
+
+type coinbase struct {
+	aggregator.ResponseGetter
+	baseFetcher
+}
+
+// FetchPrice will fetch the price using the http client
+func (c *coinbase) FetchPrice(ctx context.Context, base string, quote string) (float64, error) {
+	if !c.hasPair(base, quote) {
+		return 0, aggregator.ErrPairNotSupported
+	}
+
+	quote = c.MappedQuote(quote)
+	market := c.marketID(base, quote, formatMarketID(coinbaseMarketFormat))
+
+	var cpr coinbasePriceRequest
+	err := c.ResponseGetter.Get(ctx, fmt.Sprintf(coinbasePriceUrl, market), &cpr)
+	// the errors come with a 4xx status code, described in the body
+	if err != nil && !(decodeErrorResponse(err, &cpr) && cpr.Message != "") {
+		return 0, err
+	}
+	if cpr.Message != "" {
+		return 0, fmt.Errorf("%w, message: %s", errInvalidResponseData, cpr.Message)
+	}
+	if cpr.Price == "" {
+		return 0, errInvalidResponseData
+	}
+
+	return StrToPositiveFloat64(cpr.Price)
+}
+
+// FetchPrices fetches the prices of the provided pairs, with one request for each pair
+func (c *coinbase) FetchPrices(ctx context.Context, pairs []aggregator.BaseQuote) map[aggregator.BaseQuote]aggregator.PriceResult {
+	return fetchPricesConcurrently(ctx, c.FetchPrice, pairs)
+}
+
+// Name returns the name
+func (c *coinbase) Name() string {
+	return CoinbaseName
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (c *coinbase) IsInterfaceNil() bool {
+	return c == nil
+}
@@ -2,38 +2,72 @@ package fetchers
 
 const (
 	quoteUSDFiat = "USD"
-	quoteUSDT    = "USDT"
 
 	// BinanceName defines the Binance exchange name
 	BinanceName = "Binance"
 	// BitfinexName defines the Bitfinex exchange name
 	BitfinexName = "Bitfinex"
+	// BybitName defines the Bybit exchange name
+	BybitName = "Bybit"
+	// CoinbaseName defines the Coinbase Exchange name
+	CoinbaseName = "Coinbase"
 	// CryptocomName defines the crypto.com exchange name
 	CryptocomName = "Crypto.com"
+	// GateioName defines the Gate.io exchange name
+	GateioName = "Gate.io"
 	// GeminiName defines the Gemini exchange name
 	GeminiName = "Gemini"
 	// HitbtcName defines the HitBTC exchange name
 	HitbtcName = "HitBTC"
-	// HuobiName defines the Huobi exchange name
+	// HTXName defines the HTX exchange name
+	HTXName = "HTX"
+	// HuobiName is the former name of the HTX exchange, still accepted in configs
 	HuobiName = "Huobi"
 	// KrakenName defines the Kraken exchange name
 	KrakenName = "Kraken"
+	// KucoinName defines the KuCoin exchange name
+	KucoinName = "KuCoin"
+	// MexcName defines the MEXC exchange name
+	MexcName = "MEXC"
 	// OkxName defines the Okx exchange name
 	OkxName = "Okx"
 	// XExchangeName defines the XExchange name
 	XExchangeName = "XExchange"
+	// RestJSONFetcherName defines a declarative fetcher built from config, named by its RestJSONFetcherConfig
+	RestJSONFetcherName = "REST JSON fetcher"
 	// EVMGasPriceStation defines an EVM gas station that will push gas prices as a full token pair price
 	EVMGasPriceStation = "EVM gas price station"
+	// EVMJSONRPCName defines an EVM node queried over JSON-RPC for the gas prices, as an alternative to the gas station
+	EVMJSONRPCName = "EVM JSON-RPC"
 )
 
 // ImplementedFetchers is the map of all implemented exchange fetchers
 var ImplementedFetchers = map[string]struct{}{
 	BinanceName:   {},
 	BitfinexName:  {},
+	BybitName:     {},
+	CoinbaseName:  {},
 	CryptocomName: {},
+	GateioName:    {},
 	GeminiName:    {},
 	HitbtcName:    {},
-	HuobiName:     {},
+	HTXName:       {},
 	KrakenName:    {},
+	KucoinName:    {},
+	MexcName:      {},
 	OkxName:       {},
 }
+
+var renamedFetchers = map[string]string{
+	HuobiName: HTXName,
+}
+
+// CurrentExchangeName returns the current name of an exchange that was renamed, or the provided name otherwise
+func CurrentExchangeName(name string) string {
+	currentName, renamed := renamedFetchers[name]
+	if renamed {
+		return currentName
+	}
+
+	return name
+}
@@ -0,0 +1,16 @@
+package fetchers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurrentExchangeName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, HTXName, CurrentExchangeName(HuobiName))
+	assert.Equal(t, HTXName, CurrentExchangeName(HTXName))
+	assert.Equal(t, BinanceName, CurrentExchangeName(BinanceName))
+	assert.Equal(t, "unknown", CurrentExchangeName("unknown"))
+}
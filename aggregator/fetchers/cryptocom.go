@@ -8,7 +8,8 @@ import (
 )
 
 const (
-	cryptocomPriceUrl = "https://api.crypto.com/v2/public/get-ticker?instrument_name=%s_%s"
+	cryptocomPriceUrl     = "https://api.crypto.com/v2/public/get-ticker?instrument_name=%s"
+	cryptocomMarketFormat = "%s_%s"
 )
 
 type cryptocomPriceRequest struct {
@@ -34,10 +35,12 @@ func (c *cryptocom) FetchPrice(ctx context.Context, base, quote string) (float64
 		return 0, aggregator.ErrPairNotSupported
 	}
 
-	quote = c.normalizeQuoteName(quote, CryptocomName)
+	quote = c.MappedQuote(quote)
+
+	market := c.marketID(base, quote, formatMarketID(cryptocomMarketFormat))
 
 	var cpr cryptocomPriceRequest
-	err := c.ResponseGetter.Get(ctx, fmt.Sprintf(cryptocomPriceUrl, base, quote), &cpr)
+	err := c.ResponseGetter.Get(ctx, fmt.Sprintf(cryptocomPriceUrl, market), &cpr)
 	if err != nil {
 		return 0, err
 	}
@@ -50,6 +53,11 @@ func (c *cryptocom) FetchPrice(ctx context.Context, base, quote string) (float64
 	return StrToPositiveFloat64(cpr.Result.Data[0].Price)
 }
 
+// FetchPrices fetches the prices of the provided pairs, with one request for each pair
+func (c *cryptocom) FetchPrices(ctx context.Context, pairs []aggregator.BaseQuote) map[aggregator.BaseQuote]aggregator.PriceResult {
+	return fetchPricesConcurrently(ctx, c.FetchPrice, pairs)
+}
+
 // Name returns the name
 func (c *cryptocom) Name() string {
 	return CryptocomName
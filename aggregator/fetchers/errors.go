@@ -8,4 +8,9 @@ var (
 	errNilResponseGetter       = errors.New("nil response getter")
 	errInvalidPair             = errors.New("invalid pair")
 	errInvalidGasPriceSelector = errors.New("invalid gas price selector")
+	errInvalidRestJSONConfig   = errors.New("invalid REST JSON fetcher config")
+	errInvalidEVMJSONRPCConfig = errors.New("invalid EVM JSON-RPC fetcher config")
+	errJSONRPC                 = errors.New("JSON-RPC error")
+	errStalePrice              = errors.New("stale price")
+	errLowVolume               = errors.New("low volume")
 )
@@ -65,6 +65,11 @@ func (fetcher *evmGasPriceFetcher) FetchPrice(ctx context.Context, base string,
 	return latestGasPrice, err
 }
 
+// FetchPrices fetches the prices of the provided pairs, with one request for each pair
+func (fetcher *evmGasPriceFetcher) FetchPrices(ctx context.Context, pairs []aggregator.BaseQuote) map[aggregator.BaseQuote]aggregator.PriceResult {
+	return fetchPricesConcurrently(ctx, fetcher.FetchPrice, pairs)
+}
+
 // Name returns the name
 func (fetcher *evmGasPriceFetcher) Name() string {
 	return fmt.Sprintf("%s when using selector %s", EVMGasPriceStation, fetcher.config.Selector)
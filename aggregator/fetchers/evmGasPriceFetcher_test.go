@@ -17,7 +17,7 @@ func createMockEVMGasPriceFetcher() *evmGasPriceFetcher {
 			ApiURL:   "api-url",
 			Selector: "SafeGasPrice",
 		},
-		baseFetcher: newBaseFetcher(),
+		baseFetcher: newBaseFetcher(nil, SymbolMapping{}),
 	}
 }
 
@@ -0,0 +1,368 @@
+package fetchers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+	"github.com/klever-io/klv-oracles-go/aggregator/stats"
+)
+
+const (
+	gweiTicker              = "GWEI"
+	weiInGwei               = 1e9
+	jsonRPCVersion          = "2.0"
+	defaultFeeHistoryBlocks = 20
+	maxFeeHistoryBlocks     = 1024
+
+	methodGasPrice             = "eth_gasPrice"
+	methodFeeHistory           = "eth_feeHistory"
+	methodMaxPriorityFeePerGas = "eth_maxPriorityFeePerGas"
+
+	// GasPriceFeed is the legacy gas price returned by eth_gasPrice
+	GasPriceFeed = "GASPRICE"
+	// BaseFeeFeed is the base fee of the next block
+	BaseFeeFeed = "BASEFEE"
+	// PriorityFeeFeed is the priority fee suggested by eth_maxPriorityFeePerGas
+	PriorityFeeFeed = "PRIORITYFEE"
+	// PriorityFee25Feed is the median, over the fee history blocks, of the 25th percentile of the priority fees
+	PriorityFee25Feed = "PRIORITYFEE25"
+	// PriorityFee50Feed is the median, over the fee history blocks, of the 50th percentile of the priority fees
+	PriorityFee50Feed = "PRIORITYFEE50"
+	// PriorityFee75Feed is the median, over the fee history blocks, of the 75th percentile of the priority fees
+	PriorityFee75Feed = "PRIORITYFEE75"
+	// MaxFeeFeed is the max fee per gas of a transaction still included if the base fee doubles: twice the base fee
+	// plus the suggested priority fee
+	MaxFeeFeed = "MAXFEE"
+)
+
+// the order matches the percentile feeds
+var rewardPercentiles = []float64{25, 50, 75}
+
+var methodsOfFeeds = map[string][]string{
+	GasPriceFeed:      {methodGasPrice},
+	BaseFeeFeed:       {methodFeeHistory},
+	PriorityFeeFeed:   {methodMaxPriorityFeePerGas},
+	PriorityFee25Feed: {methodFeeHistory},
+	PriorityFee50Feed: {methodFeeHistory},
+	PriorityFee75Feed: {methodFeeHistory},
+	MaxFeeFeed:        {methodFeeHistory, methodMaxPriorityFeePerGas},
+}
+
+// EVMJSONRPCGasFetcherConfig represents the config DTO used for the gas fetcher querying an EVM node
+type EVMJSONRPCGasFetcherConfig struct {
+	NodeURL string
+	// Selector is the feed returned for the GWEI pairs, the ones converted afterwards by the gas price service
+	Selector string
+	// FeeHistoryBlocks is the number of blocks the priority fee percentiles are computed on, 20 when 0
+	FeeHistoryBlocks uint64
+}
+
+type jsonRPCRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int           `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type jsonRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type jsonRPCResponse struct {
+	ID     int             `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *jsonRPCError   `json:"error"`
+}
+
+type feeHistoryResult struct {
+	BaseFeePerGas []string   `json:"baseFeePerGas"`
+	Reward        [][]string `json:"reward"`
+}
+
+// gasFees holds the fees in GWEI
+type gasFees struct {
+	gasPrice     float64
+	baseFee      float64
+	priorityFee  float64
+	percentiles  []float64
+	fetchedFeeds map[string]struct{}
+}
+
+type evmJSONRPCGasFetcher struct {
+	aggregator.ResponsePoster
+	config EVMJSONRPCGasFetcherConfig
+	baseFetcher
+}
+
+func newEVMJSONRPCGasFetcher(args ArgsPriceFetcher) (*evmJSONRPCGasFetcher, error) {
+	poster, ok := args.ResponseGetter.(aggregator.ResponsePoster)
+	if !ok {
+		return nil, fmt.Errorf("%w, fetcher %s", aggregator.ErrPostNotSupported, EVMJSONRPCName)
+	}
+
+	config := args.EVMJSONRPCConfig
+	if len(config.NodeURL) == 0 {
+		return nil, fmt.Errorf("%w, empty node URL", errInvalidEVMJSONRPCConfig)
+	}
+	if _, found := methodsOfFeeds[config.Selector]; !found {
+		return nil, fmt.Errorf("%w: %q", errInvalidGasPriceSelector, config.Selector)
+	}
+	if config.FeeHistoryBlocks == 0 {
+		config.FeeHistoryBlocks = defaultFeeHistoryBlocks
+	}
+	if config.FeeHistoryBlocks > maxFeeHistoryBlocks {
+		return nil, fmt.Errorf("%w, fee history blocks %d, maximum %d", errInvalidEVMJSONRPCConfig,
+			config.FeeHistoryBlocks, maxFeeHistoryBlocks)
+	}
+
+	return &evmJSONRPCGasFetcher{
+		ResponsePoster: poster,
+		config:         config,
+		baseFetcher:    newBaseFetcher(args.QuoteMappings, args.SymbolMapping),
+	}, nil
+}
+
+// FetchPrice returns, in GWEI, the gas feed named by the base. The GWEI base returns the configured selector feed,
+// to be converted by the gas price service in the quote currency
+func (fetcher *evmJSONRPCGasFetcher) FetchPrice(ctx context.Context, base string, quote string) (float64, error) {
+	feed, err := fetcher.feedOf(base, quote)
+	if err != nil {
+		return 0, err
+	}
+
+	fees, err := fetcher.fetchFees(ctx, []string{feed})
+	if err != nil {
+		return 0, err
+	}
+
+	return fees.value(feed)
+}
+
+// FetchPrices fetches the gas feeds of the provided pairs with a single batch request
+func (fetcher *evmJSONRPCGasFetcher) FetchPrices(ctx context.Context, pairs []aggregator.BaseQuote) map[aggregator.BaseQuote]aggregator.PriceResult {
+	results := make(map[aggregator.BaseQuote]aggregator.PriceResult, len(pairs))
+	feeds := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		feed, err := fetcher.feedOf(pair.Base, pair.Quote)
+		if err != nil {
+			results[pair] = aggregator.PriceResult{Err: err}
+			continue
+		}
+		feeds = append(feeds, feed)
+	}
+	if len(feeds) == 0 {
+		return results
+	}
+
+	fees, err := fetcher.fetchFees(ctx, feeds)
+	for _, pair := range pairs {
+		if _, found := results[pair]; found {
+			continue
+		}
+		if err != nil {
+			results[pair] = aggregator.PriceResult{Err: err}
+			continue
+		}
+
+		feed, _ := fetcher.feedOf(pair.Base, pair.Quote)
+		price, errValue := fees.value(feed)
+		results[pair] = aggregator.PriceResult{Price: price, Err: errValue}
+	}
+
+	return results
+}
+
+func (fetcher *evmJSONRPCGasFetcher) feedOf(base string, quote string) (string, error) {
+	if base == gweiTicker {
+		return fetcher.config.Selector, nil
+	}
+	if quote != gweiTicker {
+		return "", aggregator.ErrPairNotSupported
+	}
+	if _, found := methodsOfFeeds[base]; !found {
+		return "", aggregator.ErrPairNotSupported
+	}
+
+	return base, nil
+}
+
+// fetchFees sends, in a single batch request, only the calls needed by the provided feeds
+func (fetcher *evmJSONRPCGasFetcher) fetchFees(ctx context.Context, feeds []string) (*gasFees, error) {
+	requests := make([]jsonRPCRequest, 0, len(methodsOfFeeds))
+	addedMethods := make(map[string]struct{})
+	for _, feed := range feeds {
+		for _, method := range methodsOfFeeds[feed] {
+			if _, found := addedMethods[method]; found {
+				continue
+			}
+			addedMethods[method] = struct{}{}
+			requests = append(requests, fetcher.createRequest(len(requests)+1, method))
+		}
+	}
+
+	responses := make([]jsonRPCResponse, 0, len(requests))
+	err := fetcher.ResponsePoster.Post(ctx, fetcher.config.NodeURL, requests, &responses)
+	if err != nil {
+		return nil, err
+	}
+
+	responsesByID := make(map[int]jsonRPCResponse, len(responses))
+	for _, response := range responses {
+		responsesByID[response.ID] = response
+	}
+
+	fees := &gasFees{
+		fetchedFeeds: make(map[string]struct{}),
+	}
+	for _, request := range requests {
+		response, found := responsesByID[request.ID]
+		if !found {
+			return nil, fmt.Errorf("%w, no response for %s", errInvalidResponseData, request.Method)
+		}
+		if response.Error != nil {
+			return nil, fmt.Errorf("%w, %s returned code %d: %s", errJSONRPC, request.Method,
+				response.Error.Code, response.Error.Message)
+		}
+
+		err = fees.parse(request.Method, response.Result)
+		if err != nil {
+			return nil, fmt.Errorf("%w while parsing the %s response", err, request.Method)
+		}
+	}
+	for _, feed := range feeds {
+		fees.fetchedFeeds[feed] = struct{}{}
+	}
+
+	return fees, nil
+}
+
+func (fetcher *evmJSONRPCGasFetcher) createRequest(id int, method string) jsonRPCRequest {
+	params := make([]interface{}, 0)
+	if method == methodFeeHistory {
+		params = append(params, fmt.Sprintf("0x%x", fetcher.config.FeeHistoryBlocks), "latest", rewardPercentiles)
+	}
+
+	return jsonRPCRequest{
+		JSONRPC: jsonRPCVersion,
+		ID:      id,
+		Method:  method,
+		Params:  params,
+	}
+}
+
+func (fees *gasFees) parse(method string, result json.RawMessage) error {
+	switch method {
+	case methodGasPrice:
+		return parseQuantityInGwei(result, &fees.gasPrice)
+	case methodMaxPriorityFeePerGas:
+		return parseQuantityInGwei(result, &fees.priorityFee)
+	default:
+		return fees.parseFeeHistory(result)
+	}
+}
+
+func (fees *gasFees) parseFeeHistory(result json.RawMessage) error {
+	history := &feeHistoryResult{}
+	err := json.Unmarshal(result, history)
+	if err != nil {
+		return err
+	}
+	// the last base fee is the one of the next block
+	if len(history.BaseFeePerGas) == 0 || len(history.Reward) == 0 {
+		return errInvalidResponseData
+	}
+
+	fees.baseFee, err = hexToGwei(history.BaseFeePerGas[len(history.BaseFeePerGas)-1])
+	if err != nil {
+		return err
+	}
+
+	fees.percentiles = make([]float64, len(rewardPercentiles))
+	for idx := range rewardPercentiles {
+		rewards := make([]float64, 0, len(history.Reward))
+		for _, blockRewards := range history.Reward {
+			if len(blockRewards) != len(rewardPercentiles) {
+				return errInvalidResponseData
+			}
+			reward, errReward := hexToGwei(blockRewards[idx])
+			if errReward != nil {
+				return errReward
+			}
+			rewards = append(rewards, reward)
+		}
+		fees.percentiles[idx], err = stats.Median(rewards)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (fees *gasFees) value(feed string) (float64, error) {
+	if _, found := fees.fetchedFeeds[feed]; !found {
+		return 0, aggregator.ErrPairNotSupported
+	}
+
+	switch feed {
+	case GasPriceFeed:
+		return fees.gasPrice, nil
+	case BaseFeeFeed:
+		return fees.baseFee, nil
+	case PriorityFeeFeed:
+		return fees.priorityFee, nil
+	case PriorityFee25Feed:
+		return fees.percentiles[0], nil
+	case PriorityFee50Feed:
+		return fees.percentiles[1], nil
+	case PriorityFee75Feed:
+		return fees.percentiles[2], nil
+	default:
+		return 2*fees.baseFee + fees.priorityFee, nil
+	}
+}
+
+func parseQuantityInGwei(result json.RawMessage, value *float64) error {
+	var quantity string
+	err := json.Unmarshal(result, &quantity)
+	if err != nil {
+		return err
+	}
+
+	*value, err = hexToGwei(quantity)
+
+	return err
+}
+
+// hexToGwei converts a JSON-RPC quantity, an amount of wei hex encoded, to GWEI
+func hexToGwei(quantity string) (float64, error) {
+	digits := strings.TrimPrefix(quantity, "0x")
+	wei, ok := big.NewInt(0).SetString(digits, 16)
+	if !ok || len(digits) == len(quantity) {
+		return 0, fmt.Errorf("%w, invalid quantity %q", errInvalidResponseData, quantity)
+	}
+
+	weiAsFloat, _ := new(big.Float).SetInt(wei).Float64()
+
+	return weiAsFloat / weiInGwei, nil
+}
+
+// Name returns the name
+func (fetcher *evmJSONRPCGasFetcher) Name() string {
+	return fmt.Sprintf("%s when using selector %s", EVMJSONRPCName, fetcher.config.Selector)
+}
+
+// ProvidesGasFeeds returns true as the fee feeds, like the base fee, are fetched next to the gas price
+func (fetcher *evmJSONRPCGasFetcher) ProvidesGasFeeds() bool {
+	return true
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (fetcher *evmJSONRPCGasFetcher) IsInterfaceNil() bool {
+	return fetcher == nil
+}
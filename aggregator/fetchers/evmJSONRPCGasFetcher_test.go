@@ -0,0 +1,262 @@
+package fetchers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+	"github.com/klever-io/klv-oracles-go/aggregator/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jsonRPCStub is a local EVM node answering the gas related JSON-RPC calls, single or batched
+type jsonRPCStub struct {
+	*httptest.Server
+	mut          sync.Mutex
+	results      map[string]interface{}
+	errorMethod  string
+	batches      [][]string
+	feeHistoryIn []interface{}
+}
+
+func newJSONRPCStub() *jsonRPCStub {
+	stub := &jsonRPCStub{
+		results: map[string]interface{}{
+			methodGasPrice:             "0x4a817c800", // 20 GWEI
+			methodMaxPriorityFeePerGas: "0x77359400",  // 2 GWEI
+			methodFeeHistory: map[string]interface{}{
+				"oldestBlock":   "0x10",
+				"baseFeePerGas": []string{"0x2540be400", "0x2e90edd00", "0x37e11d600"}, // 10, 12.5, 15 GWEI
+				"gasUsedRatio":  []float64{0.5, 0.9},
+				"reward": [][]string{
+					{"0x3b9aca00", "0x77359400", "0xb2d05e00"},  // 1, 2, 3 GWEI
+					{"0x77359400", "0xee6b2800", "0x165a0bc00"}, // 2, 4, 6 GWEI
+					{"0x5f5e100", "0x3b9aca00", "0x77359400"},   // 0.1, 1, 2 GWEI
+				},
+			},
+		},
+	}
+	stub.Server = httptest.NewServer(http.HandlerFunc(stub.serve))
+
+	return stub
+}
+
+func (stub *jsonRPCStub) serve(rw http.ResponseWriter, req *http.Request) {
+	requests := make([]jsonRPCRequest, 0)
+	err := json.NewDecoder(req.Body).Decode(&requests)
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	stub.mut.Lock()
+	defer stub.mut.Unlock()
+
+	methods := make([]string, 0, len(requests))
+	responses := make([]map[string]interface{}, 0, len(requests))
+	// the responses of a batch can be sent in any order
+	for idx := len(requests) - 1; idx >= 0; idx-- {
+		request := requests[idx]
+		methods = append([]string{request.Method}, methods...)
+		response := map[string]interface{}{"jsonrpc": jsonRPCVersion, "id": request.ID}
+		if request.Method == stub.errorMethod {
+			response["error"] = map[string]interface{}{"code": -32601, "message": "method not available"}
+		} else {
+			response["result"] = stub.results[request.Method]
+		}
+		if request.Method == methodFeeHistory {
+			stub.feeHistoryIn = request.Params
+		}
+		responses = append(responses, response)
+	}
+	stub.batches = append(stub.batches, methods)
+
+	_ = json.NewEncoder(rw).Encode(responses)
+}
+
+func (stub *jsonRPCStub) sentBatches() [][]string {
+	stub.mut.Lock()
+	defer stub.mut.Unlock()
+
+	return stub.batches
+}
+
+func (stub *jsonRPCStub) feeHistoryParams() []interface{} {
+	stub.mut.Lock()
+	defer stub.mut.Unlock()
+
+	return stub.feeHistoryIn
+}
+
+func createEVMJSONRPCGasFetcher(t *testing.T, nodeURL string) *evmJSONRPCGasFetcher {
+	responseGetter, err := aggregator.NewHttpResponseGetter(aggregator.ArgsHttpResponseGetter{
+		Timeout: time.Second,
+	})
+	require.Nil(t, err)
+
+	fetcher, err := newEVMJSONRPCGasFetcher(ArgsPriceFetcher{
+		FetcherName:    EVMJSONRPCName,
+		ResponseGetter: responseGetter,
+		EVMJSONRPCConfig: EVMJSONRPCGasFetcherConfig{
+			NodeURL:  nodeURL,
+			Selector: MaxFeeFeed,
+		},
+	})
+	require.Nil(t, err)
+
+	return fetcher
+}
+
+func TestNewEVMJSONRPCGasFetcher(t *testing.T) {
+	t.Parallel()
+
+	responseGetter, _ := aggregator.NewHttpResponseGetter(aggregator.DefaultArgsHttpResponseGetter())
+	args := ArgsPriceFetcher{
+		FetcherName:    EVMJSONRPCName,
+		ResponseGetter: responseGetter,
+		EVMJSONRPCConfig: EVMJSONRPCGasFetcherConfig{
+			NodeURL:  "http://localhost:8545",
+			Selector: BaseFeeFeed,
+		},
+	}
+
+	fetcher, err := NewPriceFetcher(args)
+	require.Nil(t, err)
+	assert.Equal(t, "EVM JSON-RPC when using selector BASEFEE", fetcher.Name())
+	assert.Equal(t, uint64(defaultFeeHistoryBlocks), fetcher.(*evmJSONRPCGasFetcher).config.FeeHistoryBlocks)
+
+	invalidArgs := args
+	invalidArgs.ResponseGetter = &mock.HttpResponseGetterStub{}
+	_, err = NewPriceFetcher(invalidArgs)
+	assert.True(t, errors.Is(err, aggregator.ErrPostNotSupported))
+
+	invalidArgs = args
+	invalidArgs.EVMJSONRPCConfig.NodeURL = ""
+	_, err = NewPriceFetcher(invalidArgs)
+	assert.True(t, errors.Is(err, errInvalidEVMJSONRPCConfig))
+
+	invalidArgs = args
+	invalidArgs.EVMJSONRPCConfig.Selector = "SafeGasPrice"
+	_, err = NewPriceFetcher(invalidArgs)
+	assert.True(t, errors.Is(err, errInvalidGasPriceSelector))
+
+	invalidArgs = args
+	invalidArgs.EVMJSONRPCConfig.FeeHistoryBlocks = maxFeeHistoryBlocks + 1
+	_, err = NewPriceFetcher(invalidArgs)
+	assert.True(t, errors.Is(err, errInvalidEVMJSONRPCConfig))
+}
+
+func TestEVMJSONRPCGasFetcher_FetchPrice(t *testing.T) {
+	t.Parallel()
+
+	t.Run("each feed should only send the calls it needs", func(t *testing.T) {
+		t.Parallel()
+
+		node := newJSONRPCStub()
+		defer node.Close()
+		fetcher := createEVMJSONRPCGasFetcher(t, node.URL)
+
+		expectedFeeds := map[string]float64{
+			GasPriceFeed:      20,
+			BaseFeeFeed:       15,
+			PriorityFeeFeed:   2,
+			PriorityFee25Feed: 1,
+			PriorityFee50Feed: 2,
+			PriorityFee75Feed: 3,
+			MaxFeeFeed:        32,
+		}
+		for feed, expectedValue := range expectedFeeds {
+			value, err := fetcher.FetchPrice(context.Background(), feed, gweiTicker)
+			require.Nil(t, err, feed)
+			assert.InDelta(t, expectedValue, value, 1e-9, feed)
+		}
+
+		// the GWEI pairs, converted by the gas price service, get the selector feed
+		value, err := fetcher.FetchPrice(context.Background(), gweiTicker, "USD")
+		require.Nil(t, err)
+		assert.InDelta(t, 32, value, 1e-9)
+
+		for _, batch := range node.sentBatches() {
+			assert.LessOrEqual(t, len(batch), 2)
+		}
+		assert.Equal(t, []interface{}{"0x14", "latest", []interface{}{25.0, 50.0, 75.0}}, node.feeHistoryParams())
+	})
+	t.Run("unknown feed should error", func(t *testing.T) {
+		t.Parallel()
+
+		fetcher := createEVMJSONRPCGasFetcher(t, "http://localhost:0")
+
+		_, err := fetcher.FetchPrice(context.Background(), "ETH", "USD")
+		assert.Equal(t, aggregator.ErrPairNotSupported, err)
+		_, err = fetcher.FetchPrice(context.Background(), "UNKNOWN", gweiTicker)
+		assert.Equal(t, aggregator.ErrPairNotSupported, err)
+	})
+	t.Run("JSON-RPC error should error", func(t *testing.T) {
+		t.Parallel()
+
+		node := newJSONRPCStub()
+		defer node.Close()
+		node.errorMethod = methodMaxPriorityFeePerGas
+		fetcher := createEVMJSONRPCGasFetcher(t, node.URL)
+
+		_, err := fetcher.FetchPrice(context.Background(), MaxFeeFeed, gweiTicker)
+		assert.True(t, errors.Is(err, errJSONRPC))
+		assert.Contains(t, err.Error(), "method not available")
+
+		value, err := fetcher.FetchPrice(context.Background(), BaseFeeFeed, gweiTicker)
+		require.Nil(t, err)
+		assert.InDelta(t, 15, value, 1e-9)
+	})
+	t.Run("invalid quantity should error", func(t *testing.T) {
+		t.Parallel()
+
+		node := newJSONRPCStub()
+		defer node.Close()
+		node.results[methodGasPrice] = "1234"
+		fetcher := createEVMJSONRPCGasFetcher(t, node.URL)
+
+		_, err := fetcher.FetchPrice(context.Background(), GasPriceFeed, gweiTicker)
+		assert.True(t, errors.Is(err, errInvalidResponseData))
+	})
+	t.Run("empty fee history should error", func(t *testing.T) {
+		t.Parallel()
+
+		node := newJSONRPCStub()
+		defer node.Close()
+		node.results[methodFeeHistory] = map[string]interface{}{"baseFeePerGas": []string{}, "reward": [][]string{}}
+		fetcher := createEVMJSONRPCGasFetcher(t, node.URL)
+
+		_, err := fetcher.FetchPrice(context.Background(), PriorityFee50Feed, gweiTicker)
+		assert.True(t, errors.Is(err, errInvalidResponseData))
+	})
+}
+
+func TestEVMJSONRPCGasFetcher_FetchPrices(t *testing.T) {
+	t.Parallel()
+
+	node := newJSONRPCStub()
+	defer node.Close()
+	fetcher := createEVMJSONRPCGasFetcher(t, node.URL)
+
+	pairs := []aggregator.BaseQuote{
+		{Base: BaseFeeFeed, Quote: gweiTicker},
+		{Base: PriorityFee75Feed, Quote: gweiTicker},
+		{Base: GasPriceFeed, Quote: gweiTicker},
+		{Base: "BTC", Quote: "USD"},
+	}
+	results := fetcher.FetchPrices(context.Background(), pairs)
+
+	require.Len(t, results, 4)
+	assert.InDelta(t, 15, results[pairs[0]].Price, 1e-9)
+	assert.InDelta(t, 3, results[pairs[1]].Price, 1e-9)
+	assert.InDelta(t, 20, results[pairs[2]].Price, 1e-9)
+	assert.Equal(t, aggregator.ErrPairNotSupported, results[pairs[3]].Err)
+	assert.Equal(t, [][]string{{methodFeeHistory, methodGasPrice}}, node.sentBatches())
+}
@@ -4,8 +4,11 @@ import (
 	"fmt"
 
 	"github.com/klever-io/klv-oracles-go/aggregator"
+	logger "github.com/multiversx/mx-chain-logger-go"
 )
 
+var log = logger.GetOrCreate("klv-oracle-go/aggregator/fetchers")
+
 // XExchangeTokensPair defines a base-quote pair of ids used by XExchange
 type XExchangeTokensPair struct {
 	Base  string
@@ -17,6 +20,11 @@ type ArgsPriceFetcher struct {
 	FetcherName    string
 	ResponseGetter aggregator.ResponseGetter
 	EVMGasConfig   EVMGasPriceFetcherConfig
+	// EVMJSONRPCConfig is used by the EVM JSON-RPC fetcher, whose response getter must be able to post requests
+	EVMJSONRPCConfig EVMJSONRPCGasFetcherConfig
+	QuoteMappings    map[string]string
+	SymbolMapping    SymbolMapping
+	RestJSONConfig   RestJSONFetcherConfig
 }
 
 // NewPriceFetcher returns a new price fetcher of the type provided
@@ -32,49 +40,86 @@ func createFetcher(args ArgsPriceFetcher) (aggregator.PriceFetcher, error) {
 	case BinanceName:
 		return &binance{
 			ResponseGetter: args.ResponseGetter,
-			baseFetcher:    newBaseFetcher(),
+			baseFetcher:    newBaseFetcher(args.QuoteMappings, args.SymbolMapping),
 		}, nil
 	case BitfinexName:
 		return &bitfinex{
 			ResponseGetter: args.ResponseGetter,
-			baseFetcher:    newBaseFetcher(),
+			baseFetcher:    newBaseFetcher(args.QuoteMappings, args.SymbolMapping),
+		}, nil
+	case BybitName:
+		return &bybit{
+			ResponseGetter: args.ResponseGetter,
+			baseFetcher:    newBaseFetcher(args.QuoteMappings, args.SymbolMapping),
+		}, nil
+	case CoinbaseName:
+		return &coinbase{
+			ResponseGetter: args.ResponseGetter,
+			baseFetcher:    newBaseFetcher(args.QuoteMappings, args.SymbolMapping),
 		}, nil
 	case CryptocomName:
 		return &cryptocom{
 			ResponseGetter: args.ResponseGetter,
-			baseFetcher:    newBaseFetcher(),
+			baseFetcher:    newBaseFetcher(args.QuoteMappings, args.SymbolMapping),
+		}, nil
+	case GateioName:
+		return &gateio{
+			ResponseGetter: args.ResponseGetter,
+			baseFetcher:    newBaseFetcher(args.QuoteMappings, args.SymbolMapping),
 		}, nil
 	case GeminiName:
 		return &gemini{
 			ResponseGetter: args.ResponseGetter,
-			baseFetcher:    newBaseFetcher(),
+			baseFetcher:    newBaseFetcher(args.QuoteMappings, args.SymbolMapping),
 		}, nil
 	case HitbtcName:
 		return &hitbtc{
 			ResponseGetter: args.ResponseGetter,
-			baseFetcher:    newBaseFetcher(),
+			baseFetcher:    newBaseFetcher(args.QuoteMappings, args.SymbolMapping),
 		}, nil
-	case HuobiName:
-		return &huobi{
+	case HTXName:
+		return &htx{
 			ResponseGetter: args.ResponseGetter,
-			baseFetcher:    newBaseFetcher(),
+			baseFetcher:    newBaseFetcher(args.QuoteMappings, args.SymbolMapping),
 		}, nil
 	case KrakenName:
 		return &kraken{
 			ResponseGetter: args.ResponseGetter,
-			baseFetcher:    newBaseFetcher(),
+			baseFetcher:    newBaseFetcher(args.QuoteMappings, args.SymbolMapping),
+		}, nil
+	case KucoinName:
+		return &kucoin{
+			ResponseGetter: args.ResponseGetter,
+			baseFetcher:    newBaseFetcher(args.QuoteMappings, args.SymbolMapping),
+		}, nil
+	case MexcName:
+		return &mexc{
+			ResponseGetter: args.ResponseGetter,
+			baseFetcher:    newBaseFetcher(args.QuoteMappings, args.SymbolMapping),
 		}, nil
 	case OkxName:
 		return &okx{
 			ResponseGetter: args.ResponseGetter,
-			baseFetcher:    newBaseFetcher(),
+			baseFetcher:    newBaseFetcher(args.QuoteMappings, args.SymbolMapping),
 		}, nil
+	case RestJSONFetcherName:
+		fetcher, err := newRestJSONFetcher(args)
+		if err != nil {
+			return nil, err
+		}
+		return fetcher, nil
 	case EVMGasPriceStation:
 		return &evmGasPriceFetcher{
 			ResponseGetter: args.ResponseGetter,
 			config:         args.EVMGasConfig,
-			baseFetcher:    newBaseFetcher(),
+			baseFetcher:    newBaseFetcher(args.QuoteMappings, args.SymbolMapping),
 		}, nil
+	case EVMJSONRPCName:
+		fetcher, err := newEVMJSONRPCGasFetcher(args)
+		if err != nil {
+			return nil, err
+		}
+		return fetcher, nil
 	}
 	return nil, fmt.Errorf("%w, fetcherName %s", errInvalidFetcherName, args.FetcherName)
 }
@@ -40,6 +40,15 @@ func TestNewPriceFetcher(t *testing.T) {
 		assert.Nil(t, pf)
 		assert.Equal(t, errNilResponseGetter, err)
 	})
+	t.Run("invalid REST JSON config should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsPriceFetcher()
+		args.FetcherName = RestJSONFetcherName
+		pf, err := NewPriceFetcher(args)
+		assert.Nil(t, pf)
+		assert.True(t, errors.Is(err, errInvalidRestJSONConfig))
+	})
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
@@ -61,6 +70,24 @@ func TestNewPriceFetcher(t *testing.T) {
 			assert.Equal(t, "*fetchers.bitfinex", fmt.Sprintf("%T", pf))
 			assert.Nil(t, err)
 		})
+		t.Run("Bybit", func(t *testing.T) {
+			t.Parallel()
+
+			args := createMockArgsPriceFetcher()
+			args.FetcherName = BybitName
+			pf, err := NewPriceFetcher(args)
+			assert.Equal(t, "*fetchers.bybit", fmt.Sprintf("%T", pf))
+			assert.Nil(t, err)
+		})
+		t.Run("Coinbase", func(t *testing.T) {
+			t.Parallel()
+
+			args := createMockArgsPriceFetcher()
+			args.FetcherName = CoinbaseName
+			pf, err := NewPriceFetcher(args)
+			assert.Equal(t, "*fetchers.coinbase", fmt.Sprintf("%T", pf))
+			assert.Nil(t, err)
+		})
 		t.Run("CryptoCom", func(t *testing.T) {
 			t.Parallel()
 
@@ -70,6 +97,15 @@ func TestNewPriceFetcher(t *testing.T) {
 			assert.Equal(t, "*fetchers.cryptocom", fmt.Sprintf("%T", pf))
 			assert.Nil(t, err)
 		})
+		t.Run("Gateio", func(t *testing.T) {
+			t.Parallel()
+
+			args := createMockArgsPriceFetcher()
+			args.FetcherName = GateioName
+			pf, err := NewPriceFetcher(args)
+			assert.Equal(t, "*fetchers.gateio", fmt.Sprintf("%T", pf))
+			assert.Nil(t, err)
+		})
 		t.Run("Gemini", func(t *testing.T) {
 			t.Parallel()
 
@@ -88,13 +124,13 @@ func TestNewPriceFetcher(t *testing.T) {
 			assert.Equal(t, "*fetchers.hitbtc", fmt.Sprintf("%T", pf))
 			assert.Nil(t, err)
 		})
-		t.Run("Huobi", func(t *testing.T) {
+		t.Run("HTX", func(t *testing.T) {
 			t.Parallel()
 
 			args := createMockArgsPriceFetcher()
-			args.FetcherName = HuobiName
+			args.FetcherName = HTXName
 			pf, err := NewPriceFetcher(args)
-			assert.Equal(t, "*fetchers.huobi", fmt.Sprintf("%T", pf))
+			assert.Equal(t, "*fetchers.htx", fmt.Sprintf("%T", pf))
 			assert.Nil(t, err)
 		})
 		t.Run("Kraken", func(t *testing.T) {
@@ -106,6 +142,24 @@ func TestNewPriceFetcher(t *testing.T) {
 			assert.Equal(t, "*fetchers.kraken", fmt.Sprintf("%T", pf))
 			assert.Nil(t, err)
 		})
+		t.Run("Kucoin", func(t *testing.T) {
+			t.Parallel()
+
+			args := createMockArgsPriceFetcher()
+			args.FetcherName = KucoinName
+			pf, err := NewPriceFetcher(args)
+			assert.Equal(t, "*fetchers.kucoin", fmt.Sprintf("%T", pf))
+			assert.Nil(t, err)
+		})
+		t.Run("Mexc", func(t *testing.T) {
+			t.Parallel()
+
+			args := createMockArgsPriceFetcher()
+			args.FetcherName = MexcName
+			pf, err := NewPriceFetcher(args)
+			assert.Equal(t, "*fetchers.mexc", fmt.Sprintf("%T", pf))
+			assert.Nil(t, err)
+		})
 		t.Run("Okx", func(t *testing.T) {
 			t.Parallel()
 
@@ -115,6 +169,17 @@ func TestNewPriceFetcher(t *testing.T) {
 			assert.Equal(t, "*fetchers.okx", fmt.Sprintf("%T", pf))
 			assert.Nil(t, err)
 		})
+		t.Run("REST JSON", func(t *testing.T) {
+			t.Parallel()
+
+			args := createMockArgsPriceFetcher()
+			args.FetcherName = RestJSONFetcherName
+			args.RestJSONConfig = createMockRestJSONFetcherConfig()
+			pf, err := NewPriceFetcher(args)
+			assert.Equal(t, "*fetchers.restJSONFetcher", fmt.Sprintf("%T", pf))
+			assert.Equal(t, "custom source", pf.Name())
+			assert.Nil(t, err)
+		})
 		t.Run("EVM gas price", func(t *testing.T) {
 			t.Parallel()
 
@@ -22,7 +22,7 @@ func Test_FunctionalTesting(t *testing.T) {
 
 	t.Skip("this test should be run only when doing debugging work on the component")
 
-	responseGetter, err := aggregator.NewHttpResponseGetter()
+	responseGetter, err := aggregator.NewHttpResponseGetter(aggregator.DefaultArgsHttpResponseGetter())
 	require.Nil(t, err)
 
 	wg := sync.WaitGroup{}
@@ -53,7 +53,7 @@ func Test_FunctionalTestingForEVMGasPrice(t *testing.T) {
 
 	t.Skip("this test should be run only when doing debugging work on the component")
 
-	responseGetter, err := aggregator.NewHttpResponseGetter()
+	responseGetter, err := aggregator.NewHttpResponseGetter(aggregator.DefaultArgsHttpResponseGetter())
 	require.Nil(t, err)
 
 	// IMPORTANT: on the API URL value we should append &apikey=<APIKEY>
@@ -285,6 +285,11 @@ func getFuncGetCalled(name, returnPrice, pair string, returnErr error) func(ctx
 	switch name {
 	case BinanceName:
 		return func(ctx context.Context, url string, response interface{}) error {
+			batch, isBatch := response.(*[]binancePriceRequest)
+			if isBatch {
+				*batch = []binancePriceRequest{{Symbol: pair, Price: returnPrice}}
+				return returnErr
+			}
 			cast, _ := response.(*binancePriceRequest)
 			cast.Price = returnPrice
 			return returnErr
@@ -295,6 +300,18 @@ func getFuncGetCalled(name, returnPrice, pair string, returnErr error) func(ctx
 			cast.Price = returnPrice
 			return returnErr
 		}
+	case BybitName:
+		return func(ctx context.Context, url string, response interface{}) error {
+			cast, _ := response.(*bybitPriceRequest)
+			cast.Result.List = []bybitTicker{{Symbol: pair, LastPrice: returnPrice}}
+			return returnErr
+		}
+	case CoinbaseName:
+		return func(ctx context.Context, url string, response interface{}) error {
+			cast, _ := response.(*coinbasePriceRequest)
+			cast.Price = returnPrice
+			return returnErr
+		}
 	case CryptocomName:
 		return func(ctx context.Context, url string, response interface{}) error {
 			cast, _ := response.(*cryptocomPriceRequest)
@@ -305,6 +322,12 @@ func getFuncGetCalled(name, returnPrice, pair string, returnErr error) func(ctx
 			}
 			return returnErr
 		}
+	case GateioName:
+		return func(ctx context.Context, url string, response interface{}) error {
+			cast, _ := response.(*[]gateioTicker)
+			*cast = []gateioTicker{{CurrencyPair: pair, Last: returnPrice}}
+			return returnErr
+		}
 	case GeminiName:
 		return func(ctx context.Context, url string, response interface{}) error {
 			cast, _ := response.(*geminiPriceRequest)
@@ -317,9 +340,10 @@ func getFuncGetCalled(name, returnPrice, pair string, returnErr error) func(ctx
 			cast.Price = returnPrice
 			return returnErr
 		}
-	case HuobiName:
+	case HTXName:
 		return func(ctx context.Context, url string, response interface{}) error {
-			cast, _ := response.(*huobiPriceRequest)
+			cast, _ := response.(*htxPriceRequest)
+			cast.Status = htxStatusOk
 			var err error
 			cast.Ticker.Price, err = strconv.ParseFloat(returnPrice, 64)
 			if err != nil {
@@ -335,6 +359,19 @@ func getFuncGetCalled(name, returnPrice, pair string, returnErr error) func(ctx
 			}
 			return returnErr
 		}
+	case KucoinName:
+		return func(ctx context.Context, url string, response interface{}) error {
+			cast, _ := response.(*kucoinPriceRequest)
+			cast.Code = kucoinSuccessCode
+			cast.Data = &kucoinTicker{Price: returnPrice}
+			return returnErr
+		}
+	case MexcName:
+		return func(ctx context.Context, url string, response interface{}) error {
+			cast, _ := response.(*mexcPriceRequest)
+			cast.Price = returnPrice
+			return returnErr
+		}
 	case OkxName:
 		return func(ctx context.Context, url string, response interface{}) error {
 			cast, _ := response.(*okxPriceRequest)
@@ -345,3 +382,152 @@ func getFuncGetCalled(name, returnPrice, pair string, returnErr error) func(ctx
 
 	return nil
 }
+
+func Test_FetchPriceWithQuoteMappings(t *testing.T) {
+	t.Parallel()
+
+	ethTicker := "ETH"
+	args := ArgsPriceFetcher{
+		FetcherName: BinanceName,
+		ResponseGetter: &mock.HttpResponseGetterStub{
+			GetCalled: func(ctx context.Context, url string, response interface{}) error {
+				assert.Equal(t, "https://api.binance.com/api/v3/ticker/price?symbol=ETHUSDT", url)
+				cast, _ := response.(*binancePriceRequest)
+				cast.Price = "4714.05"
+				return nil
+			},
+		},
+		QuoteMappings: map[string]string{quoteUSDFiat: "USDT"},
+	}
+	fetcher, _ := NewPriceFetcher(args)
+	assert.Equal(t, "USDT", fetcher.MappedQuote(quoteUSDFiat))
+
+	fetcher.AddPair(ethTicker, quoteUSDFiat)
+	price, err := fetcher.FetchPrice(context.Background(), ethTicker, quoteUSDFiat)
+	require.Nil(t, err)
+	require.Equal(t, 4714.05, price)
+}
+
+func Test_FetchPriceWithSymbolMappings(t *testing.T) {
+	t.Parallel()
+
+	symbolMapping := SymbolMapping{
+		Assets:  map[string]string{"BTC": "XBT", "USD": "USDX"},
+		Markets: map[string]string{"KLV-USD": "MAPPED"},
+	}
+	expectedURLs := map[string][]string{
+		BinanceName: {
+			"https://api.binance.com/api/v3/ticker/price?symbol=XBTUSDX",
+			"https://api.binance.com/api/v3/ticker/price?symbol=MAPPED",
+		},
+		BitfinexName: {
+			"https://api.bitfinex.com/v1/pubticker/XBTUSDX",
+			"https://api.bitfinex.com/v1/pubticker/MAPPED",
+		},
+		BybitName: {
+			"https://api.bybit.com/v5/market/tickers?category=spot&symbol=XBTUSDX",
+			"https://api.bybit.com/v5/market/tickers?category=spot&symbol=MAPPED",
+		},
+		CoinbaseName: {
+			"https://api.exchange.coinbase.com/products/XBT-USDX/ticker",
+			"https://api.exchange.coinbase.com/products/MAPPED/ticker",
+		},
+		CryptocomName: {
+			"https://api.crypto.com/v2/public/get-ticker?instrument_name=XBT_USDX",
+			"https://api.crypto.com/v2/public/get-ticker?instrument_name=MAPPED",
+		},
+		GateioName: {
+			"https://api.gateio.ws/api/v4/spot/tickers?currency_pair=XBT_USDX",
+			"https://api.gateio.ws/api/v4/spot/tickers?currency_pair=MAPPED",
+		},
+		GeminiName: {
+			"https://api.gemini.com/v2/ticker/XBTUSDX",
+			"https://api.gemini.com/v2/ticker/MAPPED",
+		},
+		HitbtcName: {
+			"https://api.hitbtc.com/api/3/public/ticker/XBTUSDX",
+			"https://api.hitbtc.com/api/3/public/ticker/MAPPED",
+		},
+		HTXName: {
+			"https://api.htx.com/market/detail/merged?symbol=xbtusdx",
+			"https://api.htx.com/market/detail/merged?symbol=MAPPED",
+		},
+		KrakenName: {
+			"https://api.kraken.com/0/public/Ticker?pair=XBTUSDX",
+			"https://api.kraken.com/0/public/Ticker?pair=MAPPED",
+		},
+		KucoinName: {
+			"https://api.kucoin.com/api/v1/market/orderbook/level1?symbol=XBT-USDX",
+			"https://api.kucoin.com/api/v1/market/orderbook/level1?symbol=MAPPED",
+		},
+		MexcName: {
+			"https://api.mexc.com/api/v3/ticker/price?symbol=XBTUSDX",
+			"https://api.mexc.com/api/v3/ticker/price?symbol=MAPPED",
+		},
+		OkxName: {
+			"https://www.okx.com/api/v5/market/ticker?instId=XBT-USDX",
+			"https://www.okx.com/api/v5/market/ticker?instId=MAPPED",
+		},
+	}
+
+	for f := range ImplementedFetchers {
+		fetcherName := f
+
+		t.Run(fetcherName, func(t *testing.T) {
+			t.Parallel()
+
+			urls := make([]string, 0)
+			getFuncGetCalledForPair := getFuncGetCalled(fetcherName, "4714.05", "XBTUSDX", nil)
+			args := ArgsPriceFetcher{
+				FetcherName: fetcherName,
+				ResponseGetter: &mock.HttpResponseGetterStub{
+					GetCalled: func(ctx context.Context, url string, response interface{}) error {
+						urls = append(urls, url)
+						return getFuncGetCalledForPair(ctx, url, response)
+					},
+				},
+				SymbolMapping: symbolMapping,
+			}
+			fetcher, _ := NewPriceFetcher(args)
+
+			fetcher.AddPair("BTC", quoteUSDFiat)
+			fetcher.AddPair("KLV", quoteUSDFiat)
+			price, err := fetcher.FetchPrice(context.Background(), "BTC", quoteUSDFiat)
+			require.Nil(t, err)
+			require.Equal(t, 4714.05, price)
+			_, _ = fetcher.FetchPrice(context.Background(), "KLV", quoteUSDFiat)
+
+			assert.Equal(t, expectedURLs[fetcherName], urls)
+		})
+	}
+}
+
+func Test_FetchPrices(t *testing.T) {
+	t.Parallel()
+
+	for f := range ImplementedFetchers {
+		fetcherName := f
+
+		t.Run(fetcherName, func(t *testing.T) {
+			t.Parallel()
+
+			args := ArgsPriceFetcher{
+				FetcherName: fetcherName,
+				ResponseGetter: &mock.HttpResponseGetterStub{
+					GetCalled: getFuncGetCalled(fetcherName, "4714.05", "ETHUSD", nil),
+				},
+			}
+			fetcher, _ := NewPriceFetcher(args)
+			fetcher.AddPair("ETH", quoteUSDFiat)
+
+			supportedPair := aggregator.BaseQuote{Base: "ETH", Quote: quoteUSDFiat}
+			unsupportedPair := aggregator.BaseQuote{Base: "BTC", Quote: quoteUSDFiat}
+			results := fetcher.FetchPrices(context.Background(), []aggregator.BaseQuote{supportedPair, unsupportedPair})
+
+			assert.Equal(t, map[aggregator.BaseQuote]aggregator.PriceResult{
+				supportedPair:   {Price: 4714.05},
+				unsupportedPair: {Err: aggregator.ErrPairNotSupported},
+			}, results)
+		})
+	}
+}
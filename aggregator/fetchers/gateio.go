@@ -0,0 +1,62 @@
+package fetchers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+)
+
+const (
+	gateioPriceUrl     = "https://api.gateio.ws/api/v4/spot/tickers?currency_pair=%s"
+	gateioMarketFormat = "%s_%s"
+)
+
+type gateioTicker struct {
+	CurrencyPair string `json:"currency_pair"`
+	Last         string `json:"last"`
+}
+
+type gateio struct {
+	aggregator.ResponseGetter
+	baseFetcher
+}
+
+// FetchPrice will fetch the price using the http client
+func (g *gateio) FetchPrice(ctx context.Context, base string, quote string) (float64, error) {
+	if !g.hasPair(base, quote) {
+		return 0, aggregator.ErrPairNotSupported
+	}
+
+	quote = g.MappedQuote(quote)
+	market := g.marketID(base, quote, formatMarketID(gateioMarketFormat))
+
+	var tickers []gateioTicker
+	err := g.ResponseGetter.Get(ctx, fmt.Sprintf(gateioPriceUrl, market), &tickers)
+	if err != nil {
+		return 0, err
+	}
+	if len(tickers) == 0 {
+		return 0, errInvalidResponseData
+	}
+	if tickers[0].Last == "" {
+		return 0, errInvalidResponseData
+	}
+
+	return StrToPositiveFloat64(tickers[0].Last)
+}
+
+// FetchPrices fetches the prices of the provided pairs, with one request for each pair
+func (g *gateio) FetchPrices(ctx context.Context, pairs []aggregator.BaseQuote) map[aggregator.BaseQuote]aggregator.PriceResult {
+	return fetchPricesConcurrently(ctx, g.FetchPrice, pairs)
+}
+
+// Name returns the name
+func (g *gateio) Name() string {
+	return GateioName
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (g *gateio) IsInterfaceNil() bool {
+	return g == nil
+}
@@ -8,7 +8,8 @@ import (
 )
 
 const (
-	geminiPriceUrl = "https://api.gemini.com/v2/ticker/%s%s"
+	geminiPriceUrl     = "https://api.gemini.com/v2/ticker/%s"
+	geminiMarketFormat = "%s%s"
 )
 
 type geminiPriceRequest struct {
@@ -26,10 +27,12 @@ func (g *gemini) FetchPrice(ctx context.Context, base, quote string) (float64, e
 		return 0, aggregator.ErrPairNotSupported
 	}
 
-	quote = g.normalizeQuoteName(quote, GeminiName)
+	quote = g.MappedQuote(quote)
+
+	market := g.marketID(base, quote, formatMarketID(geminiMarketFormat))
 
 	var gpr geminiPriceRequest
-	err := g.ResponseGetter.Get(ctx, fmt.Sprintf(geminiPriceUrl, base, quote), &gpr)
+	err := g.ResponseGetter.Get(ctx, fmt.Sprintf(geminiPriceUrl, market), &gpr)
 	if err != nil {
 		return 0, err
 	}
@@ -40,6 +43,11 @@ func (g *gemini) FetchPrice(ctx context.Context, base, quote string) (float64, e
 	return StrToPositiveFloat64(gpr.Price)
 }
 
+// FetchPrices fetches the prices of the provided pairs, with one request for each pair
+func (g *gemini) FetchPrices(ctx context.Context, pairs []aggregator.BaseQuote) map[aggregator.BaseQuote]aggregator.PriceResult {
+	return fetchPricesConcurrently(ctx, g.FetchPrice, pairs)
+}
+
 // Name returns the name
 func (g *gemini) Name() string {
 	return GeminiName
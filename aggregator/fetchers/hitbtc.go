@@ -8,11 +8,19 @@ import (
 )
 
 const (
-	hitbtcPriceUrl = "https://api.hitbtc.com/api/3/public/ticker/%s%s"
+	hitbtcPriceUrl     = "https://api.hitbtc.com/api/3/public/ticker/%s"
+	hitbtcMarketFormat = "%s%s"
 )
 
 type hitbtcPriceRequest struct {
-	Price string `json:"last"`
+	Price string       `json:"last"`
+	Error *hitbtcError `json:"error"`
+}
+
+type hitbtcError struct {
+	Code        int    `json:"code"`
+	Message     string `json:"message"`
+	Description string `json:"description"`
 }
 
 type hitbtc struct {
@@ -26,19 +34,32 @@ func (h *hitbtc) FetchPrice(ctx context.Context, base, quote string) (float64, e
 		return 0, aggregator.ErrPairNotSupported
 	}
 
-	quote = h.normalizeQuoteName(quote, HitbtcName)
+	quote = h.MappedQuote(quote)
+
+	market := h.marketID(base, quote, formatMarketID(hitbtcMarketFormat))
 
 	var hpr hitbtcPriceRequest
-	err := h.ResponseGetter.Get(ctx, fmt.Sprintf(hitbtcPriceUrl, base, quote), &hpr)
-	if err != nil {
+	err := h.ResponseGetter.Get(ctx, fmt.Sprintf(hitbtcPriceUrl, market), &hpr)
+	// the errors come with a 4xx status code, described in the body
+	if err != nil && !(decodeErrorResponse(err, &hpr) && hpr.Error != nil) {
 		return 0, err
 	}
+	if hpr.Error != nil {
+		return 0, fmt.Errorf("%w, code: %d, message: %s, description: %s",
+			errInvalidResponseData, hpr.Error.Code, hpr.Error.Message, hpr.Error.Description)
+	}
+	// the last price is null for the markets without any trade
 	if hpr.Price == "" {
 		return 0, errInvalidResponseData
 	}
 	return StrToPositiveFloat64(hpr.Price)
 }
 
+// FetchPrices fetches the prices of the provided pairs, with one request for each pair
+func (h *hitbtc) FetchPrices(ctx context.Context, pairs []aggregator.BaseQuote) map[aggregator.BaseQuote]aggregator.PriceResult {
+	return fetchPricesConcurrently(ctx, h.FetchPrice, pairs)
+}
+
 // Name returns the name
 func (h *hitbtc) Name() string {
 	return HitbtcName
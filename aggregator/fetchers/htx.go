@@ -0,0 +1,85 @@
+package fetchers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+)
+
+const (
+	htxPriceUrl      = "https://api.htx.com/market/detail/merged?symbol=%s"
+	htxStatusOk      = "ok"
+	htxUnknownStatus = "unknown"
+)
+
+type htxPriceRequest struct {
+	Status  string         `json:"status"`
+	ErrCode string         `json:"err-code"`
+	ErrMsg  string         `json:"err-msg"`
+	Ticker  htxPriceTicker `json:"tick"`
+}
+
+type htxPriceTicker struct {
+	Price float64 `json:"close"`
+}
+
+type htx struct {
+	aggregator.ResponseGetter
+	baseFetcher
+}
+
+// FetchPrice will fetch the price using the http client
+func (h *htx) FetchPrice(ctx context.Context, base string, quote string) (float64, error) {
+	if !h.hasPair(base, quote) {
+		return 0, aggregator.ErrPairNotSupported
+	}
+
+	quote = h.MappedQuote(quote)
+
+	market := h.marketID(base, quote, buildHTXMarketID)
+
+	var hpr htxPriceRequest
+	err := h.ResponseGetter.Get(ctx, fmt.Sprintf(htxPriceUrl, market), &hpr)
+	// the errors can come with a 4xx status code, described in the body
+	if err != nil && !(decodeErrorResponse(err, &hpr) && len(hpr.Status) > 0) {
+		return 0, err
+	}
+	if hpr.Status != htxStatusOk {
+		return 0, newHTXResponseError(hpr)
+	}
+	if hpr.Ticker.Price <= 0 {
+		return 0, errInvalidResponseData
+	}
+
+	return hpr.Ticker.Price, nil
+}
+
+func newHTXResponseError(hpr htxPriceRequest) error {
+	status := hpr.Status
+	if status == "" {
+		status = htxUnknownStatus
+	}
+
+	return fmt.Errorf("%w, status: %s, code: %s, message: %s", errInvalidResponseData, status, hpr.ErrCode, hpr.ErrMsg)
+}
+
+func buildHTXMarketID(baseSymbol string, quoteSymbol string) string {
+	return strings.ToLower(baseSymbol + quoteSymbol)
+}
+
+// FetchPrices fetches the prices of the provided pairs, with one request for each pair
+func (h *htx) FetchPrices(ctx context.Context, pairs []aggregator.BaseQuote) map[aggregator.BaseQuote]aggregator.PriceResult {
+	return fetchPricesConcurrently(ctx, h.FetchPrice, pairs)
+}
+
+// Name returns the name
+func (h *htx) Name() string {
+	return HTXName
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (h *htx) IsInterfaceNil() bool {
+	return h == nil
+}
@@ -9,7 +9,8 @@ import (
 )
 
 const (
-	krakenPriceUrl = "https://api.kraken.com/0/public/Ticker?pair=%s%s"
+	krakenPriceUrl     = "https://api.kraken.com/0/public/Ticker?pair=%s"
+	krakenMarketFormat = "%s%s"
 )
 
 type krakenPriceRequest struct {
@@ -31,10 +32,14 @@ func (k *kraken) FetchPrice(ctx context.Context, base string, quote string) (flo
 		return 0, aggregator.ErrPairNotSupported
 	}
 
-	quote = k.normalizeQuoteName(quote, KrakenName)
+	quote = k.MappedQuote(quote)
+
+	market := k.marketID(base, quote, formatMarketID(krakenMarketFormat))
+	baseSymbol := k.exchangeSymbol(base)
+	quoteSymbol := k.exchangeSymbol(quote)
 
 	var hpr krakenPriceRequest
-	err := k.ResponseGetter.Get(ctx, fmt.Sprintf(krakenPriceUrl, base, quote), &hpr)
+	err := k.ResponseGetter.Get(ctx, fmt.Sprintf(krakenPriceUrl, market), &hpr)
 	if err != nil {
 		return 0, err
 	}
@@ -46,7 +51,7 @@ func (k *kraken) FetchPrice(ctx context.Context, base string, quote string) (flo
 			return 0, errInvalidResponseData
 		}
 
-		if strings.Contains(k, base) || strings.Contains(k, quote) {
+		if strings.Contains(k, baseSymbol) || strings.Contains(k, quoteSymbol) {
 			return StrToPositiveFloat64(v.Price[0])
 		}
 	}
@@ -54,6 +59,11 @@ func (k *kraken) FetchPrice(ctx context.Context, base string, quote string) (flo
 	return 0, errInvalidResponseData
 }
 
+// FetchPrices fetches the prices of the provided pairs, with one request for each pair
+func (k *kraken) FetchPrices(ctx context.Context, pairs []aggregator.BaseQuote) map[aggregator.BaseQuote]aggregator.PriceResult {
+	return fetchPricesConcurrently(ctx, k.FetchPrice, pairs)
+}
+
 // Name returns the name
 func (k *kraken) Name() string {
 	return KrakenName
@@ -0,0 +1,69 @@
+package fetchers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+)
+
+const (
+	kucoinPriceUrl     = "https://api.kucoin.com/api/v1/market/orderbook/level1?symbol=%s"
+	kucoinMarketFormat = "%s-%s"
+	kucoinSuccessCode  = "200000"
+)
+
+type kucoinPriceRequest struct {
+	Code string        `json:"code"`
+	Msg  string        `json:"msg"`
+	Data *kucoinTicker `json:"data"`
+}
+
+type kucoinTicker struct {
+	Price string `json:"price"`
+}
+
+type kucoin struct {
+	aggregator.ResponseGetter
+	baseFetcher
+}
+
+// FetchPrice will fetch the price using the http client
+func (k *kucoin) FetchPrice(ctx context.Context, base string, quote string) (float64, error) {
+	if !k.hasPair(base, quote) {
+		return 0, aggregator.ErrPairNotSupported
+	}
+
+	quote = k.MappedQuote(quote)
+	market := k.marketID(base, quote, formatMarketID(kucoinMarketFormat))
+
+	var kpr kucoinPriceRequest
+	err := k.ResponseGetter.Get(ctx, fmt.Sprintf(kucoinPriceUrl, market), &kpr)
+	if err != nil {
+		return 0, err
+	}
+	if kpr.Code != kucoinSuccessCode {
+		return 0, fmt.Errorf("%w, code: %s, message: %s", errInvalidResponseData, kpr.Code, kpr.Msg)
+	}
+	// an unknown symbol is reported with the success code and an empty data field
+	if kpr.Data == nil || kpr.Data.Price == "" {
+		return 0, errInvalidResponseData
+	}
+
+	return StrToPositiveFloat64(kpr.Data.Price)
+}
+
+// FetchPrices fetches the prices of the provided pairs, with one request for each pair
+func (k *kucoin) FetchPrices(ctx context.Context, pairs []aggregator.BaseQuote) map[aggregator.BaseQuote]aggregator.PriceResult {
+	return fetchPricesConcurrently(ctx, k.FetchPrice, pairs)
+}
+
+// Name returns the name
+func (k *kucoin) Name() string {
+	return KucoinName
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (k *kucoin) IsInterfaceNil() bool {
+	return k == nil
+}
@@ -0,0 +1,65 @@
+package fetchers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+)
+
+const (
+	mexcPriceUrl     = "https://api.mexc.com/api/v3/ticker/price?symbol=%s"
+	mexcMarketFormat = "%s%s"
+)
+
+type mexcPriceRequest struct {
+	Symbol string `json:"symbol"`
+	Price  string `json:"price"`
+	Code   int    `json:"code"`
+	Msg    string `json:"msg"`
+}
+
+type mexc struct {
+	aggregator.ResponseGetter
+	baseFetcher
+}
+
+// FetchPrice will fetch the price using the http client
+func (m *mexc) FetchPrice(ctx context.Context, base string, quote string) (float64, error) {
+	if !m.hasPair(base, quote) {
+		return 0, aggregator.ErrPairNotSupported
+	}
+
+	quote = m.MappedQuote(quote)
+	market := m.marketID(base, quote, formatMarketID(mexcMarketFormat))
+
+	var mpr mexcPriceRequest
+	err := m.ResponseGetter.Get(ctx, fmt.Sprintf(mexcPriceUrl, market), &mpr)
+	// the errors come with a 4xx status code, described in the body
+	if err != nil && !(decodeErrorResponse(err, &mpr) && mpr.Code != 0) {
+		return 0, err
+	}
+	if mpr.Code != 0 {
+		return 0, fmt.Errorf("%w, code: %d, message: %s", errInvalidResponseData, mpr.Code, mpr.Msg)
+	}
+	if mpr.Price == "" {
+		return 0, errInvalidResponseData
+	}
+
+	return StrToPositiveFloat64(mpr.Price)
+}
+
+// FetchPrices fetches the prices of the provided pairs, with one request for each pair
+func (m *mexc) FetchPrices(ctx context.Context, pairs []aggregator.BaseQuote) map[aggregator.BaseQuote]aggregator.PriceResult {
+	return fetchPricesConcurrently(ctx, m.FetchPrice, pairs)
+}
+
+// Name returns the name
+func (m *mexc) Name() string {
+	return MexcName
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (m *mexc) IsInterfaceNil() bool {
+	return m == nil
+}
@@ -8,7 +8,8 @@ import (
 )
 
 const (
-	okxPriceUrl = "https://www.okx.com/api/v5/market/ticker?instId=%s-%s"
+	okxPriceUrl     = "https://www.okx.com/api/v5/market/ticker?instId=%s"
+	okxMarketFormat = "%s-%s"
 )
 
 type okxPriceRequest struct {
@@ -30,10 +31,12 @@ func (o *okx) FetchPrice(ctx context.Context, base string, quote string) (float6
 		return 0, aggregator.ErrPairNotSupported
 	}
 
-	quote = o.normalizeQuoteName(quote, OkxName)
+	quote = o.MappedQuote(quote)
+
+	market := o.marketID(base, quote, formatMarketID(okxMarketFormat))
 
 	var opr okxPriceRequest
-	err := o.ResponseGetter.Get(ctx, fmt.Sprintf(okxPriceUrl, base, quote), &opr)
+	err := o.ResponseGetter.Get(ctx, fmt.Sprintf(okxPriceUrl, market), &opr)
 	if err != nil {
 		return 0, err
 	}
@@ -47,6 +50,11 @@ func (o *okx) FetchPrice(ctx context.Context, base string, quote string) (float6
 	return StrToPositiveFloat64(opr.Data[0].Price)
 }
 
+// FetchPrices fetches the prices of the provided pairs, with one request for each pair
+func (o *okx) FetchPrices(ctx context.Context, pairs []aggregator.BaseQuote) map[aggregator.BaseQuote]aggregator.PriceResult {
+	return fetchPricesConcurrently(ctx, o.FetchPrice, pairs)
+}
+
 // Name returns the name
 func (o *okx) Name() string {
 	return OkxName
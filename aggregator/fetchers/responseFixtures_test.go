@@ -0,0 +1,272 @@
+package fetchers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// redirectResponseGetter sends every request to the test server, keeping the path and the query of the original URL
+type redirectResponseGetter struct {
+	serverURL      string
+	responseGetter aggregator.ResponseGetter
+}
+
+func (getter *redirectResponseGetter) Get(ctx context.Context, rawURL string, response interface{}) error {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	return getter.responseGetter.Get(ctx, getter.serverURL+parsedURL.RequestURI(), response)
+}
+
+func (getter *redirectResponseGetter) IsInterfaceNil() bool {
+	return getter == nil
+}
+
+type responseFixture struct {
+	exchange      string
+	base          string
+	quote         string
+	expectedURI   string
+	statusCode    int
+	response      string
+	expectedPrice float64
+	expectedErr   error
+	errContains   string
+}
+
+func testResponseFixture(t *testing.T, fixture responseFixture) {
+	httpServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, fixture.expectedURI, req.URL.RequestURI())
+
+		rw.WriteHeader(fixture.statusCode)
+		_, _ = rw.Write([]byte(fixture.response))
+	}))
+	defer httpServer.Close()
+
+	httpResponseGetter, err := aggregator.NewHttpResponseGetter(aggregator.DefaultArgsHttpResponseGetter())
+	require.Nil(t, err)
+
+	args := createMockArgsPriceFetcher()
+	args.FetcherName = fixture.exchange
+	args.ResponseGetter = &redirectResponseGetter{
+		serverURL:      httpServer.URL,
+		responseGetter: httpResponseGetter,
+	}
+	fetcher, err := NewPriceFetcher(args)
+	require.Nil(t, err)
+
+	fetcher.AddPair(fixture.base, fixture.quote)
+	price, err := fetcher.FetchPrice(context.Background(), fixture.base, fixture.quote)
+	if fixture.expectedErr != nil {
+		assert.True(t, errors.Is(err, fixture.expectedErr), "unexpected error %v", err)
+		assert.True(t, strings.Contains(err.Error(), fixture.errContains), "unexpected error %v", err)
+		assert.Zero(t, price)
+		return
+	}
+
+	require.Nil(t, err)
+	assert.Equal(t, fixture.expectedPrice, price)
+}
+
+func TestResponseFixtures(t *testing.T) {
+	t.Parallel()
+
+	fixtures := map[string]responseFixture{
+		"Coinbase ticker": {
+			exchange:      CoinbaseName,
+			base:          "BTC",
+			quote:         "USD",
+			expectedURI:   "/products/BTC-USD/ticker",
+			statusCode:    http.StatusOK,
+			response:      `{"ask":"67012.36","bid":"67012.35","volume":"7393.54913416","trade_id":688349941,"price":"67012.36","size":"0.00075","time":"2024-10-08T09:12:19.410343Z","rfq_volume":"48.281729"}`,
+			expectedPrice: 67012.36,
+		},
+		"Coinbase unknown product": {
+			exchange:    CoinbaseName,
+			base:        "BTC",
+			quote:       "XYZ",
+			expectedURI: "/products/BTC-XYZ/ticker",
+			statusCode:  http.StatusNotFound,
+			response:    `{"message":"NotFound"}`,
+			expectedErr: errInvalidResponseData,
+			errContains: "NotFound",
+		},
+		"Bybit ticker": {
+			exchange:    BybitName,
+			base:        "BTC",
+			quote:       "USDT",
+			expectedURI: "/v5/market/tickers?category=spot&symbol=BTCUSDT",
+			statusCode:  http.StatusOK,
+			response: `{"retCode":0,"retMsg":"OK","result":{"category":"spot","list":[{"symbol":"BTCUSDT","bid1Price":"62325.9","bid1Size":"0.130484","ask1Price":"62326",` +
+				`"ask1Size":"1.085391","lastPrice":"62325.9","prevPrice24h":"62085.3","price24hPcnt":"0.0039","highPrice24h":"63202","lowPrice24h":"61713.7",` +
+				`"turnover24h":"744419394.0457836","volume24h":"11946.006532","usdIndexPrice":"62304.631611"}]},"retExtInfo":{},"time":1728378738916}`,
+			expectedPrice: 62325.9,
+		},
+		"Bybit error code": {
+			exchange:    BybitName,
+			base:        "BTC",
+			quote:       "XYZ",
+			expectedURI: "/v5/market/tickers?category=spot&symbol=BTCXYZ",
+			statusCode:  http.StatusOK,
+			response:    `{"retCode":10001,"retMsg":"Not supported symbols","result":{},"retExtInfo":{},"time":1728378781325}`,
+			expectedErr: errInvalidResponseData,
+			errContains: "code: 10001, message: Not supported symbols",
+		},
+		"KuCoin ticker": {
+			exchange:      KucoinName,
+			base:          "BTC",
+			quote:         "USDT",
+			expectedURI:   "/api/v1/market/orderbook/level1?symbol=BTC-USDT",
+			statusCode:    http.StatusOK,
+			response:      `{"code":"200000","data":{"time":1728378827442,"sequence":"14317329587","price":"62340.2","size":"0.00008","bestBid":"62340.1","bestBidSize":"0.28813765","bestAsk":"62340.2","bestAskSize":"0.30386212"}}`,
+			expectedPrice: 62340.2,
+		},
+		"KuCoin unknown symbol": {
+			exchange:    KucoinName,
+			base:        "BTC",
+			quote:       "XYZ",
+			expectedURI: "/api/v1/market/orderbook/level1?symbol=BTC-XYZ",
+			statusCode:  http.StatusOK,
+			response:    `{"code":"200000","data":null}`,
+			expectedErr: errInvalidResponseData,
+		},
+		"KuCoin error code": {
+			exchange:    KucoinName,
+			base:        "BTC",
+			quote:       "USDT",
+			expectedURI: "/api/v1/market/orderbook/level1?symbol=BTC-USDT",
+			statusCode:  http.StatusOK,
+			response:    `{"code":"429000","msg":"Too many requests in a short period of time, please retry later."}`,
+			expectedErr: errInvalidResponseData,
+			errContains: "code: 429000",
+		},
+		"Gate.io ticker": {
+			exchange:    GateioName,
+			base:        "BTC",
+			quote:       "USDT",
+			expectedURI: "/api/v4/spot/tickers?currency_pair=BTC_USDT",
+			statusCode:  http.StatusOK,
+			response: `[{"currency_pair":"BTC_USDT","last":"62338.1","lowest_ask":"62338.1","highest_bid":"62338","change_percentage":"0.34","base_volume":"5623.0214",` +
+				`"quote_volume":"350224118.57","high_24h":"63170","low_24h":"61700"}]`,
+			expectedPrice: 62338.1,
+		},
+		"Gate.io empty list": {
+			exchange:    GateioName,
+			base:        "BTC",
+			quote:       "USDT",
+			expectedURI: "/api/v4/spot/tickers?currency_pair=BTC_USDT",
+			statusCode:  http.StatusOK,
+			response:    `[]`,
+			expectedErr: errInvalidResponseData,
+		},
+		"HTX ticker": {
+			exchange:    HTXName,
+			base:        "BTC",
+			quote:       "USDT",
+			expectedURI: "/market/detail/merged?symbol=btcusdt",
+			statusCode:  http.StatusOK,
+			response: `{"ch":"market.btcusdt.detail.merged","status":"ok","ts":1728379283124,"tick":{"id":362578433047,"version":362578433047,` +
+				`"open":62101.01,"close":62340.01,"low":61700.0,"high":63180.0,"amount":2170.12,"vol":1.3530281e8,"count":2212911,` +
+				`"bid":[62340.0,0.35],"ask":[62340.01,0.14]}}`,
+			expectedPrice: 62340.01,
+		},
+		"HTX error code": {
+			exchange:    HTXName,
+			base:        "BTC",
+			quote:       "XYZ",
+			expectedURI: "/market/detail/merged?symbol=btcxyz",
+			statusCode:  http.StatusOK,
+			response:    `{"status":"error","err-code":"invalid-parameter","err-msg":"invalid symbol","data":null}`,
+			expectedErr: errInvalidResponseData,
+			errContains: "status: error, code: invalid-parameter, message: invalid symbol",
+		},
+		"HTX error code with status 400": {
+			exchange:    HTXName,
+			base:        "BTC",
+			quote:       "XYZ",
+			expectedURI: "/market/detail/merged?symbol=btcxyz",
+			statusCode:  http.StatusBadRequest,
+			response:    `{"status":"error","err-code":"bad-request","err-msg":"invalid symbol","data":null}`,
+			expectedErr: errInvalidResponseData,
+			errContains: "status: error, code: bad-request, message: invalid symbol",
+		},
+		"HitBTC ticker": {
+			exchange:    HitbtcName,
+			base:        "BTC",
+			quote:       "USDT",
+			expectedURI: "/api/3/public/ticker/BTCUSDT",
+			statusCode:  http.StatusOK,
+			response: `{"ask":"62351.47","bid":"62345.18","last":"62349.99","low":"61722.27","high":"63199.99","open":"62097.34",` +
+				`"volume":"412.16781","volume_quote":"25759911.4508941","timestamp":"2024-10-08T09:23:52.145Z"}`,
+			expectedPrice: 62349.99,
+		},
+		"HitBTC market without trades": {
+			exchange:    HitbtcName,
+			base:        "BTC",
+			quote:       "USDT",
+			expectedURI: "/api/3/public/ticker/BTCUSDT",
+			statusCode:  http.StatusOK,
+			response:    `{"ask":null,"bid":null,"last":null,"low":"0","high":"0","open":null,"volume":"0","volume_quote":"0","timestamp":"2024-10-08T09:23:52.145Z"}`,
+			expectedErr: errInvalidResponseData,
+		},
+		"HitBTC error code": {
+			exchange:    HitbtcName,
+			base:        "BTC",
+			quote:       "XYZ",
+			expectedURI: "/api/3/public/ticker/BTCXYZ",
+			statusCode:  http.StatusBadRequest,
+			response:    `{"error":{"code":2001,"message":"Symbol not found","description":"Try get /api/3/public/symbol, to get list of all available symbols."}}`,
+			expectedErr: errInvalidResponseData,
+			errContains: "code: 2001, message: Symbol not found",
+		},
+		"HitBTC gateway error": {
+			exchange:    HitbtcName,
+			base:        "BTC",
+			quote:       "USDT",
+			expectedURI: "/api/3/public/ticker/BTCUSDT",
+			statusCode:  http.StatusBadGateway,
+			response:    `<html>bad gateway</html>`,
+			expectedErr: aggregator.ErrUnexpectedHTTPStatus,
+			errContains: "status 502 for",
+		},
+		"MEXC ticker": {
+			exchange:      MexcName,
+			base:          "BTC",
+			quote:         "USDT",
+			expectedURI:   "/api/v3/ticker/price?symbol=BTCUSDT",
+			statusCode:    http.StatusOK,
+			response:      `{"symbol":"BTCUSDT","price":"62343.99"}`,
+			expectedPrice: 62343.99,
+		},
+		"MEXC error code": {
+			exchange:    MexcName,
+			base:        "BTC",
+			quote:       "XYZ",
+			expectedURI: "/api/v3/ticker/price?symbol=BTCXYZ",
+			statusCode:  http.StatusBadRequest,
+			response:    `{"code":-1121,"msg":"Invalid symbol."}`,
+			expectedErr: errInvalidResponseData,
+			errContains: "code: -1121, message: Invalid symbol.",
+		},
+	}
+
+	for name, fixture := range fixtures {
+		fixture := fixture
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			testResponseFixture(t, fixture)
+		})
+	}
+}
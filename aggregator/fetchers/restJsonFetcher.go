@@ -0,0 +1,303 @@
+package fetchers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+)
+
+const (
+	symbolTransformNone  = ""
+	symbolTransformUpper = "upper"
+	symbolTransformLower = "lower"
+
+	basePlaceholder   = "{base}"
+	quotePlaceholder  = "{quote}"
+	marketPlaceholder = "{market}"
+
+	defaultRestJSONMarketFormat = basePlaceholder + quotePlaceholder
+	jsonPathSeparator           = "."
+	millisecondsThreshold       = 1e12
+)
+
+// RestJSONFetcherConfig represents the config DTO used for a declarative price fetcher querying a REST API that
+// responds with JSON
+type RestJSONFetcherConfig struct {
+	// Name is the fetcher name, used in the pairs' exchanges lists
+	Name string
+	// URLTemplate is the URL queried for a pair. The {base}, {quote} and {market} placeholders are replaced
+	URLTemplate string
+	// MarketFormat defines how the {market} placeholder is built from the {base} and {quote} placeholders when the
+	// market is not explicitly mapped. Defaults to {base}{quote}
+	MarketFormat string
+	// PricePath is the dot separated path of the price in the JSON response, e.g. data.0.last
+	PricePath string
+	// TimestampPath is the optional path of the price timestamp, either in seconds, milliseconds or RFC3339 format
+	TimestampPath string
+	// MaxPriceAgeInSeconds rejects prices older than this value. Used only when the TimestampPath is set
+	MaxPriceAgeInSeconds uint64
+	// VolumePath is the optional path of the traded volume
+	VolumePath string
+	// MinVolume rejects prices with a lower traded volume. Used only when the VolumePath is set
+	MinVolume float64
+	// Headers are set on each request
+	Headers map[string]string
+	// SymbolTransform is applied to the base and quote symbols. Valid options are "", "upper" and "lower"
+	SymbolTransform string
+}
+
+type restJSONFetcher struct {
+	aggregator.ResponseGetter
+	config RestJSONFetcherConfig
+	baseFetcher
+	timeSinceHandler func(t time.Time) time.Duration
+}
+
+func newRestJSONFetcher(args ArgsPriceFetcher) (*restJSONFetcher, error) {
+	err := checkRestJSONFetcherConfig(args.RestJSONConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	config := args.RestJSONConfig
+	if len(config.MarketFormat) == 0 {
+		config.MarketFormat = defaultRestJSONMarketFormat
+	}
+
+	return &restJSONFetcher{
+		ResponseGetter:   args.ResponseGetter,
+		config:           config,
+		baseFetcher:      newBaseFetcher(args.QuoteMappings, args.SymbolMapping),
+		timeSinceHandler: time.Since,
+	}, nil
+}
+
+// CheckRestJSONFetcherConfigs checks the REST JSON fetcher configs, rejecting the duplicated names since the
+// fetchers, the rate limits and the source IPs are keyed by name
+func CheckRestJSONFetcherConfigs(configs []RestJSONFetcherConfig) error {
+	names := make(map[string]struct{}, len(configs))
+	for _, config := range configs {
+		err := checkRestJSONFetcherConfig(config)
+		if err != nil {
+			return err
+		}
+		if _, found := names[config.Name]; found {
+			return fmt.Errorf("%w: duplicated name %s", errInvalidRestJSONConfig, config.Name)
+		}
+		names[config.Name] = struct{}{}
+	}
+
+	return nil
+}
+
+func checkRestJSONFetcherConfig(config RestJSONFetcherConfig) error {
+	if len(config.Name) == 0 {
+		return fmt.Errorf("%w: empty name", errInvalidRestJSONConfig)
+	}
+	if _, found := ImplementedFetchers[config.Name]; found {
+		return fmt.Errorf("%w: name %s is already used by an implemented fetcher", errInvalidRestJSONConfig, config.Name)
+	}
+	if CurrentExchangeName(config.Name) != config.Name {
+		return fmt.Errorf("%w: name %s is the former name of an implemented fetcher", errInvalidRestJSONConfig, config.Name)
+	}
+	if len(config.URLTemplate) == 0 {
+		return fmt.Errorf("%w: empty URL template for %s", errInvalidRestJSONConfig, config.Name)
+	}
+	if len(config.PricePath) == 0 {
+		return fmt.Errorf("%w: empty price path for %s", errInvalidRestJSONConfig, config.Name)
+	}
+
+	switch config.SymbolTransform {
+	case symbolTransformNone, symbolTransformUpper, symbolTransformLower:
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown symbol transform %q for %s", errInvalidRestJSONConfig, config.SymbolTransform, config.Name)
+	}
+}
+
+// FetchPrice will fetch the price using the http client
+func (r *restJSONFetcher) FetchPrice(ctx context.Context, base string, quote string) (float64, error) {
+	if !r.hasPair(base, quote) {
+		return 0, aggregator.ErrPairNotSupported
+	}
+
+	quote = r.MappedQuote(quote)
+
+	var response interface{}
+	ctx = aggregator.WithRequestHeaders(ctx, r.config.Headers)
+	err := r.ResponseGetter.Get(ctx, r.buildURL(base, quote), &response)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(r.config.TimestampPath) > 0 {
+		err = r.checkTimestamp(response)
+		if err != nil {
+			return 0, err
+		}
+	}
+	if len(r.config.VolumePath) > 0 {
+		err = r.checkVolume(response)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	priceValue, err := valueAtJSONPath(response, r.config.PricePath)
+	if err != nil {
+		return 0, err
+	}
+
+	return jsonValueToPositiveFloat64(priceValue)
+}
+
+func (r *restJSONFetcher) buildURL(base string, quote string) string {
+	baseSymbol := r.transformSymbol(r.exchangeSymbol(base))
+	quoteSymbol := r.transformSymbol(r.exchangeSymbol(quote))
+	market := r.marketID(base, quote, func(_ string, _ string) string {
+		return strings.NewReplacer(basePlaceholder, baseSymbol, quotePlaceholder, quoteSymbol).Replace(r.config.MarketFormat)
+	})
+
+	replacer := strings.NewReplacer(
+		basePlaceholder, baseSymbol,
+		quotePlaceholder, quoteSymbol,
+		marketPlaceholder, market,
+	)
+
+	return replacer.Replace(r.config.URLTemplate)
+}
+
+func (r *restJSONFetcher) transformSymbol(symbol string) string {
+	switch r.config.SymbolTransform {
+	case symbolTransformUpper:
+		return strings.ToUpper(symbol)
+	case symbolTransformLower:
+		return strings.ToLower(symbol)
+	default:
+		return symbol
+	}
+}
+
+func (r *restJSONFetcher) checkTimestamp(response interface{}) error {
+	value, err := valueAtJSONPath(response, r.config.TimestampPath)
+	if err != nil {
+		return err
+	}
+
+	timestamp, err := jsonValueToTime(value)
+	if err != nil {
+		return err
+	}
+
+	maxAge := time.Duration(r.config.MaxPriceAgeInSeconds) * time.Second
+	age := r.timeSinceHandler(timestamp)
+	if maxAge > 0 && age > maxAge {
+		return fmt.Errorf("%w, price age %v exceeds %v", errStalePrice, age, maxAge)
+	}
+
+	return nil
+}
+
+func (r *restJSONFetcher) checkVolume(response interface{}) error {
+	value, err := valueAtJSONPath(response, r.config.VolumePath)
+	if err != nil {
+		return err
+	}
+
+	volume, err := jsonValueToFloat64(value)
+	if err != nil {
+		return err
+	}
+	if volume < r.config.MinVolume {
+		return fmt.Errorf("%w, volume %v is lower than %v", errLowVolume, volume, r.config.MinVolume)
+	}
+
+	return nil
+}
+
+func valueAtJSONPath(response interface{}, path string) (interface{}, error) {
+	current := response
+	for _, segment := range strings.Split(path, jsonPathSeparator) {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, found := node[segment]
+			if !found {
+				return nil, fmt.Errorf("%w, missing key %q from path %s", errInvalidResponseData, segment, path)
+			}
+			current = value
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, fmt.Errorf("%w, invalid index %q from path %s", errInvalidResponseData, segment, path)
+			}
+			current = node[index]
+		default:
+			return nil, fmt.Errorf("%w, can not resolve %q from path %s", errInvalidResponseData, segment, path)
+		}
+	}
+
+	return current, nil
+}
+
+func jsonValueToFloat64(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	default:
+		return 0, errInvalidResponseData
+	}
+}
+
+func jsonValueToPositiveFloat64(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		if v <= 0 {
+			return 0, errInvalidResponseData
+		}
+		return v, nil
+	case string:
+		return StrToPositiveFloat64(v)
+	default:
+		return 0, errInvalidResponseData
+	}
+}
+
+func jsonValueToTime(value interface{}) (time.Time, error) {
+	if str, ok := value.(string); ok {
+		timestamp, err := time.Parse(time.RFC3339, str)
+		if err == nil {
+			return timestamp, nil
+		}
+	}
+
+	numeric, err := jsonValueToFloat64(value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if numeric >= millisecondsThreshold {
+		return time.UnixMilli(int64(numeric)), nil
+	}
+
+	return time.Unix(int64(numeric), 0), nil
+}
+
+// FetchPrices fetches the prices of the provided pairs, with one request for each pair
+func (r *restJSONFetcher) FetchPrices(ctx context.Context, pairs []aggregator.BaseQuote) map[aggregator.BaseQuote]aggregator.PriceResult {
+	return fetchPricesConcurrently(ctx, r.FetchPrice, pairs)
+}
+
+// Name returns the name
+func (r *restJSONFetcher) Name() string {
+	return r.config.Name
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (r *restJSONFetcher) IsInterfaceNil() bool {
+	return r == nil
+}
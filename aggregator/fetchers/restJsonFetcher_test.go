@@ -0,0 +1,274 @@
+package fetchers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+	"github.com/klever-io/klv-oracles-go/aggregator/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMockRestJSONFetcherConfig() RestJSONFetcherConfig {
+	return RestJSONFetcherConfig{
+		Name:        "custom source",
+		URLTemplate: "https://api.custom.io/ticker?symbol={market}",
+		PricePath:   "data.0.last",
+	}
+}
+
+func createRestJSONFetcher(t *testing.T, config RestJSONFetcherConfig, responseGetter aggregator.ResponseGetter) *restJSONFetcher {
+	args := createMockArgsPriceFetcher()
+	args.FetcherName = RestJSONFetcherName
+	args.RestJSONConfig = config
+	args.ResponseGetter = responseGetter
+
+	fetcher, err := newRestJSONFetcher(args)
+	require.Nil(t, err)
+
+	return fetcher
+}
+
+func TestNewRestJSONFetcher(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid configs should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsPriceFetcher()
+		args.RestJSONConfig = createMockRestJSONFetcherConfig()
+		args.RestJSONConfig.Name = ""
+		fetcher, err := newRestJSONFetcher(args)
+		assert.Nil(t, fetcher)
+		assert.True(t, errors.Is(err, errInvalidRestJSONConfig))
+
+		args.RestJSONConfig = createMockRestJSONFetcherConfig()
+		args.RestJSONConfig.Name = BinanceName
+		fetcher, err = newRestJSONFetcher(args)
+		assert.Nil(t, fetcher)
+		assert.True(t, errors.Is(err, errInvalidRestJSONConfig))
+
+		args.RestJSONConfig = createMockRestJSONFetcherConfig()
+		args.RestJSONConfig.URLTemplate = ""
+		fetcher, err = newRestJSONFetcher(args)
+		assert.Nil(t, fetcher)
+		assert.True(t, errors.Is(err, errInvalidRestJSONConfig))
+
+		args.RestJSONConfig = createMockRestJSONFetcherConfig()
+		args.RestJSONConfig.PricePath = ""
+		fetcher, err = newRestJSONFetcher(args)
+		assert.Nil(t, fetcher)
+		assert.True(t, errors.Is(err, errInvalidRestJSONConfig))
+
+		args.RestJSONConfig = createMockRestJSONFetcherConfig()
+		args.RestJSONConfig.SymbolTransform = "camel"
+		fetcher, err = newRestJSONFetcher(args)
+		assert.Nil(t, fetcher)
+		assert.True(t, errors.Is(err, errInvalidRestJSONConfig))
+		assert.True(t, strings.Contains(err.Error(), "camel"))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsPriceFetcher()
+		args.RestJSONConfig = createMockRestJSONFetcherConfig()
+		fetcher, err := newRestJSONFetcher(args)
+		assert.Nil(t, err)
+		assert.False(t, fetcher.IsInterfaceNil())
+		assert.Equal(t, defaultRestJSONMarketFormat, fetcher.config.MarketFormat)
+	})
+}
+
+func TestCheckRestJSONFetcherConfigs(t *testing.T) {
+	t.Parallel()
+
+	first := createMockRestJSONFetcherConfig()
+	second := createMockRestJSONFetcherConfig()
+	second.Name = "another source"
+	assert.Nil(t, CheckRestJSONFetcherConfigs(nil))
+	assert.Nil(t, CheckRestJSONFetcherConfigs([]RestJSONFetcherConfig{first, second}))
+
+	err := CheckRestJSONFetcherConfigs([]RestJSONFetcherConfig{first, second, first})
+	assert.True(t, errors.Is(err, errInvalidRestJSONConfig))
+	assert.True(t, strings.Contains(err.Error(), "duplicated name custom source"))
+
+	second.Name = HuobiName
+	err = CheckRestJSONFetcherConfigs([]RestJSONFetcherConfig{first, second})
+	assert.True(t, errors.Is(err, errInvalidRestJSONConfig))
+
+	second.Name = KrakenName
+	err = CheckRestJSONFetcherConfigs([]RestJSONFetcherConfig{first, second})
+	assert.True(t, errors.Is(err, errInvalidRestJSONConfig))
+}
+
+func TestRestJSONFetcher_FetchPrice(t *testing.T) {
+	t.Parallel()
+
+	t.Run("pair not added should error", func(t *testing.T) {
+		t.Parallel()
+
+		fetcher := createRestJSONFetcher(t, createMockRestJSONFetcherConfig(), &mock.HttpResponseGetterStub{})
+		price, err := fetcher.FetchPrice(context.Background(), "ETH", quoteUSDFiat)
+		assert.Equal(t, aggregator.ErrPairNotSupported, err)
+		assert.Zero(t, price)
+	})
+	t.Run("response getter errors should error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		fetcher := createRestJSONFetcher(t, createMockRestJSONFetcherConfig(), &mock.HttpResponseGetterStub{
+			GetCalled: func(ctx context.Context, url string, response interface{}) error {
+				return expectedErr
+			},
+		})
+		fetcher.AddPair("ETH", quoteUSDFiat)
+		price, err := fetcher.FetchPrice(context.Background(), "ETH", quoteUSDFiat)
+		assert.Equal(t, expectedErr, err)
+		assert.Zero(t, price)
+	})
+	t.Run("should query the API and parse the response", func(t *testing.T) {
+		t.Parallel()
+
+		httpServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "/ticker", req.URL.Path)
+			assert.Equal(t, "eth-usdt", req.URL.Query().Get("symbol"))
+			assert.Equal(t, "secret", req.Header.Get("X-Api-Key"))
+
+			rw.WriteHeader(http.StatusOK)
+			_, _ = rw.Write([]byte(`{"data":[{"last":"4714.05","vol":1200.5,"ts":1700000000000}]}`))
+		}))
+		defer httpServer.Close()
+
+		responseGetter, _ := aggregator.NewHttpResponseGetter(aggregator.DefaultArgsHttpResponseGetter())
+		config := createMockRestJSONFetcherConfig()
+		config.URLTemplate = httpServer.URL + "/ticker?symbol={market}"
+		config.MarketFormat = "{base}-{quote}"
+		config.SymbolTransform = symbolTransformLower
+		config.Headers = map[string]string{"X-Api-Key": "secret"}
+		config.VolumePath = "data.0.vol"
+		config.MinVolume = 1000
+		config.TimestampPath = "data.0.ts"
+		config.MaxPriceAgeInSeconds = 60
+
+		args := createMockArgsPriceFetcher()
+		args.FetcherName = RestJSONFetcherName
+		args.RestJSONConfig = config
+		args.ResponseGetter = responseGetter
+		args.QuoteMappings = map[string]string{quoteUSDFiat: "USDT"}
+		pf, err := NewPriceFetcher(args)
+		require.Nil(t, err)
+
+		fetcher := pf.(*restJSONFetcher)
+		fetcher.timeSinceHandler = func(t time.Time) time.Duration {
+			return time.UnixMilli(1700000030000).Sub(t)
+		}
+		fetcher.AddPair("ETH", quoteUSDFiat)
+		price, err := fetcher.FetchPrice(context.Background(), "ETH", quoteUSDFiat)
+		assert.Nil(t, err)
+		assert.Equal(t, 4714.05, price)
+	})
+	t.Run("placeholders and mapped markets should be replaced", func(t *testing.T) {
+		t.Parallel()
+
+		urls := make([]string, 0)
+		config := createMockRestJSONFetcherConfig()
+		config.URLTemplate = "https://api.custom.io/{base}/{quote}?market={market}"
+		config.SymbolTransform = symbolTransformUpper
+		fetcher := createRestJSONFetcher(t, config, &mock.HttpResponseGetterStub{
+			GetCalled: func(ctx context.Context, url string, response interface{}) error {
+				urls = append(urls, url)
+				return nil
+			},
+		})
+		fetcher.markets = map[string]string{"KLV-USD": "klever_usd"}
+		fetcher.assets = map[string]string{"btc": "xbt"}
+		fetcher.AddPair("btc", "usd")
+		fetcher.AddPair("KLV", "USD")
+
+		_, _ = fetcher.FetchPrice(context.Background(), "btc", "usd")
+		_, _ = fetcher.FetchPrice(context.Background(), "KLV", "USD")
+		expectedURLs := []string{
+			"https://api.custom.io/XBT/USD?market=XBTUSD",
+			"https://api.custom.io/KLV/USD?market=klever_usd",
+		}
+		assert.Equal(t, expectedURLs, urls)
+	})
+	t.Run("numeric price should work", func(t *testing.T) {
+		t.Parallel()
+
+		config := createMockRestJSONFetcherConfig()
+		config.PricePath = "price"
+		fetcher := createRestJSONFetcher(t, config, createRawResponseGetter(map[string]interface{}{"price": 12.5}))
+		fetcher.AddPair("ETH", quoteUSDFiat)
+		price, err := fetcher.FetchPrice(context.Background(), "ETH", quoteUSDFiat)
+		assert.Nil(t, err)
+		assert.Equal(t, 12.5, price)
+	})
+	t.Run("invalid responses should error", func(t *testing.T) {
+		t.Parallel()
+
+		responses := []interface{}{
+			map[string]interface{}{"data": []interface{}{}},
+			map[string]interface{}{"data": []interface{}{map[string]interface{}{"bid": "1"}}},
+			map[string]interface{}{"data": "value"},
+			map[string]interface{}{"data": []interface{}{map[string]interface{}{"last": "-1"}}},
+			map[string]interface{}{"data": []interface{}{map[string]interface{}{"last": 0.0}}},
+			map[string]interface{}{"data": []interface{}{map[string]interface{}{"last": true}}},
+			nil,
+		}
+		for _, response := range responses {
+			fetcher := createRestJSONFetcher(t, createMockRestJSONFetcherConfig(), createRawResponseGetter(response))
+			fetcher.AddPair("ETH", quoteUSDFiat)
+			price, err := fetcher.FetchPrice(context.Background(), "ETH", quoteUSDFiat)
+			assert.True(t, errors.Is(err, errInvalidResponseData), "response %v", response)
+			assert.Zero(t, price)
+		}
+	})
+	t.Run("stale price should error", func(t *testing.T) {
+		t.Parallel()
+
+		config := createMockRestJSONFetcherConfig()
+		config.PricePath = "price"
+		config.TimestampPath = "time"
+		config.MaxPriceAgeInSeconds = 10
+		response := map[string]interface{}{"price": "1.5", "time": "2024-01-01T10:00:00Z"}
+		fetcher := createRestJSONFetcher(t, config, createRawResponseGetter(response))
+		fetcher.timeSinceHandler = func(t time.Time) time.Duration {
+			return time.Date(2024, 1, 1, 10, 0, 11, 0, time.UTC).Sub(t)
+		}
+		fetcher.AddPair("ETH", quoteUSDFiat)
+		price, err := fetcher.FetchPrice(context.Background(), "ETH", quoteUSDFiat)
+		assert.True(t, errors.Is(err, errStalePrice))
+		assert.Zero(t, price)
+	})
+	t.Run("low volume should error", func(t *testing.T) {
+		t.Parallel()
+
+		config := createMockRestJSONFetcherConfig()
+		config.PricePath = "price"
+		config.VolumePath = "volume"
+		config.MinVolume = 100
+		response := map[string]interface{}{"price": "1.5", "volume": "99.9"}
+		fetcher := createRestJSONFetcher(t, config, createRawResponseGetter(response))
+		fetcher.AddPair("ETH", quoteUSDFiat)
+		price, err := fetcher.FetchPrice(context.Background(), "ETH", quoteUSDFiat)
+		assert.True(t, errors.Is(err, errLowVolume))
+		assert.Zero(t, price)
+	})
+}
+
+func createRawResponseGetter(rawResponse interface{}) *mock.HttpResponseGetterStub {
+	return &mock.HttpResponseGetterStub{
+		GetCalled: func(ctx context.Context, url string, response interface{}) error {
+			cast, _ := response.(*interface{})
+			*cast = rawResponse
+			return nil
+		},
+	}
+}
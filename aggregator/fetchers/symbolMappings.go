@@ -0,0 +1,44 @@
+package fetchers
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+)
+
+type symbolMappingsHandler interface {
+	unusedSymbolMappings() []string
+}
+
+// CheckSymbolMappings returns a description for each configured symbol mapping that will never be used: the mappings
+// of exchanges that do not have a price fetcher and the asset and market mappings that are not used by the pairs
+// added in the exchange's price fetcher. It should be called after all the pairs were added in the price fetchers
+func CheckSymbolMappings(symbolMappings map[string]SymbolMapping, priceFetchers []aggregator.PriceFetcher) []string {
+	fetchersByName := make(map[string]aggregator.PriceFetcher, len(priceFetchers))
+	for _, priceFetcher := range priceFetchers {
+		fetchersByName[priceFetcher.Name()] = priceFetcher
+	}
+
+	unknownMappings := make([]string, 0)
+	for exchange := range symbolMappings {
+		priceFetcher, found := fetchersByName[exchange]
+		if !found {
+			unknownMappings = append(unknownMappings, fmt.Sprintf("%s: unknown exchange", exchange))
+			continue
+		}
+
+		handler, ok := priceFetcher.(symbolMappingsHandler)
+		if !ok {
+			unknownMappings = append(unknownMappings, fmt.Sprintf("%s: exchange does not use symbol mappings", exchange))
+			continue
+		}
+
+		for _, unusedMapping := range handler.unusedSymbolMappings() {
+			unknownMappings = append(unknownMappings, fmt.Sprintf("%s: %s is not used by any pair", exchange, unusedMapping))
+		}
+	}
+	sort.Strings(unknownMappings)
+
+	return unknownMappings
+}
@@ -0,0 +1,53 @@
+package fetchers
+
+import (
+	"testing"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+	"github.com/klever-io/klv-oracles-go/aggregator/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckSymbolMappings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no mappings should not report", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsPriceFetcher()
+		binanceFetcher, _ := NewPriceFetcher(args)
+
+		unknownMappings := CheckSymbolMappings(nil, []aggregator.PriceFetcher{binanceFetcher})
+		assert.Empty(t, unknownMappings)
+	})
+	t.Run("should report unknown and unused mappings", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsPriceFetcher()
+		args.FetcherName = KrakenName
+		args.SymbolMapping = SymbolMapping{
+			Assets: map[string]string{"BTC": "XBT", "DOGE": "XDG"},
+		}
+		krakenFetcher, _ := NewPriceFetcher(args)
+		krakenFetcher.AddPair("BTC", "USD")
+
+		stubFetcher := &mock.PriceFetcherStub{
+			NameCalled: func() string {
+				return "stub"
+			},
+		}
+
+		symbolMappings := map[string]SymbolMapping{
+			KrakenName:         args.SymbolMapping,
+			"Unknown":          {},
+			stubFetcher.Name(): {},
+		}
+		unknownMappings := CheckSymbolMappings(symbolMappings, []aggregator.PriceFetcher{krakenFetcher, stubFetcher})
+		expectedUnknownMappings := []string{
+			"Kraken: asset DOGE is not used by any pair",
+			"Unknown: unknown exchange",
+			"stub: exchange does not use symbol mappings",
+		}
+		assert.Equal(t, expectedUnknownMappings, unknownMappings)
+	})
+}
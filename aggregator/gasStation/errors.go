@@ -7,10 +7,21 @@ var (
 	ErrNilGasPriceFetcher = errors.New("nil gas price fetcher")
 	// ErrMismatchFetchedPricesLen signals that there is a mismatch between the pairs and fetched prices length
 	ErrMismatchFetchedPricesLen = errors.New("mismatch between pairs and fetched prices length")
-	// ErrEthUsdPriceZero signals that the ETH/USD price is zero, making gas price calculation impossible
-	ErrEthUsdPriceZero = errors.New("ETH/USD price is zero, gas price calculation not possible")
-	// ErrMissingPairs signals that the pairs required for gas price conversion are missing
-	ErrMissingPairs = errors.New("missing pairs for gas price conversion")
+	// ErrNilPriceAggregator signals that a nil price aggregator was provided
+	ErrNilPriceAggregator = errors.New("nil price aggregator")
+	// ErrReferencePriceZero signals that the USD price of a chain native token or of a gas pair quote is zero, making
+	// gas price calculation impossible
+	ErrReferencePriceZero = errors.New("reference USD price is zero, gas price calculation not possible")
+	// ErrInvalidMinNumberOfResults signals that an invalid minimum number of results was provided
+	ErrInvalidMinNumberOfResults = errors.New("invalid minimum number of results")
+	// ErrNotEnoughGasResults signals that too few gas sources responded
+	ErrNotEnoughGasResults = errors.New("not enough gas sources responded")
+	// ErrInvalidGasSmoothing signals that an invalid gas smoothing was provided
+	ErrInvalidGasSmoothing = errors.New("invalid gas smoothing")
+	// ErrInvalidGasChain signals that an invalid gas chain was provided
+	ErrInvalidGasChain = errors.New("invalid gas chain")
+	// ErrUnknownGasChain signals that a gas pair refers to a chain that was not provided
+	ErrUnknownGasChain = errors.New("unknown gas chain")
 	// ErrNoGweiPairs signals that no Gas pairs were found for gas price calculation
 	ErrNoGasPairs = errors.New("no Gas pairs found for gas price calculation")
 )
@@ -3,29 +3,54 @@ package gas
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/multiversx/mx-chain-core-go/core/check"
+	logger "github.com/multiversx/mx-chain-logger-go"
 )
 
+var log = logger.GetOrCreate("klv-oracle-go/aggregator/gasStation")
+
 const gweiTicker = "GWEI"
 const ethTicker = "ETH"
 const quoteUSD = "USD"
-const weiNeg = 1e-9 // Gwei to ETH conversion factor
+const weiNeg = 1e-9 // Gwei to native token conversion factor
 
 type ArgsPairInfo struct {
 	Base      string  // Base currency ticker
 	Quote     string  // Quote currency ticker
+	Chain     string  // Chain of a gas pair, empty for the default chain and for the other pairs
 	Price     float64 // Price of the pair
 	Timestamp int64   // Timestamp of the price
 }
 
+// ArgsGasChain describes an EVM chain whose gas prices are provided
+type ArgsGasChain struct {
+	Chain            string         // Chain identifier, as used by the gas pairs
+	NativeToken      string         // Ticker of the token the gas is paid in, its USD price must be among the pairs
+	GasPriceFetchers []PriceFetcher // Sources of the gas prices of the chain
+	MinResultsNum    int            // Minimum number of sources that must respond
+}
+
 // ArgsGasPriceService is the DTO used to create a new GasPriceService
 type ArgsGasPriceService struct {
-	GasPriceFetcher PriceFetcher // Fetcher for gas prices
+	PriceAggregator  PriceAggregator    // Source of the reference prices the gas prices are converted with
+	GasPriceFetchers []PriceFetcher     // Sources of the gas prices of the default chain, paid in ETH
+	MinResultsNum    int                // Minimum number of sources of the default chain that must respond
+	Chains           []ArgsGasChain     // The other chains
+	Smoothing        []ArgsGasSmoothing // Smoothing of the gas values of some gas pairs, none by default
+}
+
+type gasChain struct {
+	nativeToken string
+	sources     *gasSources
 }
 
 type gasPriceService struct {
-	gasPriceFetcher PriceFetcher
+	priceAggregator PriceAggregator
+	chains          map[string]*gasChain
+	mutSmoothing    sync.RWMutex
+	smoothing       map[pairKey]*pairSmoothing
 }
 
 // NewGasPriceService creates a new instance of the gas price service
@@ -34,55 +59,118 @@ func NewGasPriceService(args ArgsGasPriceService) (*gasPriceService, error) {
 		return nil, err
 	}
 
+	chains := make(map[string]*gasChain, len(args.Chains)+1)
+	if len(args.GasPriceFetchers) > 0 {
+		chains[""] = &gasChain{
+			nativeToken: ethTicker,
+			sources:     newGasSources("", args.GasPriceFetchers, args.MinResultsNum),
+		}
+	}
+	for _, chain := range args.Chains {
+		chains[chain.Chain] = &gasChain{
+			nativeToken: chain.NativeToken,
+			sources:     newGasSources(chain.Chain, chain.GasPriceFetchers, chain.MinResultsNum),
+		}
+	}
+
+	smoothing := make(map[pairKey]*pairSmoothing, len(args.Smoothing))
+	for _, smoothingArgs := range args.Smoothing {
+		key := pairKey{chain: smoothingArgs.Chain, base: smoothingArgs.Base, quote: smoothingArgs.Quote}
+		if _, found := smoothing[key]; found {
+			return nil, fmt.Errorf("%w, duplicated smoothing for %s", ErrInvalidGasSmoothing, key)
+		}
+
+		smoother, err := newGasSmoother(smoothingArgs)
+		if err != nil {
+			return nil, err
+		}
+		smoothing[key] = &pairSmoothing{
+			smoother: smoother,
+		}
+	}
+
 	return &gasPriceService{
-		gasPriceFetcher: args.GasPriceFetcher,
+		priceAggregator: args.PriceAggregator,
+		chains:          chains,
+		smoothing:       smoothing,
 	}, nil
 }
 
 func checkArgsGasPriceService(args ArgsGasPriceService) error {
-	if check.IfNil(args.GasPriceFetcher) {
+	if check.IfNil(args.PriceAggregator) {
+		return ErrNilPriceAggregator
+	}
+	if len(args.GasPriceFetchers) == 0 && len(args.Chains) == 0 {
 		return ErrNilGasPriceFetcher
 	}
+	if len(args.GasPriceFetchers) > 0 {
+		err := checkGasSources("", args.GasPriceFetchers, args.MinResultsNum)
+		if err != nil {
+			return err
+		}
+	}
+
+	chains := make(map[string]struct{}, len(args.Chains))
+	for _, chain := range args.Chains {
+		if len(chain.Chain) == 0 || len(chain.NativeToken) == 0 {
+			return fmt.Errorf("%w, chain %q with native token %q", ErrInvalidGasChain, chain.Chain, chain.NativeToken)
+		}
+		if len(chain.GasPriceFetchers) == 0 {
+			return fmt.Errorf("%w for chain %s", ErrNilGasPriceFetcher, chain.Chain)
+		}
+		err := checkGasSources(chain.Chain, chain.GasPriceFetchers, chain.MinResultsNum)
+		if err != nil {
+			return err
+		}
+		if _, found := chains[chain.Chain]; found {
+			return fmt.Errorf("%w, duplicated chain %s", ErrInvalidGasChain, chain.Chain)
+		}
+		chains[chain.Chain] = struct{}{}
+	}
 
 	return nil
 }
 
-// ConvertGasPrices converts gas prices in GWEI to various denominations
-func (gps *gasPriceService) ConvertGasPrices(ctx context.Context, pairs []ArgsPairInfo) ([]ArgsPairInfo, error) {
-	err := gps.VerifyRequiredPairs(pairs)
-	if err == ErrNoGasPairs {
-		// If no gas pairs are found, return the pairs as is
-		return pairs, nil
+func checkGasSources(chain string, fetchers []PriceFetcher, minResultsNum int) error {
+	for idx, fetcher := range fetchers {
+		if check.IfNil(fetcher) {
+			return fmt.Errorf("%w, index %d for chain %q", ErrNilGasPriceFetcher, idx, chain)
+		}
 	}
+	if minResultsNum < 1 {
+		return fmt.Errorf("%w, minimum %d for chain %q", ErrInvalidMinNumberOfResults, minResultsNum, chain)
+	}
+	if len(fetchers) < minResultsNum {
+		return fmt.Errorf("%w, %d gas sources for a minimum of %d results for chain %q",
+			ErrInvalidMinNumberOfResults, len(fetchers), minResultsNum, chain)
+	}
+
+	return nil
+}
 
+// ConvertGasPrices converts gas prices in GWEI to various denominations. The pairs quoted in GWEI are gas fee feeds,
+// like the base fee, fetched as they are
+func (gps *gasPriceService) ConvertGasPrices(ctx context.Context, pairs []ArgsPairInfo) ([]ArgsPairInfo, error) {
+	pairs, err := gps.fetchGasFeeds(ctx, pairs)
 	if err != nil {
 		return nil, err
 	}
 
-	// Find the ETH/USD price from the cached index
-	ethPrice := 0.0
-	for _, pair := range pairs {
-		if pair.Base == ethTicker && pair.Quote == quoteUSD {
-			ethPrice = pair.Price
-			break
-		}
-	}
-
-	if ethPrice == 0.0 {
-		return nil, ErrEthUsdPriceZero
+	err = gps.VerifyRequiredPairs(pairs)
+	if err == ErrNoGasPairs {
+		// If no gas pairs are found, return the pairs as is
+		return pairs, nil
 	}
 
-	// Fetch the actual GWEI value using the gas price fetcher
-	gasPrice, err := gps.gasPriceFetcher.FetchPrice(ctx, gweiTicker, quoteUSD)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch gas price in GWEI: %w", err)
+		return nil, err
 	}
 
-	// Find token/USD prices for all tokens that need gas price denominated
-	targetTokensIndex := make(map[string]int)
-	for idx, pair := range pairs {
-		if pair.Quote == quoteUSD && pair.Base != ethTicker && pair.Base != gweiTicker {
-			targetTokensIndex[pair.Base] = idx
+	// The token/USD prices already fetched are reused, the others are requested from the price aggregator
+	references := newReferencePrices(gps.priceAggregator)
+	for _, pair := range pairs {
+		if pair.Quote == quoteUSD && !isGasPair(pair) && pair.Price > 0 {
+			references.usdPrices[pair.Base] = pair.Price
 		}
 	}
 
@@ -90,85 +178,131 @@ func (gps *gasPriceService) ConvertGasPrices(ctx context.Context, pairs []ArgsPa
 	result := make([]ArgsPairInfo, len(pairs))
 	copy(result, pairs)
 
-	// Process each GWEI pair using the cached indices from gasPricePairs
+	// The gas price of each chain is fetched once, for all its GWEI pairs
+	gasPrices := make(map[string]float64)
 	for idx, pair := range result {
 		if pair.Base != gweiTicker {
 			continue
 		}
 
-		gweiAsEth := gasPrice * weiNeg
-		nominalValue := ethPrice * gweiAsEth
-
-		// For GWEI/USD just use the nominal value directly
-		if pair.Quote == quoteUSD {
-			result[idx].Price = nominalValue
-			continue
+		chain := gps.chains[pair.Chain]
+		nativePrice, err := references.crossRate(ctx, chain.nativeToken, pair.Quote)
+		if err != nil {
+			return nil, fmt.Errorf("%w for the gas price of chain %q", err, pair.Chain)
 		}
 
-		// For other tokens, divide by their USD price
-		targetIndex, ok := targetTokensIndex[pair.Quote]
-		if !ok {
-			return nil, fmt.Errorf("%w: %s/%s pair for gas price calculation", ErrMissingPairs, pair.Quote, quoteUSD)
+		gasPrice, found := gasPrices[pair.Chain]
+		if !found {
+			gasPrice, err = chain.sources.fetchGasPrice(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch gas price in GWEI for chain %q: %w", pair.Chain, err)
+			}
+			gasPrices[pair.Chain] = gasPrice
 		}
 
-		result[idx].Price = nominalValue / result[targetIndex].Price
+		gweiAsNativeToken := gps.smooth(pair, gasPrice) * weiNeg
+		result[idx].Price = nativePrice * gweiAsNativeToken
 	}
 
 	return result, nil
 }
 
-// VerifyRequiredPairs checks if all required pairs for gas price calculation are available
-func (gps *gasPriceService) VerifyRequiredPairs(pairs []ArgsPairInfo) error {
-	// Check if ETH/USD pair exists
-	hasEthUsd := false
-	// Create maps for token/USD pairs that are used for gas price calculation in different tokens
-	gweiPairsMap := make(map[string]bool)
-	tokenUsdPairsMap := make(map[string]bool)
+func (gps *gasPriceService) fetchGasFeeds(ctx context.Context, pairs []ArgsPairInfo) ([]ArgsPairInfo, error) {
+	result := make([]ArgsPairInfo, len(pairs))
+	copy(result, pairs)
 
-	for _, pair := range pairs {
-		// Check for ETH/USD pair which is required for all gas price calculations
-		if pair.Base == ethTicker && pair.Quote == quoteUSD {
-			hasEthUsd = true
+	for idx, pair := range result {
+		if pair.Quote != gweiTicker || pair.Base == gweiTicker {
+			continue
 		}
 
-		// Store indices of GWEI pairs
-		if pair.Base == gweiTicker {
-			gweiPairsMap[pair.Quote] = true
+		chain, found := gps.chains[pair.Chain]
+		if !found {
+			return nil, fmt.Errorf("%w %q for the %s gas feed", ErrUnknownGasChain, pair.Chain, pair.Base)
 		}
 
-		if pair.Quote != quoteUSD {
-			continue
+		feedValue, err := chain.sources.fetchGasFeed(ctx, pair.Base)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch the %s gas feed of chain %q: %w", pair.Base, pair.Chain, err)
 		}
-		// Store USD quoted pairs for target tokens
-		tokenUsdPairsMap[pair.Base] = true
+		result[idx].Price = gps.smooth(pair, feedValue)
 	}
 
-	if len(gweiPairsMap) == 0 {
-		return ErrNoGasPairs
-	}
+	return result, nil
+}
 
-	// Return error if ETH/USD pair is missing
-	if !hasEthUsd {
-		return fmt.Errorf("%w: %s/%s pair for gas price calculation", ErrMissingPairs, ethTicker, quoteUSD)
+// smooth returns the smoothed gas value of the pair, the raw one when the pair is not smoothed
+func (gps *gasPriceService) smooth(pair ArgsPairInfo, raw float64) float64 {
+	gps.mutSmoothing.Lock()
+	defer gps.mutSmoothing.Unlock()
+
+	smoothing, found := gps.smoothing[pairKey{chain: pair.Chain, base: pair.Base, quote: pair.Quote}]
+	if !found {
+		return raw
 	}
 
-	// Check for each GWEI/Token pair if we have corresponding Token/USD pairs
-	missingTokenUsdPairs := make([]string, 0)
-	for Quote := range gweiPairsMap {
-		if Quote == quoteUSD || tokenUsdPairsMap[Quote] {
+	smoothing.raw = raw
+	smoothing.smoothed = smoothing.smoother.add(raw)
+
+	return smoothing.smoothed
+}
+
+// VerifyRequiredPairs checks that the pairs contain gas pairs, all of them on known chains. The reference prices the
+// gas prices are converted with are requested from the price aggregator when they are not among the pairs
+func (gps *gasPriceService) VerifyRequiredPairs(pairs []ArgsPairInfo) error {
+	hasGasPairs := false
+	for _, pair := range pairs {
+		if !isGasPair(pair) {
 			continue
 		}
 
-		missingTokenUsdPairs = append(missingTokenUsdPairs, fmt.Sprintf("%s/%s", Quote, quoteUSD))
+		hasGasPairs = true
+		if _, found := gps.chains[pair.Chain]; !found {
+			return fmt.Errorf("%w %q", ErrUnknownGasChain, pair.Chain)
+		}
 	}
 
-	if len(missingTokenUsdPairs) > 0 {
-		return fmt.Errorf("%w: token/USD pairs for gas price calculation: %v", ErrMissingPairs, missingTokenUsdPairs)
+	if !hasGasPairs {
+		return ErrNoGasPairs
 	}
 
 	return nil
 }
 
+func isGasPair(pair ArgsPairInfo) bool {
+	return pair.Base == gweiTicker || pair.Quote == gweiTicker
+}
+
+// Metrics returns the request counters of each gas source, keyed by the chain and the source name, and the last raw
+// and smoothed gas values, in GWEI, of the smoothed pairs
+func (gps *gasPriceService) Metrics() map[string]interface{} {
+	sources := make(map[string]interface{})
+	for _, chain := range gps.chains {
+		for _, source := range chain.sources.sources {
+			name := source.fetcher.Name()
+			if len(chain.sources.chain) > 0 {
+				name = fmt.Sprintf("%s: %s", chain.sources.chain, name)
+			}
+			sources[name] = source.metrics()
+		}
+	}
+
+	gps.mutSmoothing.RLock()
+	values := make(map[string]interface{}, len(gps.smoothing))
+	for key, smoothing := range gps.smoothing {
+		values[key.String()] = map[string]interface{}{
+			"raw":      smoothing.raw,
+			"smoothed": smoothing.smoothed,
+		}
+	}
+	gps.mutSmoothing.RUnlock()
+
+	return map[string]interface{}{
+		"sources": sources,
+		"values":  values,
+	}
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (gps *gasPriceService) IsInterfaceNil() bool {
 	return gps == nil
@@ -2,6 +2,7 @@ package gas_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	gas "github.com/klever-io/klv-oracles-go/aggregator/gasStation"
@@ -17,18 +18,64 @@ func TestGasPriceService_NewGasPriceService(t *testing.T) {
 	t.Run("nil gasPriceFetcher should error", func(t *testing.T) {
 		t.Parallel()
 
-		gps, err := gas.NewGasPriceService(gas.ArgsGasPriceService{})
+		gps, err := gas.NewGasPriceService(gas.ArgsGasPriceService{PriceAggregator: &mock.PriceFetcherStub{}})
 
 		assert.Nil(t, gps)
 		assert.Equal(t, gas.ErrNilGasPriceFetcher, err)
 	})
 
+	t.Run("nil price aggregator should error", func(t *testing.T) {
+		t.Parallel()
+
+		gps, err := gas.NewGasPriceService(gas.ArgsGasPriceService{
+			GasPriceFetchers: []gas.PriceFetcher{&mock.PriceFetcherStub{}},
+			MinResultsNum:    1,
+		})
+
+		assert.Nil(t, gps)
+		assert.Equal(t, gas.ErrNilPriceAggregator, err)
+	})
+
+	t.Run("invalid chains should error", func(t *testing.T) {
+		t.Parallel()
+
+		chain := gas.ArgsGasChain{Chain: "BSC", NativeToken: "BNB", GasPriceFetchers: []gas.PriceFetcher{&mock.PriceFetcherStub{}}, MinResultsNum: 1}
+		invalidChains := map[string][]gas.ArgsGasChain{
+			"empty chain":          {{NativeToken: "BNB", GasPriceFetchers: []gas.PriceFetcher{&mock.PriceFetcherStub{}}, MinResultsNum: 1}},
+			"empty native token":   {{Chain: "BSC", GasPriceFetchers: []gas.PriceFetcher{&mock.PriceFetcherStub{}}, MinResultsNum: 1}},
+			"duplicated chain":     {chain, chain},
+			"nil gas price source": {{Chain: "BSC", NativeToken: "BNB"}},
+			"no minimum results": {{Chain: "BSC", NativeToken: "BNB",
+				GasPriceFetchers: []gas.PriceFetcher{&mock.PriceFetcherStub{}}}},
+			"minimum results above the sources": {{Chain: "BSC", NativeToken: "BNB",
+				GasPriceFetchers: []gas.PriceFetcher{&mock.PriceFetcherStub{}}, MinResultsNum: 2}},
+		}
+		for name, chains := range invalidChains {
+			gps, err := gas.NewGasPriceService(gas.ArgsGasPriceService{
+				PriceAggregator: &mock.PriceFetcherStub{},
+				Chains:          chains,
+			})
+			assert.Nil(t, gps, name)
+			assert.NotNil(t, err, name)
+		}
+
+		// the default chain is optional when other chains are provided
+		gps, err := gas.NewGasPriceService(gas.ArgsGasPriceService{
+			PriceAggregator: &mock.PriceFetcherStub{},
+			Chains:          []gas.ArgsGasChain{chain},
+		})
+		assert.False(t, check.IfNil(gps))
+		assert.Nil(t, err)
+	})
+
 	t.Run("valid setup should work", func(t *testing.T) {
 		t.Parallel()
 
 		gasPriceFetcher := &mock.PriceFetcherStub{}
 		gps, err := gas.NewGasPriceService(gas.ArgsGasPriceService{
-			GasPriceFetcher: gasPriceFetcher,
+			PriceAggregator:  &mock.PriceFetcherStub{},
+			GasPriceFetchers: []gas.PriceFetcher{gasPriceFetcher},
+			MinResultsNum:    1,
 		})
 
 		assert.False(t, check.IfNil(gps))
@@ -39,25 +86,69 @@ func TestGasPriceService_NewGasPriceService(t *testing.T) {
 func TestGasPriceService_ConvertGasPrices(t *testing.T) {
 	t.Parallel()
 
-	t.Run("should fail, missing BTC/USD pair", func(t *testing.T) {
+	t.Run("missing reference prices should be requested from the price aggregator", func(t *testing.T) {
 		t.Parallel()
 
-		fetcher := &mock.PriceFetcherStub{}
+		fetcher := &mock.PriceFetcherStub{
+			FetchPriceCalled: func(ctx context.Context, base string, quote string) (float64, error) {
+				return 30.0, nil
+			},
+		}
+		requested := make([]string, 0)
+		priceAggregator := &mock.PriceFetcherStub{
+			FetchPriceCalled: func(ctx context.Context, base string, quote string) (float64, error) {
+				requested = append(requested, base+"/"+quote)
+				return map[string]float64{"BTC": 40000.0, "KLV": 0.002}[base], nil
+			},
+		}
 
 		gps, _ := gas.NewGasPriceService(gas.ArgsGasPriceService{
-			GasPriceFetcher: fetcher,
+			PriceAggregator:  priceAggregator,
+			GasPriceFetchers: []gas.PriceFetcher{fetcher},
+			MinResultsNum:    1,
 		})
 
 		fetchedPrices := []gas.ArgsPairInfo{
 			{Base: "ETH", Quote: "USD", Price: 2000.0, Timestamp: 123}, // ETH/USD Price
 			{Base: "GWEI", Quote: "USD", Price: 0.0, Timestamp: 123},   // GWEI/USD Price (will be converted)
 			{Base: "GWEI", Quote: "BTC", Price: 0.0, Timestamp: 123},   // GWEI/BTC Price (will be converted)
+			{Base: "GWEI", Quote: "KLV", Price: 0.0, Timestamp: 123},   // GWEI/KLV Price (will be converted)
+			{Base: "GWEI", Quote: "ETH", Price: 0.0, Timestamp: 123},   // GWEI/ETH Price (will be converted)
+		}
+
+		result, err := gps.ConvertGasPrices(context.Background(), fetchedPrices)
+		require.Nil(t, err)
+		// GWEI/BTC calculated value = 30 * 1e-9 * 2000 / 40000 = 0.0000000015
+		assert.InDelta(t, 0.0000000015, result[2].Price, 1e-15)
+		// GWEI/KLV calculated value = 30 * 1e-9 * 2000 / 0.002 = 0.03
+		assert.InDelta(t, 0.03, result[3].Price, 1e-12)
+		// GWEI/ETH calculated value = 30 * 1e-9
+		assert.InDelta(t, 0.00000003, result[4].Price, 1e-15)
+		assert.Equal(t, []string{"BTC/USD", "KLV/USD"}, requested)
+	})
+
+	t.Run("price aggregator error should fail", func(t *testing.T) {
+		t.Parallel()
+
+		priceAggregator := &mock.PriceFetcherStub{
+			FetchPriceCalled: func(ctx context.Context, base string, quote string) (float64, error) {
+				return 0, assert.AnError
+			},
+		}
+		gps, _ := gas.NewGasPriceService(gas.ArgsGasPriceService{
+			PriceAggregator:  priceAggregator,
+			GasPriceFetchers: []gas.PriceFetcher{&mock.PriceFetcherStub{}},
+			MinResultsNum:    1,
+		})
+
+		fetchedPrices := []gas.ArgsPairInfo{
+			{Base: "GWEI", Quote: "USD", Price: 0.0, Timestamp: 123}, // GWEI/USD Price
 		}
 
 		result, err := gps.ConvertGasPrices(context.Background(), fetchedPrices)
 		assert.Nil(t, result)
-		assert.NotNil(t, err)
-		assert.ErrorIs(t, err, gas.ErrMissingPairs)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "ETH/USD")
 	})
 
 	t.Run("zero ETH Price should error", func(t *testing.T) {
@@ -68,8 +159,15 @@ func TestGasPriceService_ConvertGasPrices(t *testing.T) {
 				return 30.0, nil
 			},
 		}
+		priceAggregator := &mock.PriceFetcherStub{
+			FetchPriceCalled: func(ctx context.Context, base string, quote string) (float64, error) {
+				return 0.0, nil
+			},
+		}
 		gps, _ := gas.NewGasPriceService(gas.ArgsGasPriceService{
-			GasPriceFetcher: fetcher,
+			PriceAggregator:  priceAggregator,
+			GasPriceFetchers: []gas.PriceFetcher{fetcher},
+			MinResultsNum:    1,
 		})
 
 		fetchedPrices := []gas.ArgsPairInfo{
@@ -83,7 +181,7 @@ func TestGasPriceService_ConvertGasPrices(t *testing.T) {
 
 		assert.NotNil(t, err)
 		assert.Nil(t, result)
-		assert.ErrorIs(t, err, gas.ErrEthUsdPriceZero)
+		assert.ErrorIs(t, err, gas.ErrReferencePriceZero)
 	})
 
 	t.Run("fetcher error should fail", func(t *testing.T) {
@@ -95,7 +193,9 @@ func TestGasPriceService_ConvertGasPrices(t *testing.T) {
 			},
 		}
 		gps, _ := gas.NewGasPriceService(gas.ArgsGasPriceService{
-			GasPriceFetcher: fetcher,
+			PriceAggregator:  &mock.PriceFetcherStub{},
+			GasPriceFetchers: []gas.PriceFetcher{fetcher},
+			MinResultsNum:    1,
 		})
 
 		fetchedPrices := []gas.ArgsPairInfo{
@@ -122,7 +222,9 @@ func TestGasPriceService_ConvertGasPrices(t *testing.T) {
 			},
 		}
 		gps, _ := gas.NewGasPriceService(gas.ArgsGasPriceService{
-			GasPriceFetcher: fetcher,
+			PriceAggregator:  &mock.PriceFetcherStub{},
+			GasPriceFetchers: []gas.PriceFetcher{fetcher},
+			MinResultsNum:    1,
 		})
 
 		fetchedPrices := []gas.ArgsPairInfo{
@@ -149,7 +251,9 @@ func TestGasPriceService_ConvertGasPrices(t *testing.T) {
 			},
 		}
 		gps, _ := gas.NewGasPriceService(gas.ArgsGasPriceService{
-			GasPriceFetcher: fetcher,
+			PriceAggregator:  &mock.PriceFetcherStub{},
+			GasPriceFetchers: []gas.PriceFetcher{fetcher},
+			MinResultsNum:    1,
 		})
 
 		fetchedPrices := []gas.ArgsPairInfo{
@@ -167,6 +271,107 @@ func TestGasPriceService_ConvertGasPrices(t *testing.T) {
 		// GWEI/BTC calculated value = 30 * 1e-9 * 2000 / 40000 = 0.0000000015
 		assert.InDelta(t, 0.0000000015, result[3].Price, 0.0000000001)
 	})
+	t.Run("gas fee feeds should be fetched as they are", func(t *testing.T) {
+		t.Parallel()
+
+		fetcher := &mock.GasFeedsFetcherStub{
+			PriceFetcherStub: mock.PriceFetcherStub{
+				FetchPriceCalled: func(ctx context.Context, base string, quote string) (float64, error) {
+					assert.Equal(t, "GWEI", quote)
+					if base == "BASEFEE" {
+						return 12.5, nil
+					}
+					return 0, errors.New("unknown feed")
+				},
+			},
+		}
+		gasStation := &mock.PriceFetcherStub{
+			FetchPriceCalled: func(ctx context.Context, base string, quote string) (float64, error) {
+				assert.Fail(t, "the sources not providing the gas fee feeds should not be asked for them")
+				return 0, nil
+			},
+		}
+		gps, _ := gas.NewGasPriceService(gas.ArgsGasPriceService{
+			PriceAggregator:  &mock.PriceFetcherStub{},
+			GasPriceFetchers: []gas.PriceFetcher{fetcher, gasStation},
+			MinResultsNum:    2,
+		})
+
+		fetchedPrices := []gas.ArgsPairInfo{
+			{Base: "BTC", Quote: "USD", Price: 40000.0, Timestamp: 123},
+			{Base: "BASEFEE", Quote: "GWEI", Price: 0.0, Timestamp: 123},
+		}
+		result, err := gps.ConvertGasPrices(context.Background(), fetchedPrices)
+		require.Nil(t, err)
+		assert.Equal(t, 12.5, result[1].Price)
+		assert.Equal(t, 0.0, fetchedPrices[1].Price)
+
+		fetchedPrices = append(fetchedPrices, gas.ArgsPairInfo{Base: "MAXFEE", Quote: "GWEI"})
+		result, err = gps.ConvertGasPrices(context.Background(), fetchedPrices)
+		assert.Nil(t, result)
+		assert.ErrorContains(t, err, "unknown feed")
+	})
+}
+
+func TestGasPriceService_ConvertGasPricesOfSeveralChains(t *testing.T) {
+	t.Parallel()
+
+	newChainFetcher := func(gasPrice float64) *mock.GasFeedsFetcherStub {
+		return &mock.GasFeedsFetcherStub{
+			PriceFetcherStub: mock.PriceFetcherStub{
+				FetchPriceCalled: func(ctx context.Context, base string, quote string) (float64, error) {
+					if base == "BASEFEE" {
+						return gasPrice / 2, nil
+					}
+					return gasPrice, nil
+				},
+			},
+		}
+	}
+	gps, err := gas.NewGasPriceService(gas.ArgsGasPriceService{
+		PriceAggregator:  &mock.PriceFetcherStub{},
+		GasPriceFetchers: []gas.PriceFetcher{newChainFetcher(30)},
+		MinResultsNum:    1,
+		Chains: []gas.ArgsGasChain{
+			{Chain: "BSC", NativeToken: "BNB", GasPriceFetchers: []gas.PriceFetcher{newChainFetcher(3)}, MinResultsNum: 1},
+			{Chain: "Base", NativeToken: "ETH", GasPriceFetchers: []gas.PriceFetcher{newChainFetcher(0.01)}, MinResultsNum: 1},
+		},
+	})
+	require.Nil(t, err)
+
+	fetchedPrices := []gas.ArgsPairInfo{
+		{Base: "ETH", Quote: "USD", Price: 2000.0},
+		{Base: "BNB", Quote: "USD", Price: 500.0},
+		{Base: "KLV", Quote: "USD", Price: 0.002},
+		{Base: "GWEI", Quote: "USD"},
+		{Base: "GWEI", Quote: "USD", Chain: "BSC"},
+		{Base: "GWEI", Quote: "KLV", Chain: "BSC"},
+		{Base: "GWEI", Quote: "USD", Chain: "Base"},
+		{Base: "BASEFEE", Quote: "GWEI", Chain: "BSC"},
+	}
+	result, err := gps.ConvertGasPrices(context.Background(), fetchedPrices)
+	require.Nil(t, err)
+	// 30 * 1e-9 * 2000
+	assert.InDelta(t, 0.00006, result[3].Price, 1e-12)
+	// 3 * 1e-9 * 500
+	assert.InDelta(t, 0.0000015, result[4].Price, 1e-12)
+	// 3 * 1e-9 * 500 / 0.002
+	assert.InDelta(t, 0.00075, result[5].Price, 1e-12)
+	// 0.01 * 1e-9 * 2000
+	assert.InDelta(t, 0.00000002, result[6].Price, 1e-15)
+	assert.Equal(t, 1.5, result[7].Price)
+
+	// the native token price of each chain is requested from the price aggregator when not among the pairs
+	pairsWithoutBNB := append([]gas.ArgsPairInfo{fetchedPrices[0], fetchedPrices[2]}, fetchedPrices[3:]...)
+	result, err = gps.ConvertGasPrices(context.Background(), pairsWithoutBNB)
+	require.Nil(t, err)
+	// 3 * 1e-9 * 1 (the BNB/USD price of the price aggregator stub)
+	assert.InDelta(t, 0.000000003, result[3].Price, 1e-15)
+
+	_, err = gps.ConvertGasPrices(context.Background(), []gas.ArgsPairInfo{{Base: "GWEI", Quote: "USD", Chain: "Polygon"}})
+	assert.ErrorIs(t, err, gas.ErrUnknownGasChain)
+	_, err = gps.ConvertGasPrices(context.Background(), []gas.ArgsPairInfo{{Base: "BASEFEE", Quote: "GWEI", Chain: "Polygon"}})
+	assert.ErrorIs(t, err, gas.ErrUnknownGasChain)
 }
 
 func TestGasPriceService_VerifyRequiredPairs(t *testing.T) {
@@ -182,14 +387,16 @@ func TestGasPriceService_VerifyRequiredPairs(t *testing.T) {
 		fetcher := &mock.PriceFetcherStub{}
 
 		gps, _ := gas.NewGasPriceService(gas.ArgsGasPriceService{
-			GasPriceFetcher: fetcher,
+			PriceAggregator:  &mock.PriceFetcherStub{},
+			GasPriceFetchers: []gas.PriceFetcher{fetcher},
+			MinResultsNum:    1,
 		})
 
 		err := gps.VerifyRequiredPairs(pairs)
 		assert.ErrorIs(t, err, gas.ErrNoGasPairs)
 	})
 
-	t.Run("should fail, missing BTC/USD pair", func(t *testing.T) {
+	t.Run("missing BTC/USD pair should pass", func(t *testing.T) {
 		t.Parallel()
 
 		pairs := []gas.ArgsPairInfo{
@@ -201,16 +408,17 @@ func TestGasPriceService_VerifyRequiredPairs(t *testing.T) {
 		fetcher := &mock.PriceFetcherStub{}
 
 		gps, _ := gas.NewGasPriceService(gas.ArgsGasPriceService{
-			GasPriceFetcher: fetcher,
+			PriceAggregator:  &mock.PriceFetcherStub{},
+			GasPriceFetchers: []gas.PriceFetcher{fetcher},
+			MinResultsNum:    1,
 		})
 
 		err := gps.VerifyRequiredPairs(pairs)
 
-		assert.NotNil(t, err)
-		assert.ErrorIs(t, err, gas.ErrMissingPairs)
+		assert.Nil(t, err)
 	})
 
-	t.Run("should fail, missing ETH/USD pair", func(t *testing.T) {
+	t.Run("missing ETH/USD pair should pass", func(t *testing.T) {
 		t.Parallel()
 
 		pairs := []gas.ArgsPairInfo{
@@ -222,13 +430,14 @@ func TestGasPriceService_VerifyRequiredPairs(t *testing.T) {
 		fetcher := &mock.PriceFetcherStub{}
 
 		gps, _ := gas.NewGasPriceService(gas.ArgsGasPriceService{
-			GasPriceFetcher: fetcher,
+			PriceAggregator:  &mock.PriceFetcherStub{},
+			GasPriceFetchers: []gas.PriceFetcher{fetcher},
+			MinResultsNum:    1,
 		})
 
 		err := gps.VerifyRequiredPairs(pairs)
 
-		assert.NotNil(t, err)
-		assert.ErrorIs(t, err, gas.ErrMissingPairs)
+		assert.Nil(t, err)
 	})
 
 	t.Run("should pass, all required pairs present", func(t *testing.T) {
@@ -244,7 +453,9 @@ func TestGasPriceService_VerifyRequiredPairs(t *testing.T) {
 		fetcher := &mock.PriceFetcherStub{}
 
 		gps, _ := gas.NewGasPriceService(gas.ArgsGasPriceService{
-			GasPriceFetcher: fetcher,
+			PriceAggregator:  &mock.PriceFetcherStub{},
+			GasPriceFetchers: []gas.PriceFetcher{fetcher},
+			MinResultsNum:    1,
 		})
 
 		err := gps.VerifyRequiredPairs(pairs)
@@ -252,3 +463,213 @@ func TestGasPriceService_VerifyRequiredPairs(t *testing.T) {
 		assert.Nil(t, err)
 	})
 }
+
+func TestGasPriceService_SeveralGasSources(t *testing.T) {
+	t.Parallel()
+
+	newSource := func(name string, gasPrice float64, err error) *mock.GasFeedsFetcherStub {
+		return &mock.GasFeedsFetcherStub{
+			PriceFetcherStub: mock.PriceFetcherStub{
+				NameCalled: func() string {
+					return name
+				},
+				FetchPriceCalled: func(ctx context.Context, base string, quote string) (float64, error) {
+					return gasPrice, err
+				},
+			},
+		}
+	}
+	fetchedPrices := []gas.ArgsPairInfo{
+		{Base: "ETH", Quote: "USD", Price: 2000.0},
+		{Base: "GWEI", Quote: "USD"},
+		{Base: "BASEFEE", Quote: "GWEI"},
+	}
+	errUnavailable := errors.New("gas tracker unavailable")
+
+	t.Run("the median of the responding sources should be used", func(t *testing.T) {
+		t.Parallel()
+
+		gps, err := gas.NewGasPriceService(gas.ArgsGasPriceService{
+			PriceAggregator: &mock.PriceFetcherStub{},
+			GasPriceFetchers: []gas.PriceFetcher{
+				newSource("etherscan", 30, nil),
+				newSource("node", 20, nil),
+				newSource("blocknative", 0, errUnavailable),
+				newSource("other", 24, nil),
+			},
+			MinResultsNum: 2,
+		})
+		require.Nil(t, err)
+
+		result, err := gps.ConvertGasPrices(context.Background(), fetchedPrices)
+		require.Nil(t, err)
+		assert.InDelta(t, 2000.0*24*1e-9, result[1].Price, 1e-15)
+		assert.InDelta(t, 24.0, result[2].Price, 1e-9)
+
+		metrics := gps.Metrics()["sources"].(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{
+			"num_successes": uint64(0),
+			"num_failures":  uint64(2),
+			"last_error":    errUnavailable.Error(),
+		}, metrics["blocknative"])
+		assert.Equal(t, uint64(2), metrics["node"].(map[string]interface{})["num_successes"])
+	})
+
+	t.Run("too few responding sources should error with the failure of each source", func(t *testing.T) {
+		t.Parallel()
+
+		gps, err := gas.NewGasPriceService(gas.ArgsGasPriceService{
+			PriceAggregator: &mock.PriceFetcherStub{},
+			GasPriceFetchers: []gas.PriceFetcher{
+				newSource("etherscan", 30, nil),
+				newSource("node", 0, errors.New("connection refused")),
+				newSource("blocknative", 0, errUnavailable),
+			},
+			MinResultsNum: 2,
+		})
+		require.Nil(t, err)
+
+		result, err := gps.ConvertGasPrices(context.Background(), fetchedPrices)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, gas.ErrNotEnoughGasResults))
+		assert.ErrorContains(t, err, "node: connection refused")
+		assert.ErrorContains(t, err, "blocknative: gas tracker unavailable")
+	})
+
+	t.Run("the sources of the other chains should be reported with their chain", func(t *testing.T) {
+		t.Parallel()
+
+		gps, err := gas.NewGasPriceService(gas.ArgsGasPriceService{
+			PriceAggregator: &mock.PriceFetcherStub{},
+			Chains: []gas.ArgsGasChain{
+				{
+					Chain:            "BSC",
+					NativeToken:      "BNB",
+					GasPriceFetchers: []gas.PriceFetcher{newSource("node", 3, nil)},
+					MinResultsNum:    1,
+				},
+			},
+		})
+		require.Nil(t, err)
+
+		_, found := gps.Metrics()["sources"].(map[string]interface{})["BSC: node"]
+		assert.True(t, found)
+	})
+}
+
+func TestGasPriceService_Smoothing(t *testing.T) {
+	t.Parallel()
+
+	newSource := func(gasPrices ...float64) *mock.GasFeedsFetcherStub {
+		numCalls := 0
+		return &mock.GasFeedsFetcherStub{
+			PriceFetcherStub: mock.PriceFetcherStub{
+				FetchPriceCalled: func(ctx context.Context, base string, quote string) (float64, error) {
+					gasPrice := gasPrices[numCalls]
+					numCalls++
+					return gasPrice, nil
+				},
+			},
+		}
+	}
+	fetchedPrices := []gas.ArgsPairInfo{
+		{Base: "ETH", Quote: "USD", Price: 1e9},
+		{Base: "GWEI", Quote: "USD"},
+	}
+
+	t.Run("invalid smoothing should error", func(t *testing.T) {
+		t.Parallel()
+
+		ema := gas.ArgsGasSmoothing{Base: "GWEI", Quote: "USD", Method: gas.EMASmoothing, Alpha: 0.5}
+		invalidSmoothing := map[string][]gas.ArgsGasSmoothing{
+			"unknown method":       {{Base: "GWEI", Quote: "USD", Method: "SMA"}},
+			"zero alpha":           {{Base: "GWEI", Quote: "USD", Method: gas.EMASmoothing}},
+			"alpha above 1":        {{Base: "GWEI", Quote: "USD", Method: gas.EMASmoothing, Alpha: 1.5}},
+			"empty window":         {{Base: "GWEI", Quote: "USD", Method: gas.PercentileSmoothing, Percentile: 50}},
+			"percentile above 100": {{Base: "GWEI", Quote: "USD", Method: gas.PercentileSmoothing, WindowSize: 5, Percentile: 101}},
+			"duplicated smoothing": {ema, ema},
+		}
+		for name, smoothing := range invalidSmoothing {
+			gps, err := gas.NewGasPriceService(gas.ArgsGasPriceService{
+				PriceAggregator:  &mock.PriceFetcherStub{},
+				GasPriceFetchers: []gas.PriceFetcher{&mock.PriceFetcherStub{}},
+				MinResultsNum:    1,
+				Smoothing:        smoothing,
+			})
+			assert.Nil(t, gps, name)
+			assert.True(t, errors.Is(err, gas.ErrInvalidGasSmoothing), name)
+		}
+	})
+
+	t.Run("EMA should smooth the gas price before its conversion", func(t *testing.T) {
+		t.Parallel()
+
+		gps, err := gas.NewGasPriceService(gas.ArgsGasPriceService{
+			PriceAggregator:  &mock.PriceFetcherStub{},
+			GasPriceFetchers: []gas.PriceFetcher{newSource(20, 40, 20)},
+			MinResultsNum:    1,
+			Smoothing:        []gas.ArgsGasSmoothing{{Base: "GWEI", Quote: "USD", Method: gas.EMASmoothing, Alpha: 0.5}},
+		})
+		require.Nil(t, err)
+
+		for _, expected := range []float64{20, 30, 25} {
+			result, errConvert := gps.ConvertGasPrices(context.Background(), fetchedPrices)
+			require.Nil(t, errConvert)
+			assert.InDelta(t, expected, result[1].Price, 1e-9)
+		}
+
+		values := gps.Metrics()["values"].(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{"raw": 20.0, "smoothed": 25.0}, values["GWEI/USD"])
+	})
+
+	t.Run("percentile over the window should filter the spikes", func(t *testing.T) {
+		t.Parallel()
+
+		gps, err := gas.NewGasPriceService(gas.ArgsGasPriceService{
+			PriceAggregator:  &mock.PriceFetcherStub{},
+			GasPriceFetchers: []gas.PriceFetcher{newSource(20, 500, 22, 24, 26)},
+			MinResultsNum:    1,
+			Smoothing: []gas.ArgsGasSmoothing{
+				{Base: "GWEI", Quote: "USD", Method: gas.PercentileSmoothing, WindowSize: 3, Percentile: 50},
+			},
+		})
+		require.Nil(t, err)
+
+		// windows: [20], [20 500], [20 500 22], [500 22 24], [22 24 26]
+		for _, expected := range []float64{20, 20, 22, 24, 24} {
+			result, errConvert := gps.ConvertGasPrices(context.Background(), fetchedPrices)
+			require.Nil(t, errConvert)
+			assert.InDelta(t, expected, result[1].Price, 1e-9)
+		}
+	})
+
+	t.Run("the other pairs should not be smoothed", func(t *testing.T) {
+		t.Parallel()
+
+		gps, err := gas.NewGasPriceService(gas.ArgsGasPriceService{
+			PriceAggregator:  &mock.PriceFetcherStub{},
+			GasPriceFetchers: []gas.PriceFetcher{newSource(20, 10, 40, 20)},
+			MinResultsNum:    1,
+			Smoothing: []gas.ArgsGasSmoothing{
+				{Base: "BASEFEE", Quote: "GWEI", Method: gas.EMASmoothing, Alpha: 0.5},
+			},
+		})
+		require.Nil(t, err)
+
+		pairs := []gas.ArgsPairInfo{
+			{Base: "ETH", Quote: "USD", Price: 1e9},
+			{Base: "GWEI", Quote: "USD"},
+			{Base: "BASEFEE", Quote: "GWEI"},
+		}
+		result, err := gps.ConvertGasPrices(context.Background(), pairs)
+		require.Nil(t, err)
+		// the feeds are fetched before the gas price
+		assert.InDelta(t, 20, result[2].Price, 1e-9)
+		assert.InDelta(t, 10, result[1].Price, 1e-9)
+
+		result, err = gps.ConvertGasPrices(context.Background(), pairs)
+		require.Nil(t, err)
+		assert.InDelta(t, 30, result[2].Price, 1e-9)
+		assert.InDelta(t, 20, result[1].Price, 1e-9)
+	})
+}
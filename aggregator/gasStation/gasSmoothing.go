@@ -0,0 +1,126 @@
+package gas
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	// EMASmoothing smooths the gas values with an exponential moving average
+	EMASmoothing = "EMA"
+	// PercentileSmoothing replaces the gas values with a percentile of the last values, filtering the spikes
+	PercentileSmoothing = "PERCENTILE"
+)
+
+const maxSmoothingWindowSize = 1000
+
+// ArgsGasSmoothing defines the smoothing of the gas values of a gas pair, applied before their conversion
+type ArgsGasSmoothing struct {
+	Chain      string  // Chain of the gas pair
+	Base       string  // Base of the gas pair, GWEI or a gas fee feed
+	Quote      string  // Quote of the gas pair
+	Method     string  // EMASmoothing or PercentileSmoothing
+	Alpha      float64 // Weight of the newest value of the EMA, in (0, 1]
+	WindowSize int     // Number of values the percentile is computed on
+	Percentile float64 // Percentile of the window, in [0, 100], 50 being the median
+}
+
+// gasSmoother returns the smoothed value after adding a new raw value
+type gasSmoother interface {
+	add(value float64) float64
+}
+
+// pairSmoothing holds the smoother of a gas pair along with its last raw and smoothed values
+type pairSmoothing struct {
+	smoother gasSmoother
+	raw      float64
+	smoothed float64
+}
+
+type pairKey struct {
+	chain string
+	base  string
+	quote string
+}
+
+func (key pairKey) String() string {
+	if len(key.chain) == 0 {
+		return fmt.Sprintf("%s/%s", key.base, key.quote)
+	}
+
+	return fmt.Sprintf("%s/%s on %s", key.base, key.quote, key.chain)
+}
+
+func newGasSmoother(args ArgsGasSmoothing) (gasSmoother, error) {
+	switch args.Method {
+	case EMASmoothing:
+		if args.Alpha <= 0 || args.Alpha > 1 {
+			return nil, fmt.Errorf("%w, EMA alpha %v for %s/%s", ErrInvalidGasSmoothing, args.Alpha, args.Base, args.Quote)
+		}
+
+		return &emaSmoother{
+			alpha: args.Alpha,
+		}, nil
+	case PercentileSmoothing:
+		if args.WindowSize < 1 || args.WindowSize > maxSmoothingWindowSize {
+			return nil, fmt.Errorf("%w, window size %d for %s/%s", ErrInvalidGasSmoothing, args.WindowSize, args.Base, args.Quote)
+		}
+		if args.Percentile < 0 || args.Percentile > 100 {
+			return nil, fmt.Errorf("%w, percentile %v for %s/%s", ErrInvalidGasSmoothing, args.Percentile, args.Base, args.Quote)
+		}
+
+		return &percentileSmoother{
+			windowSize: args.WindowSize,
+			percentile: args.Percentile,
+			window:     make([]float64, 0, args.WindowSize),
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w, unknown method %q for %s/%s", ErrInvalidGasSmoothing, args.Method, args.Base, args.Quote)
+	}
+}
+
+// emaSmoother computes the exponential moving average of the values, starting from the first one
+type emaSmoother struct {
+	alpha       float64
+	value       float64
+	initialized bool
+}
+
+func (ema *emaSmoother) add(value float64) float64 {
+	if !ema.initialized {
+		ema.value = value
+		ema.initialized = true
+
+		return ema.value
+	}
+
+	ema.value = ema.alpha*value + (1-ema.alpha)*ema.value
+
+	return ema.value
+}
+
+// percentileSmoother returns the nearest-rank percentile of the last windowSize values
+type percentileSmoother struct {
+	windowSize int
+	percentile float64
+	window     []float64
+}
+
+func (ps *percentileSmoother) add(value float64) float64 {
+	if len(ps.window) == ps.windowSize {
+		ps.window = ps.window[1:]
+	}
+	ps.window = append(ps.window, value)
+
+	sorted := make([]float64, len(ps.window))
+	copy(sorted, ps.window)
+	sort.Float64s(sorted)
+
+	rank := int(math.Ceil(ps.percentile / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
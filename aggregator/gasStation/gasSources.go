@@ -0,0 +1,160 @@
+package gas
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/klever-io/klv-oracles-go/aggregator/stats"
+)
+
+// gasSource is a gas price fetcher along with its request counters
+type gasSource struct {
+	fetcher      PriceFetcher
+	mut          sync.Mutex
+	numSuccesses uint64
+	numFailures  uint64
+	lastError    string
+}
+
+// gasSources aggregates the values fetched from the gas sources of a chain into the median of the successful ones
+type gasSources struct {
+	chain         string
+	sources       []*gasSource
+	minResultsNum int
+}
+
+func newGasSources(chain string, fetchers []PriceFetcher, minResultsNum int) *gasSources {
+	sources := make([]*gasSource, 0, len(fetchers))
+	for _, fetcher := range fetchers {
+		sources = append(sources, &gasSource{
+			fetcher: fetcher,
+		})
+	}
+
+	return &gasSources{
+		chain:         chain,
+		sources:       sources,
+		minResultsNum: minResultsNum,
+	}
+}
+
+// fetchGasPrice returns the gas price aggregated over all the sources
+func (gs *gasSources) fetchGasPrice(ctx context.Context) (float64, error) {
+	return gs.fetch(ctx, gs.sources, gweiTicker, quoteUSD, gs.minResultsNum)
+}
+
+// fetchGasFeed returns a gas fee feed aggregated over the sources providing the feeds, the minimum number of results
+// being capped to their number
+func (gs *gasSources) fetchGasFeed(ctx context.Context, feed string) (float64, error) {
+	feedSources := make([]*gasSource, 0, len(gs.sources))
+	for _, source := range gs.sources {
+		provider, ok := source.fetcher.(GasFeedsProvider)
+		if ok && provider.ProvidesGasFeeds() {
+			feedSources = append(feedSources, source)
+		}
+	}
+	if len(feedSources) == 0 {
+		return 0, fmt.Errorf("%w, no gas source of chain %q provides the %s feed", ErrNotEnoughGasResults, gs.chain, feed)
+	}
+
+	minResultsNum := gs.minResultsNum
+	if minResultsNum > len(feedSources) {
+		minResultsNum = len(feedSources)
+	}
+
+	return gs.fetch(ctx, feedSources, feed, gweiTicker, minResultsNum)
+}
+
+// fetch queries the sources concurrently and returns the median of the successful ones, as long as there are at
+// least minResultsNum of them. The error lists the failure of each source
+func (gs *gasSources) fetch(
+	ctx context.Context,
+	sources []*gasSource,
+	base string,
+	quote string,
+	minResultsNum int,
+) (float64, error) {
+	values := make([]float64, len(sources))
+	errs := make([]error, len(sources))
+
+	var wg sync.WaitGroup
+	wg.Add(len(sources))
+	for idx, source := range sources {
+		go func(index int, source *gasSource) {
+			defer wg.Done()
+
+			values[index], errs[index] = source.fetcher.FetchPrice(ctx, base, quote)
+			source.record(errs[index])
+		}(idx, source)
+	}
+	wg.Wait()
+
+	results := make([]float64, 0, len(values))
+	failures := make(gasSourceErrors, 0)
+	for idx, err := range errs {
+		if err == nil {
+			results = append(results, values[idx])
+			continue
+		}
+
+		failures = append(failures, fmt.Errorf("%s: %w", sources[idx].fetcher.Name(), err))
+		log.Debug("failed to fetch gas price",
+			"chain", gs.chain,
+			"gas source", sources[idx].fetcher.Name(),
+			"base", base,
+			"quote", quote,
+			"err", err.Error(),
+		)
+	}
+
+	if len(results) < minResultsNum {
+		return 0, fmt.Errorf("%w, got %d of the %d required for %s/%s on chain %q, failures: [%w]",
+			ErrNotEnoughGasResults, len(results), minResultsNum, base, quote, gs.chain, failures)
+	}
+
+	return stats.Median(results)
+}
+
+// gasSourceErrors holds the failures of the gas sources, each one still matching errors.Is
+type gasSourceErrors []error
+
+// Error returns the failures separated by semicolons
+func (errs gasSourceErrors) Error() string {
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the failures of the gas sources
+func (errs gasSourceErrors) Unwrap() []error {
+	return errs
+}
+
+func (source *gasSource) record(err error) {
+	source.mut.Lock()
+	defer source.mut.Unlock()
+
+	if err == nil {
+		source.numSuccesses++
+		return
+	}
+
+	source.numFailures++
+	source.lastError = err.Error()
+}
+
+func (source *gasSource) metrics() map[string]interface{} {
+	source.mut.Lock()
+	defer source.mut.Unlock()
+
+	return map[string]interface{}{
+		"num_successes": source.numSuccesses,
+		"num_failures":  source.numFailures,
+		"last_error":    source.lastError,
+	}
+}
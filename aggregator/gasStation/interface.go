@@ -20,3 +20,9 @@ type PriceFetcher interface {
 	basePriceFetcher
 	AddPair(base, quote string)
 }
+
+// GasFeedsProvider defines a gas price source able to provide the gas fee feeds, like the base fee, next to the gas
+// price. The other sources are only asked for the gas price
+type GasFeedsProvider interface {
+	ProvidesGasFeeds() bool
+}
@@ -0,0 +1,60 @@
+package gas
+
+import (
+	"context"
+	"fmt"
+)
+
+// referencePrices provides the token/USD prices the gas prices are converted with. Each missing price is requested
+// once from the price aggregator
+type referencePrices struct {
+	priceAggregator PriceAggregator
+	usdPrices       map[string]float64
+}
+
+func newReferencePrices(priceAggregator PriceAggregator) *referencePrices {
+	return &referencePrices{
+		priceAggregator: priceAggregator,
+		usdPrices:       make(map[string]float64),
+	}
+}
+
+// crossRate returns the price of the base in the quote, computed from their USD prices
+func (rp *referencePrices) crossRate(ctx context.Context, base string, quote string) (float64, error) {
+	if base == quote {
+		return 1, nil
+	}
+
+	baseUSDPrice, err := rp.usdPrice(ctx, base)
+	if err != nil {
+		return 0, err
+	}
+	quoteUSDPrice, err := rp.usdPrice(ctx, quote)
+	if err != nil {
+		return 0, err
+	}
+
+	return baseUSDPrice / quoteUSDPrice, nil
+}
+
+func (rp *referencePrices) usdPrice(ctx context.Context, token string) (float64, error) {
+	if token == quoteUSD {
+		return 1, nil
+	}
+
+	price, found := rp.usdPrices[token]
+	if found {
+		return price, nil
+	}
+
+	price, err := rp.priceAggregator.FetchPrice(ctx, token, quoteUSD)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch the %s/%s reference price: %w", token, quoteUSD, err)
+	}
+	if price == 0 {
+		return 0, fmt.Errorf("%w, %s/%s", ErrReferencePriceZero, token, quoteUSD)
+	}
+	rp.usdPrices[token] = price
+
+	return price, nil
+}
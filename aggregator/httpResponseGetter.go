@@ -1,47 +1,301 @@
 package aggregator
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"math/rand"
+	"net"
 	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
 )
 
 const (
-	httpGetVerb = "GET"
+	httpGetVerb  = "GET"
+	httpPostVerb = "POST"
+
+	contentTypeHeader = "Content-Type"
+	jsonContentType   = "application/json"
+
+	retryAfterHeader      = "Retry-After"
+	maxBodySnippetLength  = 256
+	defaultTimeout        = 10 * time.Second
+	defaultRetryBaseDelay = 100 * time.Millisecond
+	defaultRetryMaxDelay  = 10 * time.Second
+	minRetryDelay         = time.Millisecond
 )
 
-// httpResponseGetter wraps over the default http client
+// HTTPStatusError is returned when a server responds with a non 2xx status code
+type HTTPStatusError struct {
+	URL         string
+	StatusCode  int
+	BodySnippet string
+	// Body is the full response body, holding the error details of the APIs answering with JSON errors
+	Body []byte
+}
+
+// Error returns the error message
+func (err *HTTPStatusError) Error() string {
+	return fmt.Sprintf("%s: status %d for %s, body: %s", ErrUnexpectedHTTPStatus, err.StatusCode, err.URL, err.BodySnippet)
+}
+
+// DecodeBody casts the response body over the response object through the json serializer
+func (err *HTTPStatusError) DecodeBody(response interface{}) error {
+	return json.Unmarshal(err.Body, response)
+}
+
+// Unwrap makes errors.Is(err, ErrUnexpectedHTTPStatus) hold for any HTTPStatusError
+func (err *HTTPStatusError) Unwrap() error {
+	return ErrUnexpectedHTTPStatus
+}
+
+// ArgsHttpResponseGetter is the DTO used to create a new http response getter
+type ArgsHttpResponseGetter struct {
+	// Transport is shared between the response getters so the keep-alive connections are reused. A transport
+	// with default settings is used when nil
+	Transport http.RoundTripper
+	// Timeout bounds each attempt, including the time spent reading the response body
+	Timeout time.Duration
+	// MaxRetries is the number of times a failed request is retried. Only the network errors, the 429 and the 5xx
+	// responses are retried
+	MaxRetries int
+	// RetryBaseDelay and RetryMaxDelay bound the jittered exponential backoff between attempts. A Retry-After value
+	// received from the server replaces the backoff, unless it exceeds RetryMaxDelay
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+	// RateLimiter, when set, is waited on before each attempt, the retries included
+	RateLimiter RateLimiter
+}
+
+// DefaultArgsHttpResponseGetter returns the arguments used when no explicit HTTP client configuration is provided
+func DefaultArgsHttpResponseGetter() ArgsHttpResponseGetter {
+	return ArgsHttpResponseGetter{
+		Timeout:        defaultTimeout,
+		RetryBaseDelay: defaultRetryBaseDelay,
+		RetryMaxDelay:  defaultRetryMaxDelay,
+	}
+}
+
+// httpResponseGetter wraps over a reusable http client
 type httpResponseGetter struct {
+	client         *http.Client
+	maxRetries     int
+	retryBaseDelay time.Duration
+	retryMaxDelay  time.Duration
+	rateLimiter    RateLimiter
+
+	randMut sync.Mutex
+	rand    *rand.Rand
 }
 
 // NewHttpResponseGetter returns a new http response getter instance
-func NewHttpResponseGetter() (*httpResponseGetter, error) {
-	return &httpResponseGetter{}, nil
+func NewHttpResponseGetter(args ArgsHttpResponseGetter) (*httpResponseGetter, error) {
+	err := checkArgsHttpResponseGetter(args)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := args.Transport
+	if transport == nil {
+		transport = newDefaultHttpTransport()
+	}
+
+	return &httpResponseGetter{
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   args.Timeout,
+		},
+		maxRetries:     args.MaxRetries,
+		retryBaseDelay: args.RetryBaseDelay,
+		retryMaxDelay:  args.RetryMaxDelay,
+		rateLimiter:    args.RateLimiter,
+		rand:           rand.New(rand.NewSource(time.Now().UnixNano())),
+	}, nil
+}
+
+func checkArgsHttpResponseGetter(args ArgsHttpResponseGetter) error {
+	if args.Timeout <= 0 {
+		return fmt.Errorf("%w, timeout: %v", ErrInvalidHTTPClientConfig, args.Timeout)
+	}
+	if args.MaxRetries < 0 {
+		return fmt.Errorf("%w, max retries: %d", ErrInvalidHTTPClientConfig, args.MaxRetries)
+	}
+	if args.MaxRetries > 0 && args.RetryBaseDelay < minRetryDelay {
+		return fmt.Errorf("%w, retry base delay: %v", ErrInvalidHTTPClientConfig, args.RetryBaseDelay)
+	}
+	if args.MaxRetries > 0 && args.RetryMaxDelay < args.RetryBaseDelay {
+		return fmt.Errorf("%w, retry max delay %v is lower than the retry base delay %v",
+			ErrInvalidHTTPClientConfig, args.RetryMaxDelay, args.RetryBaseDelay)
+	}
+
+	return nil
 }
 
 // Get does a get operation on the specified url and tries to cast the response bytes over the response object through
-// the json serializer
-func (getter *httpResponseGetter) Get(ctx context.Context, url string, response interface{}) error {
-	client := &http.Client{}
+// the json serializer. Failed requests are retried with a jittered exponential backoff
+func (getter *httpResponseGetter) Get(ctx context.Context, rawURL string, response interface{}) error {
+	return getter.do(ctx, httpGetVerb, rawURL, nil, response)
+}
 
-	req, err := http.NewRequestWithContext(ctx, httpGetVerb, url, nil)
+// Post sends the request, serialized as JSON, to the specified url and casts the response bytes over the response
+// object. The failed requests are retried like the get ones, so only idempotent requests should be posted
+func (getter *httpResponseGetter) Post(ctx context.Context, rawURL string, request interface{}, response interface{}) error {
+	body, err := json.Marshal(request)
 	if err != nil {
 		return err
 	}
 
-	resp, err := client.Do(req)
+	return getter.do(ctx, httpPostVerb, rawURL, body, response)
+}
+
+func (getter *httpResponseGetter) do(ctx context.Context, method string, rawURL string, body []byte, response interface{}) error {
+	for attempt := 0; ; attempt++ {
+		if !check.IfNil(getter.rateLimiter) {
+			err := getter.rateLimiter.Wait(ctx, rawURL)
+			if err != nil {
+				return err
+			}
+		}
+
+		respBytes, retryAfter, err := getter.doRequest(ctx, method, rawURL, body)
+		if err == nil {
+			return json.Unmarshal(respBytes, response)
+		}
+		if attempt >= getter.maxRetries || !isRetryable(ctx, err) {
+			return err
+		}
+
+		delay := getter.backoffDelay(attempt)
+		if retryAfter > 0 {
+			if retryAfter > getter.retryMaxDelay {
+				return err
+			}
+			delay = retryAfter
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+func (getter *httpResponseGetter) doRequest(ctx context.Context, method string, rawURL string, body []byte) ([]byte, time.Duration, error) {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, bodyReader)
 	if err != nil {
-		return err
+		return nil, 0, err
+	}
+	if body != nil {
+		req.Header.Set(contentTypeHeader, jsonContentType)
+	}
+	for name, value := range requestHeadersFromContext(ctx) {
+		req.Header.Set(name, value)
 	}
 
-	respBytes, err := io.ReadAll(resp.Body)
+	resp, err := getter.client.Do(req)
 	if err != nil {
-		return nil
+		return nil, 0, err
 	}
 	defer func() {
 		_ = resp.Body.Close()
 	}()
 
-	return json.Unmarshal(respBytes, response)
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, parseRetryAfter(resp.Header.Get(retryAfterHeader)), &HTTPStatusError{
+			URL:         rawURL,
+			StatusCode:  resp.StatusCode,
+			BodySnippet: bodySnippet(respBytes),
+			Body:        respBytes,
+		}
+	}
+
+	return respBytes, 0, nil
+}
+
+func (getter *httpResponseGetter) backoffDelay(attempt int) time.Duration {
+	ceiling := getter.retryBaseDelay
+	for i := 0; i < attempt && ceiling < getter.retryMaxDelay; i++ {
+		ceiling *= 2
+	}
+	if ceiling > getter.retryMaxDelay {
+		ceiling = getter.retryMaxDelay
+	}
+
+	getter.randMut.Lock()
+	defer getter.randMut.Unlock()
+
+	// full jitter: a random delay up to the exponential backoff ceiling
+	return minRetryDelay + time.Duration(getter.rand.Int63n(int64(ceiling)))
+}
+
+func isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	// url.Error implements net.Error itself, only the underlying transport errors are retried
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
+// parseRetryAfter accepts both forms of the Retry-After header: a number of seconds or an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if len(value) == 0 {
+		return 0
+	}
+
+	seconds, err := strconv.ParseUint(value, 10, 32)
+	if err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0
+	}
+
+	return time.Until(date)
+}
+
+func bodySnippet(body []byte) string {
+	if len(body) <= maxBodySnippetLength {
+		return string(body)
+	}
+
+	return string(body[:maxBodySnippetLength]) + "..."
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (getter *httpResponseGetter) IsInterfaceNil() bool {
+	return getter == nil
 }
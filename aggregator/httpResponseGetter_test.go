@@ -3,12 +3,20 @@ package aggregator_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/klever-io/klv-oracles-go/aggregator"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -17,10 +25,72 @@ type testStruct struct {
 	StringVal string
 }
 
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func createMockArgsHttpResponseGetter() aggregator.ArgsHttpResponseGetter {
+	return aggregator.ArgsHttpResponseGetter{
+		Timeout:        time.Second,
+		MaxRetries:     0,
+		RetryBaseDelay: time.Millisecond,
+		RetryMaxDelay:  10 * time.Millisecond,
+	}
+}
+
+func TestNewHttpResponseGetter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid timeout should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsHttpResponseGetter()
+		args.Timeout = 0
+		responseGetter, err := aggregator.NewHttpResponseGetter(args)
+		assert.True(t, errors.Is(err, aggregator.ErrInvalidHTTPClientConfig))
+		assert.Nil(t, responseGetter)
+	})
+	t.Run("negative max retries should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsHttpResponseGetter()
+		args.MaxRetries = -1
+		responseGetter, err := aggregator.NewHttpResponseGetter(args)
+		assert.True(t, errors.Is(err, aggregator.ErrInvalidHTTPClientConfig))
+		assert.Nil(t, responseGetter)
+	})
+	t.Run("invalid retry delays should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsHttpResponseGetter()
+		args.MaxRetries = 1
+		args.RetryBaseDelay = 0
+		responseGetter, err := aggregator.NewHttpResponseGetter(args)
+		assert.True(t, errors.Is(err, aggregator.ErrInvalidHTTPClientConfig))
+		assert.Nil(t, responseGetter)
+
+		args = createMockArgsHttpResponseGetter()
+		args.MaxRetries = 1
+		args.RetryMaxDelay = args.RetryBaseDelay / 2
+		responseGetter, err = aggregator.NewHttpResponseGetter(args)
+		assert.True(t, errors.Is(err, aggregator.ErrInvalidHTTPClientConfig))
+		assert.Nil(t, responseGetter)
+	})
+	t.Run("default arguments should work", func(t *testing.T) {
+		t.Parallel()
+
+		responseGetter, err := aggregator.NewHttpResponseGetter(aggregator.DefaultArgsHttpResponseGetter())
+		assert.Nil(t, err)
+		assert.False(t, check.IfNil(responseGetter))
+	})
+}
+
 func TestHttpResponseGetter_InvalidURLShouldError(t *testing.T) {
 	t.Parallel()
 
-	responseGetter, err := aggregator.NewHttpResponseGetter()
+	responseGetter, err := aggregator.NewHttpResponseGetter(createMockArgsHttpResponseGetter())
 	require.Nil(t, err)
 	responseStruct := &testStruct{}
 
@@ -34,16 +104,16 @@ func TestHttpResponseGetter_NilResponseObjectShouldError(t *testing.T) {
 
 	httpServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.WriteHeader(http.StatusOK)
-		_, _ = rw.Write(nil)
+		_, _ = rw.Write([]byte("{}"))
 	}))
 	defer httpServer.Close()
 
-	responseGetter, err := aggregator.NewHttpResponseGetter()
+	responseGetter, err := aggregator.NewHttpResponseGetter(createMockArgsHttpResponseGetter())
 	require.Nil(t, err)
 
 	err = responseGetter.Get(context.Background(), httpServer.URL, nil)
 	require.NotNil(t, err)
-	require.IsType(t, err, &json.SyntaxError{})
+	require.IsType(t, &json.InvalidUnmarshalError{}, err)
 }
 
 func TestHttpResponseGetter_InvalidResponseShouldError(t *testing.T) {
@@ -55,7 +125,7 @@ func TestHttpResponseGetter_InvalidResponseShouldError(t *testing.T) {
 	}))
 	defer httpServer.Close()
 
-	responseGetter, err := aggregator.NewHttpResponseGetter()
+	responseGetter, err := aggregator.NewHttpResponseGetter(createMockArgsHttpResponseGetter())
 	require.Nil(t, err)
 
 	err = responseGetter.Get(context.Background(), httpServer.URL, responseGetter)
@@ -80,7 +150,7 @@ func TestHttpResponseGetter_GetShouldWork(t *testing.T) {
 	}))
 	defer httpServer.Close()
 
-	responseGetter, err := aggregator.NewHttpResponseGetter()
+	responseGetter, err := aggregator.NewHttpResponseGetter(createMockArgsHttpResponseGetter())
 	require.Nil(t, err)
 
 	responseStruct := &testStruct{}
@@ -89,3 +159,327 @@ func TestHttpResponseGetter_GetShouldWork(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, expectedStruct, responseStruct)
 }
+
+func TestHttpResponseGetter_PostShouldWork(t *testing.T) {
+	t.Parallel()
+
+	httpServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+
+		request := &testStruct{}
+		require.Nil(t, json.NewDecoder(req.Body).Decode(request))
+		request.IntVal++
+
+		rw.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(rw).Encode(request)
+	}))
+	defer httpServer.Close()
+
+	responseGetter, err := aggregator.NewHttpResponseGetter(createMockArgsHttpResponseGetter())
+	require.Nil(t, err)
+
+	responseStruct := &testStruct{}
+	err = responseGetter.Post(context.Background(), httpServer.URL, &testStruct{IntVal: 41, StringVal: "value"}, responseStruct)
+	require.Nil(t, err)
+	assert.Equal(t, &testStruct{IntVal: 42, StringVal: "value"}, responseStruct)
+}
+
+func TestHttpResponseGetter_GetShouldSetRequestHeaders(t *testing.T) {
+	t.Parallel()
+
+	httpServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "key", req.Header.Get("X-Api-Key"))
+		assert.Equal(t, "overwritten", req.Header.Get("X-Custom"))
+
+		rw.WriteHeader(http.StatusOK)
+		_, _ = rw.Write([]byte(`{"IntVal":1}`))
+	}))
+	defer httpServer.Close()
+
+	responseGetter, err := aggregator.NewHttpResponseGetter(createMockArgsHttpResponseGetter())
+	require.Nil(t, err)
+
+	ctx := aggregator.WithRequestHeaders(context.Background(), map[string]string{"X-Api-Key": "key", "X-Custom": "value"})
+	ctx = aggregator.WithRequestHeaders(ctx, map[string]string{"X-Custom": "overwritten"})
+
+	responseStruct := &testStruct{}
+	err = responseGetter.Get(ctx, httpServer.URL, responseStruct)
+	require.Nil(t, err)
+	require.Equal(t, 1, responseStruct.IntVal)
+}
+
+func TestHttpResponseGetter_NonSuccessfulStatusShouldError(t *testing.T) {
+	t.Parallel()
+
+	body := `{"error":"not found"}` + strings.Repeat("x", 1000)
+	httpServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+		_, _ = rw.Write([]byte(body))
+	}))
+	defer httpServer.Close()
+
+	args := createMockArgsHttpResponseGetter()
+	args.MaxRetries = 3
+	responseGetter, err := aggregator.NewHttpResponseGetter(args)
+	require.Nil(t, err)
+
+	responseStruct := &testStruct{}
+	err = responseGetter.Get(context.Background(), httpServer.URL, responseStruct)
+	require.True(t, errors.Is(err, aggregator.ErrUnexpectedHTTPStatus))
+
+	statusErr := &aggregator.HTTPStatusError{}
+	require.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusNotFound, statusErr.StatusCode)
+	assert.Equal(t, httpServer.URL, statusErr.URL)
+	assert.True(t, strings.HasPrefix(statusErr.BodySnippet, `{"error":"not found"}`))
+	assert.Less(t, len(statusErr.BodySnippet), len(body))
+	assert.Equal(t, body, string(statusErr.Body))
+	assert.Equal(t, &testStruct{}, responseStruct)
+}
+
+func TestHttpResponseGetter_BodyReadErrorShouldError(t *testing.T) {
+	t.Parallel()
+
+	httpServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Length", "100")
+		rw.WriteHeader(http.StatusOK)
+		_, _ = rw.Write([]byte(`{"IntVal":`))
+	}))
+	defer httpServer.Close()
+
+	responseGetter, err := aggregator.NewHttpResponseGetter(createMockArgsHttpResponseGetter())
+	require.Nil(t, err)
+
+	err = responseGetter.Get(context.Background(), httpServer.URL, &testStruct{})
+	require.True(t, errors.Is(err, io.ErrUnexpectedEOF))
+}
+
+func TestHttpResponseGetter_TimeoutShouldError(t *testing.T) {
+	t.Parallel()
+
+	unblock := make(chan struct{})
+	httpServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		<-unblock
+	}))
+	defer httpServer.Close()
+	defer close(unblock)
+
+	args := createMockArgsHttpResponseGetter()
+	args.Timeout = 50 * time.Millisecond
+	responseGetter, err := aggregator.NewHttpResponseGetter(args)
+	require.Nil(t, err)
+
+	start := time.Now()
+	err = responseGetter.Get(context.Background(), httpServer.URL, &testStruct{})
+	require.NotNil(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestHttpResponseGetter_ShouldRetry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should retry server errors until success", func(t *testing.T) {
+		t.Parallel()
+
+		numCalls := int32(0)
+		httpServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			if atomic.AddInt32(&numCalls, 1) < 3 {
+				rw.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			rw.WriteHeader(http.StatusOK)
+			_, _ = rw.Write([]byte(`{"IntVal":3}`))
+		}))
+		defer httpServer.Close()
+
+		args := createMockArgsHttpResponseGetter()
+		args.MaxRetries = 2
+		responseGetter, err := aggregator.NewHttpResponseGetter(args)
+		require.Nil(t, err)
+
+		responseStruct := &testStruct{}
+		err = responseGetter.Get(context.Background(), httpServer.URL, responseStruct)
+		require.Nil(t, err)
+		assert.Equal(t, 3, responseStruct.IntVal)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&numCalls))
+	})
+	t.Run("should stop after the max number of retries", func(t *testing.T) {
+		t.Parallel()
+
+		numCalls := int32(0)
+		httpServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			atomic.AddInt32(&numCalls, 1)
+			rw.WriteHeader(http.StatusBadGateway)
+		}))
+		defer httpServer.Close()
+
+		args := createMockArgsHttpResponseGetter()
+		args.MaxRetries = 2
+		responseGetter, err := aggregator.NewHttpResponseGetter(args)
+		require.Nil(t, err)
+
+		err = responseGetter.Get(context.Background(), httpServer.URL, &testStruct{})
+		require.True(t, errors.Is(err, aggregator.ErrUnexpectedHTTPStatus))
+		assert.Equal(t, int32(3), atomic.LoadInt32(&numCalls))
+	})
+	t.Run("should not retry client errors", func(t *testing.T) {
+		t.Parallel()
+
+		numCalls := int32(0)
+		httpServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			atomic.AddInt32(&numCalls, 1)
+			rw.WriteHeader(http.StatusBadRequest)
+		}))
+		defer httpServer.Close()
+
+		args := createMockArgsHttpResponseGetter()
+		args.MaxRetries = 2
+		responseGetter, err := aggregator.NewHttpResponseGetter(args)
+		require.Nil(t, err)
+
+		err = responseGetter.Get(context.Background(), httpServer.URL, &testStruct{})
+		require.True(t, errors.Is(err, aggregator.ErrUnexpectedHTTPStatus))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&numCalls))
+	})
+	t.Run("should honour Retry-After", func(t *testing.T) {
+		t.Parallel()
+
+		numCalls := int32(0)
+		httpServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			if atomic.AddInt32(&numCalls, 1) == 1 {
+				rw.Header().Set("Retry-After", "1")
+				rw.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			rw.WriteHeader(http.StatusOK)
+			_, _ = rw.Write([]byte(`{"IntVal":1}`))
+		}))
+		defer httpServer.Close()
+
+		args := createMockArgsHttpResponseGetter()
+		args.MaxRetries = 1
+		args.RetryMaxDelay = 2 * time.Second
+		responseGetter, err := aggregator.NewHttpResponseGetter(args)
+		require.Nil(t, err)
+
+		start := time.Now()
+		err = responseGetter.Get(context.Background(), httpServer.URL, &testStruct{})
+		require.Nil(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), time.Second)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&numCalls))
+	})
+	t.Run("should not wait for a Retry-After longer than the max delay", func(t *testing.T) {
+		t.Parallel()
+
+		numCalls := int32(0)
+		httpServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			atomic.AddInt32(&numCalls, 1)
+			rw.Header().Set("Retry-After", "120")
+			rw.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer httpServer.Close()
+
+		args := createMockArgsHttpResponseGetter()
+		args.MaxRetries = 3
+		responseGetter, err := aggregator.NewHttpResponseGetter(args)
+		require.Nil(t, err)
+
+		err = responseGetter.Get(context.Background(), httpServer.URL, &testStruct{})
+		require.True(t, errors.Is(err, aggregator.ErrUnexpectedHTTPStatus))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&numCalls))
+	})
+	t.Run("should stop retrying when the context is done", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// the context is canceled once the first response is received, so the getter notices it between two attempts
+		numCalls := int32(0)
+		args := createMockArgsHttpResponseGetter()
+		args.MaxRetries = 100
+		args.Transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			atomic.AddInt32(&numCalls, 1)
+			cancel()
+
+			return &http.Response{
+				StatusCode: http.StatusInternalServerError,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(strings.NewReader("")),
+				Request:    req,
+			}, nil
+		})
+		responseGetter, err := aggregator.NewHttpResponseGetter(args)
+		require.Nil(t, err)
+
+		err = responseGetter.Get(ctx, "http://localhost/prices", &testStruct{})
+		require.True(t, errors.Is(err, aggregator.ErrUnexpectedHTTPStatus))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&numCalls))
+	})
+}
+
+func TestHttpResponseGetter_RateLimitedRetries(t *testing.T) {
+	t.Parallel()
+
+	numCalls := int32(0)
+	httpServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&numCalls, 1)
+		rw.Header().Set("Retry-After", "0")
+		rw.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer httpServer.Close()
+
+	rateLimiter, err := aggregator.NewRateLimiter(aggregator.ArgsRateLimiter{
+		Name:              "exchange",
+		RequestsPerMinute: 60,
+		Burst:             2,
+	})
+	require.Nil(t, err)
+
+	args := createMockArgsHttpResponseGetter()
+	args.MaxRetries = 5
+	args.RateLimiter = rateLimiter
+	responseGetter, err := aggregator.NewHttpResponseGetter(args)
+	require.Nil(t, err)
+
+	// the burst allows 2 requests, the third attempt waits for a token longer than the context allows
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	err = responseGetter.Get(ctx, httpServer.URL, &testStruct{})
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&numCalls))
+	assert.Equal(t, uint64(3), rateLimiter.Metrics()["num_requests"])
+}
+
+func TestHttpResponseGetter_ShouldReuseConnections(t *testing.T) {
+	t.Parallel()
+
+	numNewConnections := int32(0)
+	httpServer := httptest.NewUnstartedServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+		_, _ = rw.Write([]byte(`{"IntVal":1}`))
+	}))
+	httpServer.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&numNewConnections, 1)
+		}
+	}
+	httpServer.Start()
+	defer httpServer.Close()
+
+	args := createMockArgsHttpResponseGetter()
+	transport, err := aggregator.NewHttpTransport(aggregator.ArgsHttpTransport{MaxIdleConnsPerHost: 1})
+	require.Nil(t, err)
+	args.Transport = transport
+	firstGetter, err := aggregator.NewHttpResponseGetter(args)
+	require.Nil(t, err)
+	secondGetter, err := aggregator.NewHttpResponseGetter(args)
+	require.Nil(t, err)
+
+	for i := 0; i < 5; i++ {
+		require.Nil(t, firstGetter.Get(context.Background(), httpServer.URL, &testStruct{}))
+		require.Nil(t, secondGetter.Get(context.Background(), httpServer.URL, &testStruct{}))
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&numNewConnections))
+}
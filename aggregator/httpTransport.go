@@ -0,0 +1,154 @@
+package aggregator
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+const (
+	maxIdleConns               = 100
+	idleConnTimeout            = 90 * time.Second
+	defaultMaxIdleConnsPerHost = 4
+	dialTimeout                = 30 * time.Second
+	dialKeepAlive              = 30 * time.Second
+)
+
+var supportedProxySchemes = map[string]struct{}{
+	"http":   {},
+	"https":  {},
+	"socks5": {},
+}
+
+// ArgsHttpTransport is the DTO used to create the transport shared by the http response getters
+type ArgsHttpTransport struct {
+	// MaxIdleConnsPerHost is the number of keep-alive connections kept for each host. A default is used when 0
+	MaxIdleConnsPerHost int
+	// ProxyURL is the http, https or socks5 proxy used for all requests, credentials can be provided in the URL.
+	// When empty, the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are used
+	ProxyURL string
+	// CACertFile is a PEM bundle with the certificate authorities trusted besides the system ones
+	CACertFile string
+	// ClientCertFile and ClientKeyFile hold the PEM encoded certificate and key presented to the servers
+	// requesting client authentication
+	ClientCertFile string
+	ClientKeyFile  string
+	// SourceIP is the local address the outgoing connections are bound to
+	SourceIP string
+}
+
+// NewHttpTransport returns a transport reusing keep-alive connections and configured with the provided proxy,
+// TLS and source address settings
+func NewHttpTransport(args ArgsHttpTransport) (*http.Transport, error) {
+	transport := newDefaultHttpTransport()
+	if args.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = args.MaxIdleConnsPerHost
+	}
+
+	err := setProxy(transport, args.ProxyURL)
+	if err != nil {
+		return nil, err
+	}
+
+	err = setTLSConfig(transport, args)
+	if err != nil {
+		return nil, err
+	}
+
+	err = setSourceIP(transport, args.SourceIP)
+	if err != nil {
+		return nil, err
+	}
+
+	return transport, nil
+}
+
+func newDefaultHttpTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = maxIdleConns
+	transport.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+	transport.IdleConnTimeout = idleConnTimeout
+
+	return transport
+}
+
+func setProxy(transport *http.Transport, proxyURL string) error {
+	if len(proxyURL) == 0 {
+		return nil
+	}
+
+	parsedURL, err := url.Parse(proxyURL)
+	if err != nil {
+		return fmt.Errorf("%w, proxy URL: %s", ErrInvalidHTTPTransportConfig, err.Error())
+	}
+	if _, supported := supportedProxySchemes[parsedURL.Scheme]; !supported || len(parsedURL.Host) == 0 {
+		return fmt.Errorf("%w, unsupported proxy URL %s", ErrInvalidHTTPTransportConfig, parsedURL.Redacted())
+	}
+
+	transport.Proxy = http.ProxyURL(parsedURL)
+
+	return nil
+}
+
+func setTLSConfig(transport *http.Transport, args ArgsHttpTransport) error {
+	if len(args.CACertFile) == 0 && len(args.ClientCertFile) == 0 && len(args.ClientKeyFile) == 0 {
+		return nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if len(args.CACertFile) > 0 {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+
+		caBundle, err := os.ReadFile(args.CACertFile)
+		if err != nil {
+			return fmt.Errorf("%w, CA bundle: %s", ErrInvalidHTTPTransportConfig, err.Error())
+		}
+		if !rootCAs.AppendCertsFromPEM(caBundle) {
+			return fmt.Errorf("%w, no certificate found in the CA bundle %s", ErrInvalidHTTPTransportConfig, args.CACertFile)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if len(args.ClientCertFile) > 0 || len(args.ClientKeyFile) > 0 {
+		clientCert, err := tls.LoadX509KeyPair(args.ClientCertFile, args.ClientKeyFile)
+		if err != nil {
+			return fmt.Errorf("%w, client certificate: %s", ErrInvalidHTTPTransportConfig, err.Error())
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	return nil
+}
+
+func setSourceIP(transport *http.Transport, sourceIP string) error {
+	if len(sourceIP) == 0 {
+		return nil
+	}
+
+	ip := net.ParseIP(sourceIP)
+	if ip == nil {
+		return fmt.Errorf("%w, invalid source IP %s", ErrInvalidHTTPTransportConfig, sourceIP)
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   dialTimeout,
+		KeepAlive: dialKeepAlive,
+		LocalAddr: &net.TCPAddr{IP: ip},
+	}
+	transport.DialContext = dialer.DialContext
+
+	return nil
+}
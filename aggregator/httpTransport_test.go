@@ -0,0 +1,208 @@
+package aggregator_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePEMFile(t *testing.T, blockType string, bytes []byte) string {
+	path := filepath.Join(t.TempDir(), "file.pem")
+	err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: bytes}), 0600)
+	require.Nil(t, err)
+
+	return path
+}
+
+func createClientCertificate(t *testing.T) (certFile string, keyFile string, cert *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "oracle"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.Nil(t, err)
+	cert, err = x509.ParseCertificate(certBytes)
+	require.Nil(t, err)
+
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	require.Nil(t, err)
+
+	return writePEMFile(t, "CERTIFICATE", certBytes), writePEMFile(t, "EC PRIVATE KEY", keyBytes), cert
+}
+
+func getWithTransport(t *testing.T, transport http.RoundTripper, url string) error {
+	args := createMockArgsHttpResponseGetter()
+	args.Transport = transport
+	responseGetter, err := aggregator.NewHttpResponseGetter(args)
+	require.Nil(t, err)
+
+	return responseGetter.Get(context.Background(), url, &testStruct{})
+}
+
+func okHandler(rw http.ResponseWriter, _ *http.Request) {
+	rw.WriteHeader(http.StatusOK)
+	_, _ = rw.Write([]byte(`{"IntVal":1}`))
+}
+
+func TestNewHttpTransport(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid proxy URL should error", func(t *testing.T) {
+		t.Parallel()
+
+		for _, proxyURL := range []string{"ftp://proxy:21", "proxy:8080", "http://", "://"} {
+			transport, err := aggregator.NewHttpTransport(aggregator.ArgsHttpTransport{ProxyURL: proxyURL})
+			assert.True(t, errors.Is(err, aggregator.ErrInvalidHTTPTransportConfig), proxyURL)
+			assert.Nil(t, transport)
+		}
+	})
+	t.Run("missing or empty CA bundle should error", func(t *testing.T) {
+		t.Parallel()
+
+		transport, err := aggregator.NewHttpTransport(aggregator.ArgsHttpTransport{CACertFile: "missing.pem"})
+		assert.True(t, errors.Is(err, aggregator.ErrInvalidHTTPTransportConfig))
+		assert.Nil(t, transport)
+
+		emptyFile := filepath.Join(t.TempDir(), "empty.pem")
+		require.Nil(t, os.WriteFile(emptyFile, []byte("no certificates"), 0600))
+		transport, err = aggregator.NewHttpTransport(aggregator.ArgsHttpTransport{CACertFile: emptyFile})
+		assert.True(t, errors.Is(err, aggregator.ErrInvalidHTTPTransportConfig))
+		assert.Nil(t, transport)
+	})
+	t.Run("client certificate without key should error", func(t *testing.T) {
+		t.Parallel()
+
+		certFile, _, _ := createClientCertificate(t)
+		transport, err := aggregator.NewHttpTransport(aggregator.ArgsHttpTransport{ClientCertFile: certFile})
+		assert.True(t, errors.Is(err, aggregator.ErrInvalidHTTPTransportConfig))
+		assert.Nil(t, transport)
+	})
+	t.Run("invalid source IP should error", func(t *testing.T) {
+		t.Parallel()
+
+		transport, err := aggregator.NewHttpTransport(aggregator.ArgsHttpTransport{SourceIP: "not an IP"})
+		assert.True(t, errors.Is(err, aggregator.ErrInvalidHTTPTransportConfig))
+		assert.Nil(t, transport)
+	})
+	t.Run("default arguments should work", func(t *testing.T) {
+		t.Parallel()
+
+		transport, err := aggregator.NewHttpTransport(aggregator.ArgsHttpTransport{})
+		assert.Nil(t, err)
+		assert.NotNil(t, transport)
+	})
+}
+
+func TestHttpTransport_ShouldUseTheProxy(t *testing.T) {
+	t.Parallel()
+
+	proxiedURL := ""
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		// a forward proxy receives the absolute URL of the target
+		proxiedURL = req.URL.String()
+		okHandler(rw, req)
+	}))
+	defer proxyServer.Close()
+
+	transport, err := aggregator.NewHttpTransport(aggregator.ArgsHttpTransport{ProxyURL: proxyServer.URL})
+	require.Nil(t, err)
+
+	err = getWithTransport(t, transport, "http://exchange.invalid/ticker?symbol=BTCUSDT")
+	require.Nil(t, err)
+	assert.Equal(t, "http://exchange.invalid/ticker?symbol=BTCUSDT", proxiedURL)
+}
+
+func TestHttpTransport_ShouldTrustTheCABundle(t *testing.T) {
+	t.Parallel()
+
+	tlsServer := httptest.NewTLSServer(http.HandlerFunc(okHandler))
+	defer tlsServer.Close()
+
+	transport, err := aggregator.NewHttpTransport(aggregator.ArgsHttpTransport{})
+	require.Nil(t, err)
+	err = getWithTransport(t, transport, tlsServer.URL)
+	require.NotNil(t, err)
+
+	caFile := writePEMFile(t, "CERTIFICATE", tlsServer.Certificate().Raw)
+	transport, err = aggregator.NewHttpTransport(aggregator.ArgsHttpTransport{CACertFile: caFile})
+	require.Nil(t, err)
+	err = getWithTransport(t, transport, tlsServer.URL)
+	assert.Nil(t, err)
+}
+
+func TestHttpTransport_ShouldPresentTheClientCertificate(t *testing.T) {
+	t.Parallel()
+
+	certFile, keyFile, clientCert := createClientCertificate(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+
+	tlsServer := httptest.NewUnstartedServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		require.Equal(t, 1, len(req.TLS.PeerCertificates))
+		assert.Equal(t, "oracle", req.TLS.PeerCertificates[0].Subject.CommonName)
+		okHandler(rw, req)
+	}))
+	tlsServer.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	tlsServer.StartTLS()
+	defer tlsServer.Close()
+
+	caFile := writePEMFile(t, "CERTIFICATE", tlsServer.Certificate().Raw)
+	transport, err := aggregator.NewHttpTransport(aggregator.ArgsHttpTransport{CACertFile: caFile})
+	require.Nil(t, err)
+	err = getWithTransport(t, transport, tlsServer.URL)
+	require.NotNil(t, err)
+
+	transport, err = aggregator.NewHttpTransport(aggregator.ArgsHttpTransport{
+		CACertFile:     caFile,
+		ClientCertFile: certFile,
+		ClientKeyFile:  keyFile,
+	})
+	require.Nil(t, err)
+	err = getWithTransport(t, transport, tlsServer.URL)
+	assert.Nil(t, err)
+}
+
+func TestHttpTransport_ShouldBindTheSourceIP(t *testing.T) {
+	t.Parallel()
+
+	remoteIP := ""
+	httpServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		remoteIP, _, _ = net.SplitHostPort(req.RemoteAddr)
+		okHandler(rw, req)
+	}))
+	defer httpServer.Close()
+
+	transport, err := aggregator.NewHttpTransport(aggregator.ArgsHttpTransport{SourceIP: "127.0.0.1"})
+	require.Nil(t, err)
+	err = getWithTransport(t, transport, httpServer.URL)
+	require.Nil(t, err)
+	assert.Equal(t, "127.0.0.1", remoteIP)
+}
@@ -11,15 +11,40 @@ type ResponseGetter interface {
 	Get(ctx context.Context, url string, response interface{}) error
 }
 
+// ResponsePoster is the component able to send a JSON request to the provided URL and decode the JSON response
+type ResponsePoster interface {
+	Post(ctx context.Context, url string, request interface{}, response interface{}) error
+}
+
+// RateLimiter delays the requests so they respect a budget
+type RateLimiter interface {
+	Wait(ctx context.Context, url string) error
+	IsInterfaceNil() bool
+}
+
 // GraphqlGetter is the graphql component able to execute a get operation on the provided URL
 type GraphqlGetter interface {
 	Query(ctx context.Context, url string, query string, variables string) ([]byte, error)
 }
 
+// BaseQuote identifies a base-quote pair
+type BaseQuote struct {
+	Base  string
+	Quote string
+}
+
+// PriceResult holds the price fetched for a pair or the error that prevented fetching it
+type PriceResult struct {
+	Price float64
+	Err   error
+}
+
 // basePriceFetcher defines the behavior of a component able to query the price
 type basePriceFetcher interface {
 	Name() string
 	FetchPrice(ctx context.Context, base string, quote string) (float64, error)
+	// FetchPrices returns a result for each of the provided pairs, using as few requests as the source allows
+	FetchPrices(ctx context.Context, pairs []BaseQuote) map[BaseQuote]PriceResult
 	IsInterfaceNil() bool
 }
 
@@ -33,6 +58,7 @@ type PriceAggregator interface {
 type PriceFetcher interface {
 	basePriceFetcher
 	AddPair(base, quote string)
+	MappedQuote(quote string) string
 }
 
 // ArgsPriceChanged is the argument used when notifying the notifee instance
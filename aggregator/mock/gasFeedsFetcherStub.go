@@ -0,0 +1,11 @@
+package mock
+
+// GasFeedsFetcherStub -
+type GasFeedsFetcherStub struct {
+	PriceFetcherStub
+}
+
+// ProvidesGasFeeds -
+func (stub *GasFeedsFetcherStub) ProvidesGasFeeds() bool {
+	return true
+}
@@ -1,12 +1,18 @@
 package mock
 
-import "context"
+import (
+	"context"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+)
 
 // PriceFetcherStub -
 type PriceFetcherStub struct {
-	NameCalled       func() string
-	FetchPriceCalled func(ctx context.Context, base string, quote string) (float64, error)
-	AddPairCalled    func(base, quote string)
+	NameCalled        func() string
+	FetchPriceCalled  func(ctx context.Context, base string, quote string) (float64, error)
+	FetchPricesCalled func(ctx context.Context, pairs []aggregator.BaseQuote) map[aggregator.BaseQuote]aggregator.PriceResult
+	AddPairCalled     func(base, quote string)
+	MappedQuoteCalled func(quote string) string
 }
 
 // Name -
@@ -27,6 +33,21 @@ func (stub *PriceFetcherStub) FetchPrice(ctx context.Context, base string, quote
 	return 1, nil
 }
 
+// FetchPrices -
+func (stub *PriceFetcherStub) FetchPrices(ctx context.Context, pairs []aggregator.BaseQuote) map[aggregator.BaseQuote]aggregator.PriceResult {
+	if stub.FetchPricesCalled != nil {
+		return stub.FetchPricesCalled(ctx, pairs)
+	}
+
+	results := make(map[aggregator.BaseQuote]aggregator.PriceResult, len(pairs))
+	for _, pair := range pairs {
+		price, err := stub.FetchPrice(ctx, pair.Base, pair.Quote)
+		results[pair] = aggregator.PriceResult{Price: price, Err: err}
+	}
+
+	return results
+}
+
 // AddPair -
 func (stub *PriceFetcherStub) AddPair(base, quote string) {
 	if stub.AddPairCalled != nil {
@@ -34,6 +55,15 @@ func (stub *PriceFetcherStub) AddPair(base, quote string) {
 	}
 }
 
+// MappedQuote -
+func (stub *PriceFetcherStub) MappedQuote(quote string) string {
+	if stub.MappedQuoteCalled != nil {
+		return stub.MappedQuoteCalled(quote)
+	}
+
+	return quote
+}
+
 // IsInterfaceNil -
 func (stub *PriceFetcherStub) IsInterfaceNil() bool {
 	return stub == nil
@@ -7,4 +7,7 @@ var (
 	errNilTxNonceHandler         = errors.New("nil tx nonce handler")
 	errNilContractAddressHandler = errors.New("nil contract address handler")
 	errNilWallet                 = errors.New("nil wallet")
+	errNoOracleKeys              = errors.New("no oracle keys")
+	errInvalidKeySelection       = errors.New("invalid key selection")
+	errNilNonceHandlerFactory    = errors.New("nil nonce handler factory")
 )
@@ -15,6 +15,7 @@ type Proxy interface {
 	GetAccount(ctx context.Context, address address.Address) (*models.Account, error)
 	SendTransaction(ctx context.Context, tx *transaction.Transaction) (string, error)
 	SendTransactions(ctx context.Context, txs []*transaction.Transaction) ([]string, error)
+	EstimateTransactionFees(ctx context.Context, tx *transaction.Transaction) (*transaction.FeesResponse, error)
 	IsInterfaceNil() bool
 }
 
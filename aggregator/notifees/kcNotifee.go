@@ -2,6 +2,10 @@ package notifees
 
 import (
 	"context"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"sync/atomic"
 
 	"github.com/klever-io/klever-go/crypto/hashing"
 	factoryHasher "github.com/klever-io/klever-go/crypto/hashing/factory"
@@ -19,23 +23,42 @@ const function = "submitBatch"
 
 var log = logger.GetOrCreate("klv-oracle-go/aggregator/notifees")
 
+// KeySelection defines how the oracle keys are picked for the transactions
+type KeySelection string
+
+const (
+	// RoundRobinKeySelection spreads the transactions over the keys in turn
+	RoundRobinKeySelection KeySelection = "round-robin"
+	// FailoverKeySelection sends the transactions with the first key, the next ones being used only when it fails
+	FailoverKeySelection KeySelection = "failover"
+)
+
+// OracleKey is a wallet allowed to write to the contract, along with the nonce handler of its transactions
+type OracleKey struct {
+	Wallet         wallet.Wallet
+	TxNonceHandler TransactionNonceHandler
+}
+
 // ArgsKCNotifee is the argument DTO for the NewKCNotifee function
 type ArgsKCNotifee struct {
 	Proxy           Proxy
-	TxNonceHandler  TransactionNonceHandler
+	Keys            []OracleKey
+	KeySelection    KeySelection
 	ContractAddress address.Address
-	Wallet          wallet.Wallet
 	BaseGasLimit    uint64
 	GasLimitForEach uint64
 }
 
 type kcNotifee struct {
 	proxy           Proxy
-	txNonceHandler  TransactionNonceHandler
 	contractAddress address.Address
-	wallet          wallet.Wallet
+	keySelection    KeySelection
 	hasher          hashing.Hasher
 	marshalizer     marshal.Marshalizer
+
+	mutKeys sync.RWMutex
+	keys    []OracleKey
+	nextKey atomic.Uint64
 }
 
 // NewKCNotifee will create a new instance of kcNotifee
@@ -52,12 +75,15 @@ func NewKCNotifee(args ArgsKCNotifee) (*kcNotifee, error) {
 
 	notifee := &kcNotifee{
 		proxy:           args.Proxy,
-		txNonceHandler:  args.TxNonceHandler,
 		contractAddress: args.ContractAddress,
-		wallet:          args.Wallet,
+		keySelection:    args.KeySelection,
+		keys:            args.Keys,
 		hasher:          hasher,
 		marshalizer:     marshal.NewProtoMarshalizer(),
 	}
+	if len(notifee.keySelection) == 0 {
+		notifee.keySelection = RoundRobinKeySelection
+	}
 
 	return notifee, nil
 }
@@ -66,21 +92,70 @@ func checkArgsKCNotifee(args ArgsKCNotifee) error {
 	if check.IfNil(args.Proxy) {
 		return errNilProxy
 	}
-	if check.IfNil(args.TxNonceHandler) {
-		return errNilTxNonceHandler
-	}
 	if check.IfNil(args.ContractAddress) {
 		return errNilContractAddressHandler
 	}
-	if check.IfNil(args.Wallet) {
-		return errNilWallet
+	switch args.KeySelection {
+	case "", RoundRobinKeySelection, FailoverKeySelection:
+	default:
+		return fmt.Errorf("%w %q", errInvalidKeySelection, args.KeySelection)
+	}
+
+	return checkKeys(args.Keys)
+}
+
+func checkKeys(keys []OracleKey) error {
+	if len(keys) == 0 {
+		return errNoOracleKeys
+	}
+	for _, key := range keys {
+		if check.IfNil(key.TxNonceHandler) {
+			return errNilTxNonceHandler
+		}
+		if check.IfNil(key.Wallet) {
+			return errNilWallet
+		}
+	}
+
+	return nil
+}
+
+// SetKeys replaces the oracle keys. The transactions being sent with the former keys are not affected
+func (en *kcNotifee) SetKeys(keys []OracleKey) error {
+	err := checkKeys(keys)
+	if err != nil {
+		return err
 	}
 
+	en.mutKeys.Lock()
+	en.keys = keys
+	en.mutKeys.Unlock()
+
 	return nil
 }
 
+// orderedKeys returns the keys in the order they are tried for the next transaction
+func (en *kcNotifee) orderedKeys() []OracleKey {
+	en.mutKeys.RLock()
+	defer en.mutKeys.RUnlock()
+
+	first := 0
+	if en.keySelection == RoundRobinKeySelection {
+		first = int((en.nextKey.Add(1) - 1) % uint64(len(en.keys)))
+	}
+
+	ordered := make([]OracleKey, 0, len(en.keys))
+	ordered = append(ordered, en.keys[first:]...)
+	ordered = append(ordered, en.keys[:first]...)
+
+	return ordered
+}
+
 // PriceChanged is the function that gets called by a price notifier. This function will assemble a Klever Blockchain
-// transaction, having the transaction's data field containing all the price changes information
+// transaction, having the transaction's data field containing all the price changes information. The transaction is
+// sent with the next oracle key, failing over to the other keys as long as it was not sent. A transaction failing to be
+// signed has the nonce of its key reset before failing over. The errors raised while sending are returned without
+// failing over, as the transaction is stored by the nonce handler of its key, which resends it
 func (en *kcNotifee) PriceChanged(ctx context.Context, priceChanges []*aggregator.ArgsPriceChanged) error {
 	txData, err := en.prepareTxData(priceChanges)
 	if err != nil {
@@ -92,9 +167,42 @@ func (en *kcNotifee) PriceChanged(ctx context.Context, priceChanges []*aggregato
 		return err
 	}
 
+	args := submitBatchArgs(priceChanges)
+	keys := en.orderedKeys()
+	for i, key := range keys {
+		var tx *transaction.Transaction
+		tx, err = en.prepareTransaction(ctx, key, txData, args, networkConfig.ChainID)
+		if err == nil {
+			err = en.signTransaction(ctx, key, tx)
+		}
+		if err == nil {
+			// once sent, the transaction is pending on this key, so it is not sent with another one
+			return en.sendTransaction(ctx, key, tx)
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+		if i < len(keys)-1 {
+			log.Warn("failed to send the transaction, trying the next oracle key",
+				"public key", hex.EncodeToString(key.Wallet.PublicKey()), "err", err.Error())
+		}
+	}
+
+	return err
+}
+
+// prepareTransaction builds the transaction of the key and applies its nonce and fees once the call is checked. A nonce
+// is consumed only when no error is returned
+func (en *kcNotifee) prepareTransaction(
+	ctx context.Context,
+	key OracleKey,
+	txData []byte,
+	args []wallet.SubmitBatchArg,
+	chainID string,
+) (*transaction.Transaction, error) {
 	// building transaction to be signed, and send using proxy interface, but noncehandler as intermediare to help with nonce logic
-	tx := transaction.NewBaseTransaction(en.wallet.PublicKey(), 0, [][]byte{txData}, 0, 0)
-	tx.SetChainID([]byte(networkConfig.ChainID))
+	tx := transaction.NewBaseTransaction(key.Wallet.PublicKey(), 0, [][]byte{txData}, 0, 0)
+	tx.SetChainID([]byte(chainID))
 
 	contractRequest := &transaction.SmartContract{
 		Type:    transaction.SmartContract_SCInvoke,
@@ -103,49 +211,126 @@ func (en *kcNotifee) PriceChanged(ctx context.Context, priceChanges []*aggregato
 
 	tx.PushContract(transaction.TXContract_SmartContractType, contractRequest)
 
-	notifeeAddress, err := address.NewAddressFromBytes(en.wallet.PublicKey())
+	notifeeAddress, err := address.NewAddressFromBytes(key.Wallet.PublicKey())
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	err = en.txNonceHandler.ApplyNonceAndGasPrice(ctx, notifeeAddress, tx)
+	err = en.checkTransaction(ctx, key.Wallet, tx, args)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	err = key.TxNonceHandler.ApplyNonceAndGasPrice(ctx, notifeeAddress, tx)
+	if err != nil {
+		return nil, err
 	}
 
-	hash, err := en.calculateHash(tx.GetRawData())
+	return tx, nil
+}
+
+// signTransaction adds the signature of the key to the transaction, resetting the nonce of the key when it fails, so
+// the applied nonce does not leave a gap behind
+func (en *kcNotifee) signTransaction(ctx context.Context, key OracleKey, tx *transaction.Transaction) error {
+	rawTx, err := en.marshalizer.Marshal(tx.GetRawData())
 	if err != nil {
+		en.resetNonce(key, err)
 		return err
 	}
 
-	signature, err := en.wallet.Sign(hash)
+	signature, err := en.sign(ctx, key.Wallet, rawTx)
 	if err != nil {
+		en.resetNonce(key, err)
 		return err
 	}
 
 	tx.AddSignature(signature)
 
-	txHash, err := en.txNonceHandler.SendTransaction(ctx, tx)
+	return nil
+}
+
+func (en *kcNotifee) sendTransaction(ctx context.Context, key OracleKey, tx *transaction.Transaction) error {
+	txHash, err := key.TxNonceHandler.SendTransaction(ctx, tx)
 	if err != nil {
 		return err
 	}
 
-	log.Debug("sent transaction", "hash", txHash)
+	log.Debug("sent transaction", "hash", txHash, "public key", hex.EncodeToString(key.Wallet.PublicKey()))
 
 	return nil
 }
 
-// calculateHash marshalizes the interface and calculates its hash
-func (en *kcNotifee) calculateHash(
-	object interface{},
-) ([]byte, error) {
-	mrsData, err := en.marshalizer.Marshal(object)
+// resetNonce has the nonce handler of the key forget the nonce applied to the transaction that failed to be signed, as
+// a remote signer timing out or restarting would otherwise leave a nonce gap blocking the next transactions of the key.
+// The transaction is never stored by the nonce handler, so it is not pending on the key
+func (en *kcNotifee) resetNonce(key OracleKey, signErr error) {
+	publicKey := hex.EncodeToString(key.Wallet.PublicKey())
+	resetter, ok := key.TxNonceHandler.(NonceResetter)
+	if !ok {
+		log.Error("the nonce applied to the unsigned transaction leaves a gap", "public key", publicKey,
+			"err", signErr.Error())
+		return
+	}
+
+	err := resetter.ResetNonce()
 	if err != nil {
-		return nil, err
+		log.Error("failed to reset the nonce after the signing error", "public key", publicKey,
+			"sign err", signErr.Error(), "err", err.Error())
+		return
+	}
+	log.Warn("reset the nonce after the signing error", "public key", publicKey, "err", signErr.Error())
+}
+
+// checkTransaction has the wallets enforcing a policy check the call before the nonce is applied to its transaction,
+// so a rejected batch does not leave a nonce gap behind. The fee checked is the estimated one
+func (en *kcNotifee) checkTransaction(
+	ctx context.Context,
+	oracleWallet wallet.Wallet,
+	tx *transaction.Transaction,
+	args []wallet.SubmitBatchArg,
+) error {
+	signer, ok := oracleWallet.(wallet.TransactionSigner)
+	if !ok {
+		return nil
+	}
+
+	fees, err := en.proxy.EstimateTransactionFees(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	return signer.CheckTransaction(ctx, &wallet.SignRequest{
+		Contract: en.contractAddress.Bech32(),
+		Function: function,
+		Args:     args,
+		Fee:      uint64(fees.KAppFee) + uint64(fees.BandwidthFee),
+	})
+}
+
+// sign hands the proto-marshalled raw transaction to the wallets able to decode it, like a remote signer enforcing its
+// own policy, and signs its blake2b hash with the other wallets
+func (en *kcNotifee) sign(ctx context.Context, oracleWallet wallet.Wallet, rawTx []byte) ([]byte, error) {
+	signer, ok := oracleWallet.(wallet.TransactionSigner)
+	if ok {
+		return signer.SignTransaction(ctx, rawTx)
+	}
+
+	return oracleWallet.Sign(en.hasher.Compute(string(rawTx)))
+}
+
+func submitBatchArgs(priceChanges []*aggregator.ArgsPriceChanged) []wallet.SubmitBatchArg {
+	args := make([]wallet.SubmitBatchArg, 0, len(priceChanges))
+	for _, priceChange := range priceChanges {
+		args = append(args, wallet.SubmitBatchArg{
+			Base:      priceChange.Base,
+			Quote:     priceChange.Quote,
+			Timestamp: priceChange.Timestamp,
+			Price:     priceChange.DenominatedPrice,
+			Decimals:  priceChange.Decimals,
+		})
 	}
 
-	hash := en.hasher.Compute(string(mrsData))
-	return hash, nil
+	return args
 }
 
 func (en *kcNotifee) prepareTxData(priceChanges []*aggregator.ArgsPriceChanged) ([]byte, error) {
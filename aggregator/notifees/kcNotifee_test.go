@@ -8,10 +8,13 @@ import (
 	"math/big"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/klever-io/klever-go/data/transaction"
+	"github.com/klever-io/klever-go/tools/marshal"
 	"github.com/klever-io/klv-bridge-eth-go/clients/klever/blockchain/address"
 	"github.com/klever-io/klv-bridge-eth-go/clients/klever/blockchain/builders"
+	nonceHandler "github.com/klever-io/klv-bridge-eth-go/clients/klever/interactors/nonceHandlerV2"
 	"github.com/klever-io/klv-bridge-eth-go/clients/klever/proxy/models"
 	"github.com/klever-io/klv-bridge-eth-go/testsCommon"
 	"github.com/klever-io/klv-bridge-eth-go/testsCommon/interactors"
@@ -28,15 +31,37 @@ var walletSk = "8734062c1158f26a3ca8a4a0da87b527a7c168653f7f4c77045e5cf571497d9d
 
 const chainID = "test"
 
+type transactionSignerStub struct {
+	wallet.Wallet
+	checkTransactionCalled func(ctx context.Context, request *wallet.SignRequest) error
+	signTransactionCalled  func(ctx context.Context, rawTx []byte) ([]byte, error)
+}
+
+func (stub *transactionSignerStub) CheckTransaction(ctx context.Context, request *wallet.SignRequest) error {
+	if stub.checkTransactionCalled != nil {
+		return stub.checkTransactionCalled(ctx, request)
+	}
+
+	return nil
+}
+
+func (stub *transactionSignerStub) SignTransaction(ctx context.Context, rawTx []byte) ([]byte, error) {
+	return stub.signTransactionCalled(ctx, rawTx)
+}
+
 func createMockArgsKCNotifee() ArgsKCNotifee {
 	contractAddress, _ := address.NewAddressFromBytes(bytes.Repeat([]byte{1}, 32))
 
 	notifeesWallet, _ := wallet.NewWalletFroHex(walletSk)
 
 	return ArgsKCNotifee{
-		Proxy:           &interactors.ProxyStub{},
-		TxNonceHandler:  &testsCommon.TxNonceHandlerV2Stub{},
-		Wallet:          notifeesWallet,
+		Proxy: &interactors.ProxyStub{},
+		Keys: []OracleKey{
+			{
+				Wallet:         notifeesWallet,
+				TxNonceHandler: &testsCommon.TxNonceHandlerV2Stub{},
+			},
+		},
 		ContractAddress: contractAddress,
 		BaseGasLimit:    1,
 		GasLimitForEach: 1,
@@ -56,10 +81,14 @@ func createMockArgsKCNotifeeWithSomeRealComponents() ArgsKCNotifee {
 
 	notifeesWallet, _ := wallet.NewWalletFroHex(walletSk)
 	return ArgsKCNotifee{
-		Proxy:           proxy,
-		TxNonceHandler:  &testsCommon.TxNonceHandlerV2Stub{},
+		Proxy: proxy,
+		Keys: []OracleKey{
+			{
+				Wallet:         notifeesWallet,
+				TxNonceHandler: &testsCommon.TxNonceHandlerV2Stub{},
+			},
+		},
 		ContractAddress: contractAddress,
-		Wallet:          notifeesWallet,
 		BaseGasLimit:    2000,
 		GasLimitForEach: 30,
 	}
@@ -101,12 +130,32 @@ func TestNewKCNotifee(t *testing.T) {
 		t.Parallel()
 
 		args := createMockArgsKCNotifee()
-		args.TxNonceHandler = nil
+		args.Keys[0].TxNonceHandler = nil
 		en, err := NewKCNotifee(args)
 
 		assert.True(t, check.IfNil(en))
 		assert.Equal(t, errNilTxNonceHandler, err)
 	})
+	t.Run("no keys should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsKCNotifee()
+		args.Keys = nil
+		en, err := NewKCNotifee(args)
+
+		assert.True(t, check.IfNil(en))
+		assert.Equal(t, errNoOracleKeys, err)
+	})
+	t.Run("invalid key selection should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsKCNotifee()
+		args.KeySelection = "random"
+		en, err := NewKCNotifee(args)
+
+		assert.True(t, check.IfNil(en))
+		assert.ErrorIs(t, err, errInvalidKeySelection)
+	})
 	t.Run("nil contract address should error", func(t *testing.T) {
 		t.Parallel()
 
@@ -121,7 +170,7 @@ func TestNewKCNotifee(t *testing.T) {
 		t.Parallel()
 
 		args := createMockArgsKCNotifee()
-		args.Wallet = nil
+		args.Keys[0].Wallet = nil
 		en, err := NewKCNotifee(args)
 
 		assert.True(t, check.IfNil(en))
@@ -146,7 +195,7 @@ func TestKCNotifee_PriceChanged(t *testing.T) {
 
 		expectedErr := errors.New("expected error")
 		args := createMockArgsKCNotifeeWithSomeRealComponents()
-		args.TxNonceHandler = &testsCommon.TxNonceHandlerV2Stub{
+		args.Keys[0].TxNonceHandler = &testsCommon.TxNonceHandlerV2Stub{
 			ApplyNonceAndGasPriceCalled: func(ctx context.Context, address address.Address, tx *transaction.Transaction) error {
 				return expectedErr
 			},
@@ -167,7 +216,7 @@ func TestKCNotifee_PriceChanged(t *testing.T) {
 		t.Parallel()
 
 		args := createMockArgsKCNotifeeWithSomeRealComponents()
-		args.TxNonceHandler = &testsCommon.TxNonceHandlerV2Stub{
+		args.Keys[0].TxNonceHandler = &testsCommon.TxNonceHandlerV2Stub{
 			ApplyNonceAndGasPriceCalled: func(ctx context.Context, address address.Address, tx *transaction.Transaction) error {
 				tx.RawData.Nonce = 43
 				return nil
@@ -196,7 +245,7 @@ func TestKCNotifee_PriceChanged(t *testing.T) {
 				return nil, expectedErr
 			},
 		}
-		args.TxNonceHandler = &testsCommon.TxNonceHandlerV2Stub{
+		args.Keys[0].TxNonceHandler = &testsCommon.TxNonceHandlerV2Stub{
 			ApplyNonceAndGasPriceCalled: func(ctx context.Context, address address.Address, tx *transaction.Transaction) error {
 				tx.RawData.Nonce = 43
 				return nil
@@ -219,7 +268,7 @@ func TestKCNotifee_PriceChanged(t *testing.T) {
 
 		expectedErr := errors.New("expected error")
 		args := createMockArgsKCNotifeeWithSomeRealComponents()
-		args.TxNonceHandler = &testsCommon.TxNonceHandlerV2Stub{
+		args.Keys[0].TxNonceHandler = &testsCommon.TxNonceHandlerV2Stub{
 			ApplyNonceAndGasPriceCalled: func(ctx context.Context, address address.Address, tx *transaction.Transaction) error {
 				tx.RawData.Nonce = 43
 				return nil
@@ -242,7 +291,7 @@ func TestKCNotifee_PriceChanged(t *testing.T) {
 		priceChanges := createMockPriceChanges()
 		sentWasCalled := false
 		args := createMockArgsKCNotifeeWithSomeRealComponents()
-		args.TxNonceHandler = &testsCommon.TxNonceHandlerV2Stub{
+		args.Keys[0].TxNonceHandler = &testsCommon.TxNonceHandlerV2Stub{
 			ApplyNonceAndGasPriceCalled: func(ctx context.Context, address address.Address, tx *transaction.Transaction) error {
 				tx.RawData.Nonce = 43
 				return nil
@@ -300,4 +349,369 @@ func TestKCNotifee_PriceChanged(t *testing.T) {
 		assert.Nil(t, err)
 		assert.True(t, sentWasCalled)
 	})
+	t.Run("transaction signer should receive the raw transaction", func(t *testing.T) {
+		t.Parallel()
+
+		priceChanges := createMockPriceChanges()
+		args := createMockArgsKCNotifeeWithSomeRealComponents()
+		args.Proxy.(*interactors.ProxyStub).EstimateTransactionFeesCalled = func(_ context.Context, _ *transaction.Transaction) (*transaction.FeesResponse, error) {
+			return &transaction.FeesResponse{
+				CostResponse: &transaction.CostResponse{KAppFee: 500000, BandwidthFee: 1000000},
+			}, nil
+		}
+		var checkedRequest *wallet.SignRequest
+		var signedRawTx []byte
+		args.Keys[0].Wallet = &transactionSignerStub{
+			Wallet: args.Keys[0].Wallet,
+			checkTransactionCalled: func(ctx context.Context, request *wallet.SignRequest) error {
+				assert.Nil(t, signedRawTx, "the call should be checked before the transaction is signed")
+				checkedRequest = request
+				return nil
+			},
+			signTransactionCalled: func(ctx context.Context, rawTx []byte) ([]byte, error) {
+				signedRawTx = rawTx
+				return []byte("signature"), nil
+			},
+		}
+		sentWasCalled := false
+		args.Keys[0].TxNonceHandler = &testsCommon.TxNonceHandlerV2Stub{
+			ApplyNonceAndGasPriceCalled: func(ctx context.Context, address address.Address, tx *transaction.Transaction) error {
+				tx.RawData.Nonce = 43
+				tx.RawData.KAppFee = 500000
+				tx.RawData.BandwidthFee = 1000000
+				return nil
+			},
+			SendTransactionCalled: func(ctx context.Context, tx *transaction.Transaction) (string, error) {
+				require.Len(t, tx.GetSignature(), 1)
+				assert.Equal(t, []byte("signature"), tx.GetSignature()[0])
+
+				// the signer gets the exact raw data being sent, nonce and fees included
+				expectedRawTx, err := marshal.NewProtoMarshalizer().Marshal(tx.GetRawData())
+				require.Nil(t, err)
+				assert.Equal(t, expectedRawTx, signedRawTx)
+				sentWasCalled = true
+
+				return "hash", nil
+			},
+		}
+
+		en, err := NewKCNotifee(args)
+		require.Nil(t, err)
+
+		err = en.PriceChanged(context.Background(), priceChanges)
+		assert.Nil(t, err)
+		assert.True(t, sentWasCalled)
+
+		rawData := &transaction.Transaction_Raw{}
+		require.Nil(t, marshal.NewProtoMarshalizer().Unmarshal(rawData, signedRawTx))
+		assert.Equal(t, uint64(43), rawData.GetNonce())
+		assert.Equal(t, int64(500000), rawData.GetKAppFee())
+		assert.Equal(t, int64(1000000), rawData.GetBandwidthFee())
+
+		require.NotNil(t, checkedRequest)
+		assert.Equal(t, args.ContractAddress.Bech32(), checkedRequest.Contract)
+		assert.Equal(t, function, checkedRequest.Function)
+		assert.Equal(t, uint64(1500000), checkedRequest.Fee)
+		require.Len(t, checkedRequest.Args, 2)
+		assert.Equal(t, wallet.SubmitBatchArg{Base: "USD", Quote: "BTC", Timestamp: 300, Price: 47000000000, Decimals: 6},
+			checkedRequest.Args[1])
+	})
+	t.Run("rejected batch should not consume a nonce", func(t *testing.T) {
+		t.Parallel()
+
+		sentNonces := make([]uint64, 0)
+		proxy := &interactors.ProxyStub{
+			GetNetworkConfigCalled: func(ctx context.Context) (*models.NetworkConfig, error) {
+				return &models.NetworkConfig{ChainID: chainID}, nil
+			},
+			GetAccountCalled: func(ctx context.Context, address address.Address) (*models.Account, error) {
+				return &models.Account{Nonce: 10}, nil
+			},
+			SendTransactionCalled: func(ctx context.Context, tx *transaction.Transaction) (string, error) {
+				sentNonces = append(sentNonces, tx.GetRawData().GetNonce())
+				return "hash", nil
+			},
+		}
+		txNonceHandler, err := nonceHandler.NewNonceTransactionHandlerV2(nonceHandler.ArgsNonceTransactionsHandlerV2{
+			Proxy:            proxy,
+			IntervalToResend: time.Minute,
+		})
+		require.Nil(t, err)
+		defer func() {
+			_ = txNonceHandler.Close()
+		}()
+
+		args := createMockArgsKCNotifeeWithSomeRealComponents()
+		args.Proxy = proxy
+		args.Keys[0].TxNonceHandler = txNonceHandler
+		args.Keys[0].Wallet = &transactionSignerStub{
+			Wallet: args.Keys[0].Wallet,
+			checkTransactionCalled: func(ctx context.Context, request *wallet.SignRequest) error {
+				if request.Args[0].Price > 1000000 {
+					return errors.New("price deviates from the reference")
+				}
+				return nil
+			},
+			signTransactionCalled: func(ctx context.Context, rawTx []byte) ([]byte, error) {
+				return []byte("signature"), nil
+			},
+		}
+		en, err := NewKCNotifee(args)
+		require.Nil(t, err)
+
+		rejected := createMockPriceChanges()
+		rejected[0].DenominatedPrice = 2000000
+		err = en.PriceChanged(context.Background(), rejected)
+		assert.ErrorContains(t, err, "price deviates from the reference")
+
+		err = en.PriceChanged(context.Background(), createMockPriceChanges())
+		require.Nil(t, err)
+		err = en.PriceChanged(context.Background(), createMockPriceChanges())
+		require.Nil(t, err)
+
+		assert.Equal(t, []uint64{10, 11}, sentNonces)
+	})
+	t.Run("signing error should reset the nonce", func(t *testing.T) {
+		t.Parallel()
+
+		sentNonces := make([]uint64, 0)
+		proxy := &interactors.ProxyStub{
+			GetNetworkConfigCalled: func(ctx context.Context) (*models.NetworkConfig, error) {
+				return &models.NetworkConfig{ChainID: chainID}, nil
+			},
+			GetAccountCalled: func(ctx context.Context, address address.Address) (*models.Account, error) {
+				return &models.Account{Nonce: 10}, nil
+			},
+			SendTransactionCalled: func(ctx context.Context, tx *transaction.Transaction) (string, error) {
+				sentNonces = append(sentNonces, tx.GetRawData().GetNonce())
+				return "hash", nil
+			},
+		}
+		txNonceHandler, err := NewResettableNonceHandler(func() (ClosableNonceHandler, error) {
+			return nonceHandler.NewNonceTransactionHandlerV2(nonceHandler.ArgsNonceTransactionsHandlerV2{
+				Proxy:            proxy,
+				IntervalToResend: time.Minute,
+			})
+		})
+		require.Nil(t, err)
+		defer func() {
+			_ = txNonceHandler.Close()
+		}()
+
+		signerUnavailable := true
+		args := createMockArgsKCNotifeeWithSomeRealComponents()
+		args.Proxy = proxy
+		args.Keys[0].TxNonceHandler = txNonceHandler
+		args.Keys[0].Wallet = &transactionSignerStub{
+			Wallet: args.Keys[0].Wallet,
+			signTransactionCalled: func(ctx context.Context, rawTx []byte) ([]byte, error) {
+				if signerUnavailable {
+					return nil, errors.New("signer unavailable")
+				}
+				return []byte("signature"), nil
+			},
+		}
+		en, err := NewKCNotifee(args)
+		require.Nil(t, err)
+
+		err = en.PriceChanged(context.Background(), createMockPriceChanges())
+		assert.ErrorContains(t, err, "signer unavailable")
+
+		signerUnavailable = false
+		err = en.PriceChanged(context.Background(), createMockPriceChanges())
+		require.Nil(t, err)
+		err = en.PriceChanged(context.Background(), createMockPriceChanges())
+		require.Nil(t, err)
+
+		assert.Equal(t, []uint64{10, 11}, sentNonces)
+	})
+}
+
+func createOracleKeys(t *testing.T, numKeys int, sentBy *[]string, failingKeys map[int]bool) []OracleKey {
+	keys := make([]OracleKey, 0, numKeys)
+	for i := 0; i < numKeys; i++ {
+		keyWallet, err := wallet.NewWallet(bytes.Repeat([]byte{byte(i + 1)}, 32))
+		require.Nil(t, err)
+
+		index := i
+		keys = append(keys, OracleKey{
+			Wallet: keyWallet,
+			TxNonceHandler: &testsCommon.TxNonceHandlerV2Stub{
+				ApplyNonceAndGasPriceCalled: func(ctx context.Context, address address.Address, tx *transaction.Transaction) error {
+					if failingKeys[index] {
+						return errors.New("insufficient funds")
+					}
+					return nil
+				},
+				SendTransactionCalled: func(ctx context.Context, tx *transaction.Transaction) (string, error) {
+					sender, _ := address.NewAddressFromBytes(tx.GetRawData().GetSender())
+					*sentBy = append(*sentBy, sender.Bech32())
+					return "hash", nil
+				},
+			},
+		})
+	}
+
+	return keys
+}
+
+func keyAddress(key OracleKey) string {
+	keyAddress, _ := key.Wallet.Address()
+	return keyAddress.Bech32()
+}
+
+func TestKCNotifee_KeySelection(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round robin should spread the transactions", func(t *testing.T) {
+		t.Parallel()
+
+		sentBy := make([]string, 0)
+		args := createMockArgsKCNotifeeWithSomeRealComponents()
+		args.Keys = createOracleKeys(t, 3, &sentBy, nil)
+		en, _ := NewKCNotifee(args)
+
+		for i := 0; i < 4; i++ {
+			err := en.PriceChanged(context.Background(), createMockPriceChanges())
+			require.Nil(t, err)
+		}
+
+		expected := []string{keyAddress(args.Keys[0]), keyAddress(args.Keys[1]), keyAddress(args.Keys[2]), keyAddress(args.Keys[0])}
+		assert.Equal(t, expected, sentBy)
+	})
+	t.Run("round robin should skip the failing keys", func(t *testing.T) {
+		t.Parallel()
+
+		sentBy := make([]string, 0)
+		args := createMockArgsKCNotifeeWithSomeRealComponents()
+		args.Keys = createOracleKeys(t, 3, &sentBy, map[int]bool{1: true})
+		en, _ := NewKCNotifee(args)
+
+		for i := 0; i < 3; i++ {
+			err := en.PriceChanged(context.Background(), createMockPriceChanges())
+			require.Nil(t, err)
+		}
+
+		expected := []string{keyAddress(args.Keys[0]), keyAddress(args.Keys[2]), keyAddress(args.Keys[2])}
+		assert.Equal(t, expected, sentBy)
+	})
+	t.Run("failover should use the first working key", func(t *testing.T) {
+		t.Parallel()
+
+		sentBy := make([]string, 0)
+		failingKeys := map[int]bool{0: true}
+		args := createMockArgsKCNotifeeWithSomeRealComponents()
+		args.KeySelection = FailoverKeySelection
+		args.Keys = createOracleKeys(t, 3, &sentBy, failingKeys)
+		en, _ := NewKCNotifee(args)
+
+		for i := 0; i < 2; i++ {
+			err := en.PriceChanged(context.Background(), createMockPriceChanges())
+			require.Nil(t, err)
+		}
+
+		expected := []string{keyAddress(args.Keys[1]), keyAddress(args.Keys[1])}
+		assert.Equal(t, expected, sentBy)
+	})
+	t.Run("send errors should not fail over", func(t *testing.T) {
+		t.Parallel()
+
+		sentBy := make([]string, 0)
+		args := createMockArgsKCNotifeeWithSomeRealComponents()
+		args.KeySelection = FailoverKeySelection
+		args.Keys = createOracleKeys(t, 2, &sentBy, nil)
+		numApplied := 0
+		args.Keys[0].TxNonceHandler = &testsCommon.TxNonceHandlerV2Stub{
+			ApplyNonceAndGasPriceCalled: func(ctx context.Context, address address.Address, tx *transaction.Transaction) error {
+				numApplied++
+				return nil
+			},
+			SendTransactionCalled: func(ctx context.Context, tx *transaction.Transaction) (string, error) {
+				return "", errors.New("connection reset")
+			},
+		}
+		en, _ := NewKCNotifee(args)
+
+		err := en.PriceChanged(context.Background(), createMockPriceChanges())
+		assert.ErrorContains(t, err, "connection reset")
+		assert.Equal(t, 1, numApplied)
+		assert.Empty(t, sentBy)
+	})
+	t.Run("signing errors should reset the nonce and fail over", func(t *testing.T) {
+		t.Parallel()
+
+		sentBy := make([]string, 0)
+		args := createMockArgsKCNotifeeWithSomeRealComponents()
+		args.KeySelection = FailoverKeySelection
+		args.Keys = createOracleKeys(t, 2, &sentBy, nil)
+		numHandlers := 0
+		args.Keys[0].TxNonceHandler, _ = NewResettableNonceHandler(func() (ClosableNonceHandler, error) {
+			numHandlers++
+			return &testsCommon.TxNonceHandlerV2Stub{}, nil
+		})
+		args.Keys[0].Wallet = &transactionSignerStub{
+			Wallet: args.Keys[0].Wallet,
+			signTransactionCalled: func(ctx context.Context, rawTx []byte) ([]byte, error) {
+				return nil, errors.New("signer unavailable")
+			},
+		}
+		en, _ := NewKCNotifee(args)
+
+		err := en.PriceChanged(context.Background(), createMockPriceChanges())
+		require.Nil(t, err)
+		assert.Equal(t, []string{keyAddress(args.Keys[1])}, sentBy)
+		assert.Equal(t, 2, numHandlers)
+	})
+	t.Run("rejected check should fail over", func(t *testing.T) {
+		t.Parallel()
+
+		sentBy := make([]string, 0)
+		args := createMockArgsKCNotifeeWithSomeRealComponents()
+		args.KeySelection = FailoverKeySelection
+		args.Keys = createOracleKeys(t, 2, &sentBy, nil)
+		args.Keys[0].Wallet = &transactionSignerStub{
+			Wallet: args.Keys[0].Wallet,
+			checkTransactionCalled: func(ctx context.Context, request *wallet.SignRequest) error {
+				return errors.New("daily fee cap reached")
+			},
+		}
+		en, _ := NewKCNotifee(args)
+
+		err := en.PriceChanged(context.Background(), createMockPriceChanges())
+		require.Nil(t, err)
+		assert.Equal(t, []string{keyAddress(args.Keys[1])}, sentBy)
+	})
+	t.Run("all keys failing should error", func(t *testing.T) {
+		t.Parallel()
+
+		sentBy := make([]string, 0)
+		args := createMockArgsKCNotifeeWithSomeRealComponents()
+		args.Keys = createOracleKeys(t, 2, &sentBy, map[int]bool{0: true, 1: true})
+		en, _ := NewKCNotifee(args)
+
+		err := en.PriceChanged(context.Background(), createMockPriceChanges())
+		assert.NotNil(t, err)
+		assert.Empty(t, sentBy)
+	})
+	t.Run("set keys should replace the keys", func(t *testing.T) {
+		t.Parallel()
+
+		sentBy := make([]string, 0)
+		args := createMockArgsKCNotifeeWithSomeRealComponents()
+		keys := createOracleKeys(t, 3, &sentBy, nil)
+		args.Keys = keys[:1]
+		en, _ := NewKCNotifee(args)
+
+		err := en.SetKeys(nil)
+		assert.Equal(t, errNoOracleKeys, err)
+
+		err = en.SetKeys(keys[1:])
+		require.Nil(t, err)
+		for i := 0; i < 2; i++ {
+			err = en.PriceChanged(context.Background(), createMockPriceChanges())
+			require.Nil(t, err)
+		}
+
+		assert.ElementsMatch(t, []string{keyAddress(keys[1]), keyAddress(keys[2])}, sentBy)
+	})
 }
@@ -0,0 +1,92 @@
+package notifees
+
+import (
+	"context"
+	"sync"
+
+	"github.com/klever-io/klever-go/data/transaction"
+	"github.com/klever-io/klv-bridge-eth-go/clients/klever/blockchain/address"
+)
+
+// ClosableNonceHandler defines a nonce handler resending the pending transactions until closed
+type ClosableNonceHandler interface {
+	TransactionNonceHandler
+	Close() error
+}
+
+// NonceResetter defines a nonce handler able to forget its computed nonces, so a nonce applied to a transaction that
+// is never sent does not leave a gap behind
+type NonceResetter interface {
+	ResetNonce() error
+}
+
+// NonceHandlerFactory creates the nonce handlers of a resettable nonce handler
+type NonceHandlerFactory func() (ClosableNonceHandler, error)
+
+type resettableNonceHandler struct {
+	factory NonceHandlerFactory
+
+	mut     sync.RWMutex
+	handler ClosableNonceHandler
+}
+
+// NewResettableNonceHandler creates a nonce handler delegating to the handler created by the factory, replaced by a
+// new one on each reset
+func NewResettableNonceHandler(factory NonceHandlerFactory) (*resettableNonceHandler, error) {
+	if factory == nil {
+		return nil, errNilNonceHandlerFactory
+	}
+
+	handler, err := factory()
+	if err != nil {
+		return nil, err
+	}
+
+	return &resettableNonceHandler{
+		factory: factory,
+		handler: handler,
+	}, nil
+}
+
+func (rnh *resettableNonceHandler) currentHandler() ClosableNonceHandler {
+	rnh.mut.RLock()
+	defer rnh.mut.RUnlock()
+
+	return rnh.handler
+}
+
+// ApplyNonceAndGasPrice applies the nonce and the fees of the current handler to the transaction
+func (rnh *resettableNonceHandler) ApplyNonceAndGasPrice(ctx context.Context, address address.Address, tx *transaction.Transaction) error {
+	return rnh.currentHandler().ApplyNonceAndGasPrice(ctx, address, tx)
+}
+
+// SendTransaction sends the transaction through the current handler, which resends it until it is executed
+func (rnh *resettableNonceHandler) SendTransaction(ctx context.Context, tx *transaction.Transaction) (string, error) {
+	return rnh.currentHandler().SendTransaction(ctx, tx)
+}
+
+// ResetNonce replaces the current handler with a new one, starting again from the account nonce. The former handler is
+// closed, so its pending transactions are no longer resent
+func (rnh *resettableNonceHandler) ResetNonce() error {
+	handler, err := rnh.factory()
+	if err != nil {
+		return err
+	}
+
+	rnh.mut.Lock()
+	former := rnh.handler
+	rnh.handler = handler
+	rnh.mut.Unlock()
+
+	return former.Close()
+}
+
+// Close closes the current handler
+func (rnh *resettableNonceHandler) Close() error {
+	return rnh.currentHandler().Close()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (rnh *resettableNonceHandler) IsInterfaceNil() bool {
+	return rnh == nil
+}
@@ -0,0 +1,91 @@
+package notifees
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/klever-io/klever-go/data/transaction"
+	"github.com/klever-io/klv-bridge-eth-go/clients/klever/blockchain/address"
+	"github.com/klever-io/klv-bridge-eth-go/testsCommon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewResettableNonceHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil factory should error", func(t *testing.T) {
+		t.Parallel()
+
+		rnh, err := NewResettableNonceHandler(nil)
+		assert.Nil(t, rnh)
+		assert.Equal(t, errNilNonceHandlerFactory, err)
+	})
+	t.Run("factory error should error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		rnh, err := NewResettableNonceHandler(func() (ClosableNonceHandler, error) {
+			return nil, expectedErr
+		})
+		assert.Nil(t, rnh)
+		assert.Equal(t, expectedErr, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		rnh, err := NewResettableNonceHandler(func() (ClosableNonceHandler, error) {
+			return &testsCommon.TxNonceHandlerV2Stub{}, nil
+		})
+		require.Nil(t, err)
+		assert.False(t, rnh.IsInterfaceNil())
+	})
+}
+
+func TestResettableNonceHandler_ResetNonce(t *testing.T) {
+	t.Parallel()
+
+	handlers := make([]*testsCommon.TxNonceHandlerV2Stub, 0)
+	closed := make([]int, 0)
+	var factoryErr error
+	rnh, err := NewResettableNonceHandler(func() (ClosableNonceHandler, error) {
+		if factoryErr != nil {
+			return nil, factoryErr
+		}
+
+		index := len(handlers)
+		handler := &testsCommon.TxNonceHandlerV2Stub{
+			ApplyNonceAndGasPriceCalled: func(ctx context.Context, address address.Address, tx *transaction.Transaction) error {
+				tx.RawData.Nonce = uint64(index)
+				return nil
+			},
+			CloseCalled: func() error {
+				closed = append(closed, index)
+				return nil
+			},
+		}
+		handlers = append(handlers, handler)
+
+		return handler, nil
+	})
+	require.Nil(t, err)
+
+	applyNonce := func() uint64 {
+		tx := transaction.NewBaseTransaction(nil, 0, nil, 0, 0)
+		require.Nil(t, rnh.ApplyNonceAndGasPrice(context.Background(), nil, tx))
+		return tx.GetRawData().GetNonce()
+	}
+	assert.Equal(t, uint64(0), applyNonce())
+
+	require.Nil(t, rnh.ResetNonce())
+	assert.Equal(t, uint64(1), applyNonce())
+	assert.Equal(t, []int{0}, closed)
+
+	factoryErr = errors.New("expected error")
+	assert.Equal(t, factoryErr, rnh.ResetNonce())
+	assert.Equal(t, uint64(1), applyNonce())
+
+	require.Nil(t, rnh.Close())
+	assert.Equal(t, []int{0, 1}, closed)
+}
@@ -3,11 +3,20 @@ package aggregator
 import (
 	"fmt"
 	"math"
+	"strconv"
+	"strings"
+	"time"
 )
 
 const (
 	minDecimals = 1
 	maxDecimals = 18
+
+	basisPointsInPercent = 100
+	basisPointsInOne     = 10000
+	percentSuffix        = "%"
+	basisPointsSuffix    = "bp"
+	chainSeparator       = "-"
 )
 
 // ArgsPair is the argument DTO for a pair
@@ -17,16 +26,40 @@ type ArgsPair struct {
 	PercentDifferenceToNotify uint32
 	Decimals                  uint64
 	Exchanges                 map[string]struct{}
+	// DeviationThresholdBps is the change, in basis points, that triggers a notification. It replaces the whole
+	// PercentDifferenceToNotify when not 0
+	DeviationThresholdBps float64
+	// UpThresholdBps and DownThresholdBps replace the deviation threshold for the price increases, respectively the
+	// price decreases, when not 0
+	UpThresholdBps   float64
+	DownThresholdBps float64
+	// AbsoluteChangeToNotify is the minimum change, in quote units, able to trigger a notification. It filters the
+	// tick sized moves of the low-priced assets. 0 disables it
+	AbsoluteChangeToNotify float64
+	// AutoSendInterval is the heartbeat of the pair: the price is sent when it was not sent for this long, even if it
+	// did not change. The price notifier's AutoSendInterval is used when 0
+	AutoSendInterval time.Duration
+	// Chain identifies the EVM chain of a gas pair, the default gas chain being used when empty. It is appended to
+	// the published base, e.g. GWEI-BSC, so each chain gets its own feeds
+	Chain string
+	// PollInterval is the time between two price fetches of the pair, letting the slow-moving feeds spare the
+	// exchanges quota. The pair is fetched on every execution when 0
+	PollInterval time.Duration
 }
 
 type pair struct {
-	base                      string
-	quote                     string
-	percentDifferenceToNotify uint32
-	decimals                  uint64
-	trimPrecision             float64
-	denominationFactor        uint64
-	exchanges                 map[string]struct{}
+	base                   string
+	quote                  string
+	upThresholdBps         float64
+	downThresholdBps       float64
+	absoluteChangeToNotify float64
+	decimals               uint64
+	trimPrecision          float64
+	denominationFactor     uint64
+	exchanges              map[string]struct{}
+	autoSendInterval       time.Duration
+	pollInterval           time.Duration
+	chain                  string
 }
 
 func newPair(args *ArgsPair) (*pair, error) {
@@ -35,15 +68,25 @@ func newPair(args *ArgsPair) (*pair, error) {
 		return nil, err
 	}
 
+	thresholdBps := float64(args.PercentDifferenceToNotify) * basisPointsInPercent
+	if args.DeviationThresholdBps > 0 {
+		thresholdBps = args.DeviationThresholdBps
+	}
+
 	denominationFactorAsFloat64 := math.Pow(10, float64(args.Decimals))
 	return &pair{
-		base:                      args.Base,
-		quote:                     args.Quote,
-		percentDifferenceToNotify: args.PercentDifferenceToNotify,
-		decimals:                  args.Decimals,
-		trimPrecision:             float64(1) / denominationFactorAsFloat64,
-		denominationFactor:        uint64(denominationFactorAsFloat64),
-		exchanges:                 args.Exchanges,
+		base:                   args.Base,
+		quote:                  args.Quote,
+		upThresholdBps:         valueOrDefault(args.UpThresholdBps, thresholdBps),
+		downThresholdBps:       valueOrDefault(args.DownThresholdBps, thresholdBps),
+		absoluteChangeToNotify: args.AbsoluteChangeToNotify,
+		decimals:               args.Decimals,
+		trimPrecision:          float64(1) / denominationFactorAsFloat64,
+		denominationFactor:     uint64(denominationFactorAsFloat64),
+		exchanges:              args.Exchanges,
+		autoSendInterval:       args.AutoSendInterval,
+		pollInterval:           args.PollInterval,
+		chain:                  args.Chain,
 	}, nil
 }
 
@@ -61,10 +104,83 @@ func checkPairArgs(args *ArgsPair) error {
 	if len(args.Exchanges) == 0 {
 		return ErrNilExchanges
 	}
+	thresholds := []float64{args.DeviationThresholdBps, args.UpThresholdBps, args.DownThresholdBps, args.AbsoluteChangeToNotify}
+	for _, threshold := range thresholds {
+		if threshold < 0 || math.IsNaN(threshold) || math.IsInf(threshold, 0) {
+			return fmt.Errorf("%w, got %v for pair %s-%s", ErrInvalidDeviationThreshold, threshold, args.Base, args.Quote)
+		}
+	}
+	// a price can not drop by 100% or more
+	if args.DownThresholdBps >= basisPointsInOne {
+		return fmt.Errorf("%w, down threshold of %v basis points for pair %s-%s can never be reached",
+			ErrInvalidDeviationThreshold, args.DownThresholdBps, args.Base, args.Quote)
+	}
+	if args.AutoSendInterval != 0 && args.AutoSendInterval < minAutoSendInterval {
+		return fmt.Errorf("%w, minimum %v, got %v for pair %s-%s", ErrInvalidAutoSendInterval,
+			minAutoSendInterval, args.AutoSendInterval, args.Base, args.Quote)
+	}
+	if len(args.Chain) > 0 && args.Base != gweiTicker && args.Quote != gweiTicker {
+		return fmt.Errorf("%w, chain %s set for pair %s-%s", ErrNotAGasPair, args.Chain, args.Base, args.Quote)
+	}
+	if args.PollInterval < 0 {
+		return fmt.Errorf("%w, got %v for pair %s-%s", ErrInvalidPollInterval, args.PollInterval, args.Base, args.Quote)
+	}
 
 	return nil
 }
 
+// isGasFeed returns true for the pairs provided by the gas price service: the GWEI prices converted in the quote
+// currency and the gas fee feeds quoted in GWEI
+func (p *pair) isGasFeed() bool {
+	return p.base == gweiTicker || p.quote == gweiTicker
+}
+
+// publishedBase returns the base notified to the contract, suffixed with the chain of the gas pairs
+func (p *pair) publishedBase() string {
+	if len(p.chain) == 0 {
+		return p.base
+	}
+
+	return p.base + chainSeparator + p.chain
+}
+
+func valueOrDefault(value float64, defaultValue float64) float64 {
+	if value > 0 {
+		return value
+	}
+
+	return defaultValue
+}
+
+// ParseDeviationThreshold converts a deviation threshold written as a percent, like "0.25%", or as basis points, like
+// "25bp" or "25bps", to basis points. An empty value is converted to 0
+func ParseDeviationThreshold(value string) (float64, error) {
+	trimmed := strings.ToLower(strings.TrimSpace(value))
+	if len(trimmed) == 0 {
+		return 0, nil
+	}
+
+	multiplier := float64(1)
+	switch {
+	case strings.HasSuffix(trimmed, percentSuffix):
+		trimmed = strings.TrimSuffix(trimmed, percentSuffix)
+		multiplier = basisPointsInPercent
+	case strings.HasSuffix(trimmed, basisPointsSuffix+"s"):
+		trimmed = strings.TrimSuffix(trimmed, basisPointsSuffix+"s")
+	case strings.HasSuffix(trimmed, basisPointsSuffix):
+		trimmed = strings.TrimSuffix(trimmed, basisPointsSuffix)
+	default:
+		return 0, fmt.Errorf("%w, %q should end with %% or bp", ErrInvalidDeviationThreshold, value)
+	}
+
+	number, err := strconv.ParseFloat(strings.TrimSpace(trimmed), 64)
+	if err != nil || number < 0 || math.IsNaN(number) || math.IsInf(number, 0) {
+		return 0, fmt.Errorf("%w, %q", ErrInvalidDeviationThreshold, value)
+	}
+
+	return number * multiplier, nil
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (p *pair) IsInterfaceNil() bool {
 	return p == nil
@@ -3,6 +3,7 @@ package aggregator
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/stretchr/testify/assert"
@@ -61,6 +62,36 @@ func TestNewPair(t *testing.T) {
 		assert.True(t, check.IfNil(pn))
 		assert.Equal(t, ErrNilExchanges, err)
 	})
+	t.Run("auto send interval under the minimum", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsPair()
+		args.AutoSendInterval = time.Millisecond
+
+		pn, err := newPair(args)
+		assert.True(t, check.IfNil(pn))
+		assert.True(t, errors.Is(err, ErrInvalidAutoSendInterval))
+	})
+	t.Run("chain set for a pair not handled by the gas service", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsPair()
+		args.Chain = "BSC"
+
+		pn, err := newPair(args)
+		assert.True(t, check.IfNil(pn))
+		assert.True(t, errors.Is(err, ErrNotAGasPair))
+	})
+	t.Run("negative poll interval", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsPair()
+		args.PollInterval = -time.Second
+
+		pn, err := newPair(args)
+		assert.True(t, check.IfNil(pn))
+		assert.True(t, errors.Is(err, ErrInvalidPollInterval))
+	})
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
@@ -69,9 +100,69 @@ func TestNewPair(t *testing.T) {
 		pn, err := newPair(args)
 		assert.False(t, check.IfNil(pn))
 		assert.Nil(t, err)
+		assert.Equal(t, float64(100), pn.upThresholdBps)
+		assert.Equal(t, float64(100), pn.downThresholdBps)
+	})
+	t.Run("negative threshold", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsPair()
+		args.UpThresholdBps = -1
+
+		pn, err := newPair(args)
+		assert.True(t, check.IfNil(pn))
+		assert.True(t, errors.Is(err, ErrInvalidDeviationThreshold))
+	})
+	t.Run("unreachable down threshold", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsPair()
+		args.DownThresholdBps = 10000
+
+		pn, err := newPair(args)
+		assert.True(t, check.IfNil(pn))
+		assert.True(t, errors.Is(err, ErrInvalidDeviationThreshold))
+	})
+	t.Run("thresholds should override the whole percent", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsPair()
+		args.DeviationThresholdBps = 25
+		args.DownThresholdBps = 10
+		args.AbsoluteChangeToNotify = 0.5
+
+		pn, err := newPair(args)
+		assert.Nil(t, err)
+		assert.Equal(t, float64(25), pn.upThresholdBps)
+		assert.Equal(t, float64(10), pn.downThresholdBps)
+		assert.Equal(t, 0.5, pn.absoluteChangeToNotify)
 	})
 }
 
+func TestParseDeviationThreshold(t *testing.T) {
+	t.Parallel()
+
+	validValues := map[string]float64{
+		"":         0,
+		"0.25%":    25,
+		" 1.5 % ":  150,
+		"25bp":     25,
+		"12.5BPS":  12.5,
+		"0%":       0,
+		"10000bps": 10000,
+	}
+	for value, expectedBps := range validValues {
+		bps, err := ParseDeviationThreshold(value)
+		assert.Nil(t, err, value)
+		assert.InDelta(t, expectedBps, bps, 1e-9, value)
+	}
+
+	for _, value := range []string{"25", "abc%", "-1%", "bp", "NaN%"} {
+		_, err := ParseDeviationThreshold(value)
+		assert.True(t, errors.Is(err, ErrInvalidDeviationThreshold), value)
+	}
+}
+
 func createMockArgsPair() *ArgsPair {
 	return &ArgsPair{
 		Base:                      "BASE",
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/klever-io/klv-oracles-go/aggregator/stats"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	logger "github.com/multiversx/mx-chain-logger-go"
 )
@@ -14,17 +15,33 @@ const minResultsNum = 1
 
 var log = logger.GetOrCreate("klv-oracle-go/aggregator")
 
+// ArgsQuoteConversion defines a conversion leg applied to the prices that an exchange reports in a substitute quote.
+// As an example, a price fetched in USDT (From) is multiplied by the USDT/USD (From/To) median price before being
+// aggregated with the USD (To) prices
+type ArgsQuoteConversion struct {
+	From          string
+	To            string
+	MinResultsNum int
+}
+
 // ArgsPriceAggregator is the DTO used in the NewPriceAggregator function
 type ArgsPriceAggregator struct {
-	PriceFetchers []PriceFetcher
-	MinResultsNum int
+	PriceFetchers    []PriceFetcher
+	MinResultsNum    int
+	QuoteConversions []ArgsQuoteConversion
 }
 
-type priceAggregator struct {
-	priceFetchers []PriceFetcher
+type quoteConversion struct {
+	to            string
 	minResultsNum int
 }
 
+type priceAggregator struct {
+	priceFetchers    []PriceFetcher
+	minResultsNum    int
+	quoteConversions map[string]quoteConversion
+}
+
 // NewPriceAggregator creates a new priceAggregator instance
 func NewPriceAggregator(args ArgsPriceAggregator) (*priceAggregator, error) {
 	err := checkArgs(args)
@@ -32,9 +49,18 @@ func NewPriceAggregator(args ArgsPriceAggregator) (*priceAggregator, error) {
 		return nil, err
 	}
 
+	quoteConversions := make(map[string]quoteConversion, len(args.QuoteConversions))
+	for _, conversion := range args.QuoteConversions {
+		quoteConversions[strings.ToUpper(conversion.From)] = quoteConversion{
+			to:            strings.ToUpper(conversion.To),
+			minResultsNum: conversion.MinResultsNum,
+		}
+	}
+
 	return &priceAggregator{
-		priceFetchers: args.PriceFetchers,
-		minResultsNum: args.MinResultsNum,
+		priceFetchers:    args.PriceFetchers,
+		minResultsNum:    args.MinResultsNum,
+		quoteConversions: quoteConversions,
 	}, nil
 }
 
@@ -51,56 +77,193 @@ func checkArgs(args ArgsPriceAggregator) error {
 			return fmt.Errorf("%w, index: %d", ErrNilPriceFetcher, idx)
 		}
 	}
+	for idx, conversion := range args.QuoteConversions {
+		if len(conversion.From) == 0 || len(conversion.To) == 0 || strings.EqualFold(conversion.From, conversion.To) {
+			return fmt.Errorf("%w, index: %d, from: %s, to: %s", ErrInvalidQuoteConversion, idx, conversion.From, conversion.To)
+		}
+		if conversion.MinResultsNum < minResultsNum {
+			return fmt.Errorf("%w for the %s/%s quote conversion, provided: %d, minimum accepted: %d",
+				ErrInvalidMinNumberOfResults, conversion.From, conversion.To, conversion.MinResultsNum, minResultsNum)
+		}
+	}
 
 	return nil
 }
 
-// FetchPrice will try to fetch the price based on the provided array of price fetchers
+// FetchPrice will try to fetch the price based on the provided array of price fetchers. Prices reported by the
+// fetchers in a substitute quote are converted to the requested quote if a quote conversion is configured
 func (pa *priceAggregator) FetchPrice(ctx context.Context, base string, quote string) (float64, error) {
-	var wg sync.WaitGroup
-	var mut sync.Mutex
-	var prices []float64
+	pair := BaseQuote{Base: base, Quote: quote}
+	result := pa.FetchPrices(ctx, []BaseQuote{pair})[pair]
+
+	return result.Price, result.Err
+}
+
+// FetchPrices computes the median price of each of the provided pairs. All the price fetchers are queried
+// concurrently, each one with a single FetchPrices call containing all the pairs and the quote conversion legs
+// they might need
+func (pa *priceAggregator) FetchPrices(ctx context.Context, pairs []BaseQuote) map[BaseQuote]PriceResult {
+	upperPairs := make(map[BaseQuote]BaseQuote, len(pairs))
+	allPairs := make([]BaseQuote, 0, len(pairs))
+	isRequested := make(map[BaseQuote]struct{})
+	for _, pair := range pairs {
+		upperPair := BaseQuote{Base: strings.ToUpper(pair.Base), Quote: strings.ToUpper(pair.Quote)}
+		upperPairs[pair] = upperPair
+		if _, found := isRequested[upperPair]; found {
+			continue
+		}
+		isRequested[upperPair] = struct{}{}
+		allPairs = append(allPairs, upperPair)
+	}
+	for _, pair := range allPairs {
+		for from, conversion := range pa.quoteConversions {
+			leg := BaseQuote{Base: from, Quote: conversion.to}
+			if _, found := isRequested[leg]; conversion.to == pair.Quote && !found {
+				isRequested[leg] = struct{}{}
+				allPairs = append(allPairs, leg)
+			}
+		}
+	}
+
+	fetchedPrices := pa.fetchFromAllFetchers(ctx, allPairs)
+	conversionRates := make(map[BaseQuote]PriceResult)
+
+	medians := make(map[BaseQuote]PriceResult, len(upperPairs))
+	results := make(map[BaseQuote]PriceResult, len(pairs))
+	for _, pair := range pairs {
+		upperPair := upperPairs[pair]
+		median, found := medians[upperPair]
+		if !found {
+			median = pa.computeMedianPrice(upperPair, fetchedPrices, conversionRates)
+			medians[upperPair] = median
+		}
+		results[pair] = median
+	}
+
+	return results
+}
+
+type fetcherPrices struct {
+	priceFetcher PriceFetcher
+	prices       map[BaseQuote]PriceResult
+}
 
-	baseUpper := strings.ToUpper(base)
-	quoteUpper := strings.ToUpper(quote)
+func (pa *priceAggregator) fetchFromAllFetchers(ctx context.Context, pairs []BaseQuote) []fetcherPrices {
+	var wg sync.WaitGroup
+	results := make([]fetcherPrices, len(pa.priceFetchers))
 
 	wg.Add(len(pa.priceFetchers))
-	for _, pf := range pa.priceFetchers {
-		go func(priceFetcher PriceFetcher) {
+	for idx, pf := range pa.priceFetchers {
+		go func(index int, priceFetcher PriceFetcher) {
 			defer wg.Done()
-			price, err := priceFetcher.FetchPrice(ctx, baseUpper, quoteUpper)
-
-			if err == ErrPairNotSupported {
-				log.Trace("pair not supported",
-					"price fetcher", priceFetcher.Name(),
-					"base", baseUpper,
-					"quote", quoteUpper,
-				)
-				return
-			}
 
-			if err != nil {
-				log.Debug("failed to fetch price",
-					"price fetcher", priceFetcher.Name(),
-					"base", baseUpper,
-					"quote", quoteUpper,
-					"err", err.Error(),
-				)
-				return
+			results[index] = fetcherPrices{
+				priceFetcher: priceFetcher,
+				prices:       priceFetcher.FetchPrices(ctx, pairs),
 			}
-
-			mut.Lock()
-			prices = append(prices, price)
-			mut.Unlock()
-		}(pf)
+		}(idx, pf)
 	}
 	wg.Wait()
 
-	if len(prices) < pa.minResultsNum {
-		return 0, ErrNotEnoughResponses
+	return results
+}
+
+func (pa *priceAggregator) computeMedianPrice(
+	pair BaseQuote,
+	fetchedPrices []fetcherPrices,
+	conversionRates map[BaseQuote]PriceResult,
+) PriceResult {
+	prices := make([]float64, 0, len(fetchedPrices))
+	for _, fetched := range fetchedPrices {
+		price, err := fetchedPrice(fetched, pair)
+		if err != nil {
+			continue
+		}
+
+		price, err = pa.convertPrice(fetched.priceFetcher, pair, price, fetchedPrices, conversionRates)
+		if err != nil {
+			log.Debug("failed to convert price",
+				"price fetcher", fetched.priceFetcher.Name(),
+				"base", pair.Base,
+				"quote", pair.Quote,
+				"err", err.Error(),
+			)
+			continue
+		}
+
+		prices = append(prices, price)
+	}
+
+	return medianResult(prices, pa.minResultsNum)
+}
+
+func fetchedPrice(fetched fetcherPrices, pair BaseQuote) (float64, error) {
+	result, found := fetched.prices[pair]
+	if !found || result.Err == ErrPairNotSupported {
+		log.Trace("pair not supported",
+			"price fetcher", fetched.priceFetcher.Name(),
+			"base", pair.Base,
+			"quote", pair.Quote,
+		)
+		return 0, ErrPairNotSupported
+	}
+	if result.Err != nil {
+		log.Debug("failed to fetch price",
+			"price fetcher", fetched.priceFetcher.Name(),
+			"base", pair.Base,
+			"quote", pair.Quote,
+			"err", result.Err.Error(),
+		)
+		return 0, result.Err
+	}
+
+	return result.Price, nil
+}
+
+func medianResult(prices []float64, minResults int) PriceResult {
+	if len(prices) < minResults {
+		return PriceResult{Err: ErrNotEnoughResponses}
+	}
+
+	median, err := stats.Median(prices)
+	return PriceResult{Price: median, Err: err}
+}
+
+func (pa *priceAggregator) convertPrice(
+	priceFetcher PriceFetcher,
+	pair BaseQuote,
+	price float64,
+	fetchedPrices []fetcherPrices,
+	conversionRates map[BaseQuote]PriceResult,
+) (float64, error) {
+	mappedQuote := strings.ToUpper(priceFetcher.MappedQuote(pair.Quote))
+	conversion, found := pa.quoteConversions[mappedQuote]
+	if !found || conversion.to != pair.Quote {
+		return price, nil
+	}
+
+	leg := BaseQuote{Base: mappedQuote, Quote: pair.Quote}
+	rate, found := conversionRates[leg]
+	if !found {
+		legPrices := make([]float64, 0, len(fetchedPrices))
+		for _, fetched := range fetchedPrices {
+			legPrice, err := fetchedPrice(fetched, leg)
+			if err == nil {
+				legPrices = append(legPrices, legPrice)
+			}
+		}
+
+		rate = medianResult(legPrices, conversion.minResultsNum)
+		if rate.Err != nil {
+			rate.Err = fmt.Errorf("%w while fetching the %s/%s conversion rate", rate.Err, leg.Base, leg.Quote)
+		}
+		conversionRates[leg] = rate
+	}
+	if rate.Err != nil {
+		return 0, rate.Err
 	}
 
-	return computeMedian(prices)
+	return price * rate.Price, nil
 }
 
 // Name returns the name
@@ -3,12 +3,14 @@ package aggregator_test
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 
 	"github.com/klever-io/klv-oracles-go/aggregator"
 	"github.com/klever-io/klv-oracles-go/aggregator/mock"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func createMockArgsPriceAggregator() aggregator.ArgsPriceAggregator {
@@ -51,10 +53,37 @@ func TestNewPriceAggregator(t *testing.T) {
 		assert.True(t, check.IfNil(pa))
 		assert.True(t, errors.Is(err, aggregator.ErrNilPriceFetcher))
 	})
+	t.Run("invalid quote conversion should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsPriceAggregator()
+		args.QuoteConversions = []aggregator.ArgsQuoteConversion{{From: "USDT", To: "usdt", MinResultsNum: 1}}
+		pa, err := aggregator.NewPriceAggregator(args)
+
+		assert.True(t, check.IfNil(pa))
+		assert.True(t, errors.Is(err, aggregator.ErrInvalidQuoteConversion))
+
+		args.QuoteConversions = []aggregator.ArgsQuoteConversion{{From: "", To: "USD", MinResultsNum: 1}}
+		pa, err = aggregator.NewPriceAggregator(args)
+
+		assert.True(t, check.IfNil(pa))
+		assert.True(t, errors.Is(err, aggregator.ErrInvalidQuoteConversion))
+	})
+	t.Run("invalid quote conversion MinResultsNum should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsPriceAggregator()
+		args.QuoteConversions = []aggregator.ArgsQuoteConversion{{From: "USDT", To: "USD"}}
+		pa, err := aggregator.NewPriceAggregator(args)
+
+		assert.True(t, check.IfNil(pa))
+		assert.True(t, errors.Is(err, aggregator.ErrInvalidMinNumberOfResults))
+	})
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
 		args := createMockArgsPriceAggregator()
+		args.QuoteConversions = []aggregator.ArgsQuoteConversion{{From: "USDT", To: "USD", MinResultsNum: 1}}
 		pa, err := aggregator.NewPriceAggregator(args)
 
 		assert.Equal(t, "price aggregator", pa.Name())
@@ -128,4 +157,172 @@ func TestPriceAggregator_FetchPrice(t *testing.T) {
 		assert.Equal(t, aggregator.ErrNotEnoughResponses, err)
 		assert.Equal(t, 0.00, value)
 	})
+	t.Run("substitute quote prices should be converted", func(t *testing.T) {
+		numConversionFetches := 0
+		args := createMockArgsPriceAggregator()
+		args.QuoteConversions = []aggregator.ArgsQuoteConversion{{From: "usdt", To: "usd", MinResultsNum: 1}}
+		args.PriceFetchers = []aggregator.PriceFetcher{
+			&mock.PriceFetcherStub{
+				FetchPriceCalled: func(ctx context.Context, base string, quote string) (float64, error) {
+					if base == "USDT" {
+						return 0, aggregator.ErrPairNotSupported
+					}
+
+					return 2000, nil
+				},
+				MappedQuoteCalled: func(quote string) string {
+					return "USDT"
+				},
+			},
+			&mock.PriceFetcherStub{
+				FetchPriceCalled: func(ctx context.Context, base string, quote string) (float64, error) {
+					if base == "USDT" {
+						return 0, aggregator.ErrPairNotSupported
+					}
+
+					return 2002, nil
+				},
+				MappedQuoteCalled: func(quote string) string {
+					return "USDT"
+				},
+			},
+			&mock.PriceFetcherStub{
+				FetchPriceCalled: func(ctx context.Context, base string, quote string) (float64, error) {
+					if base == "USDT" && quote == "USD" {
+						numConversionFetches++
+						return 0.99, nil
+					}
+
+					return 1990, nil
+				},
+			},
+		}
+		pa, _ := aggregator.NewPriceAggregator(args)
+
+		value, err := pa.FetchPrice(context.Background(), "eth", "usd")
+		assert.Nil(t, err)
+		assert.InDelta(t, 1981.98, value, 0.0001)
+		assert.Equal(t, 1, numConversionFetches)
+	})
+	t.Run("failed conversion should drop the converted prices", func(t *testing.T) {
+		args := createMockArgsPriceAggregator()
+		args.QuoteConversions = []aggregator.ArgsQuoteConversion{{From: "USDT", To: "USD", MinResultsNum: 1}}
+		args.PriceFetchers = []aggregator.PriceFetcher{
+			&mock.PriceFetcherStub{
+				FetchPriceCalled: func(ctx context.Context, base string, quote string) (float64, error) {
+					if base == "USDT" {
+						return 0, aggregator.ErrPairNotSupported
+					}
+
+					return 2000, nil
+				},
+				MappedQuoteCalled: func(quote string) string {
+					return "USDT"
+				},
+			},
+			&mock.PriceFetcherStub{
+				FetchPriceCalled: func(ctx context.Context, base string, quote string) (float64, error) {
+					if base == "USDT" {
+						return 0, aggregator.ErrPairNotSupported
+					}
+
+					return 1990, nil
+				},
+			},
+		}
+		pa, _ := aggregator.NewPriceAggregator(args)
+
+		value, err := pa.FetchPrice(context.Background(), "ETH", "USD")
+		assert.Nil(t, err)
+		assert.Equal(t, 1990.0, value)
+	})
+	t.Run("substitute quote without conversion should be used as it is", func(t *testing.T) {
+		args := createMockArgsPriceAggregator()
+		args.PriceFetchers = []aggregator.PriceFetcher{
+			&mock.PriceFetcherStub{
+				FetchPriceCalled: func(ctx context.Context, base string, quote string) (float64, error) {
+					return 2000, nil
+				},
+				MappedQuoteCalled: func(quote string) string {
+					return "USDT"
+				},
+			},
+		}
+		pa, _ := aggregator.NewPriceAggregator(args)
+
+		value, err := pa.FetchPrice(context.Background(), "ETH", "USD")
+		assert.Nil(t, err)
+		assert.Equal(t, 2000.0, value)
+	})
+}
+
+func TestPriceAggregator_FetchPrices(t *testing.T) {
+	t.Parallel()
+
+	ethUSD := aggregator.BaseQuote{Base: "ETH", Quote: "USD"}
+	btcUSD := aggregator.BaseQuote{Base: "BTC", Quote: "USD"}
+	usdtUSD := aggregator.BaseQuote{Base: "USDT", Quote: "USD"}
+	prices := map[aggregator.BaseQuote]float64{
+		ethUSD:  2000,
+		btcUSD:  60000,
+		usdtUSD: 0.5,
+	}
+
+	var mut sync.Mutex
+	requestedPairs := make([][]aggregator.BaseQuote, 0)
+	createStub := func(multiplier float64) *mock.PriceFetcherStub {
+		return &mock.PriceFetcherStub{
+			FetchPricesCalled: func(ctx context.Context, pairs []aggregator.BaseQuote) map[aggregator.BaseQuote]aggregator.PriceResult {
+				mut.Lock()
+				requestedPairs = append(requestedPairs, pairs)
+				mut.Unlock()
+
+				results := make(map[aggregator.BaseQuote]aggregator.PriceResult)
+				for _, pair := range pairs {
+					results[pair] = aggregator.PriceResult{Price: prices[pair] * multiplier}
+				}
+				return results
+			},
+			FetchPriceCalled: func(ctx context.Context, base string, quote string) (float64, error) {
+				assert.Fail(t, "should have fetched all the pairs at once")
+				return 0, nil
+			},
+		}
+	}
+
+	substituteQuoteStub := createStub(2)
+	substituteQuoteStub.MappedQuoteCalled = func(quote string) string {
+		return "USDT"
+	}
+	failingStub := &mock.PriceFetcherStub{
+		FetchPricesCalled: func(ctx context.Context, pairs []aggregator.BaseQuote) map[aggregator.BaseQuote]aggregator.PriceResult {
+			return map[aggregator.BaseQuote]aggregator.PriceResult{
+				ethUSD: {Err: errors.New("expected error")},
+				btcUSD: {Err: aggregator.ErrPairNotSupported},
+			}
+		},
+	}
+
+	args := createMockArgsPriceAggregator()
+	args.MinResultsNum = 2
+	args.QuoteConversions = []aggregator.ArgsQuoteConversion{{From: "USDT", To: "USD", MinResultsNum: 1}}
+	args.PriceFetchers = []aggregator.PriceFetcher{createStub(1), createStub(1.01), substituteQuoteStub, failingStub}
+	pa, err := aggregator.NewPriceAggregator(args)
+	require.Nil(t, err)
+
+	lowerCaseEthUSD := aggregator.BaseQuote{Base: "eth", Quote: "usd"}
+	results := pa.FetchPrices(context.Background(), []aggregator.BaseQuote{lowerCaseEthUSD, btcUSD})
+
+	// the substitute quote fetcher reports twice the price in USDT, converted with the 0.505 USDT/USD median
+	require.Equal(t, 2, len(results))
+	require.Nil(t, results[lowerCaseEthUSD].Err)
+	assert.InDelta(t, 2020, results[lowerCaseEthUSD].Price, 0.0001)
+	require.Nil(t, results[btcUSD].Err)
+	assert.InDelta(t, 60600, results[btcUSD].Price, 0.0001)
+
+	// one call for each of the fetchers, each with the requested pairs and the conversion leg
+	require.Equal(t, 3, len(requestedPairs))
+	for _, pairs := range requestedPairs {
+		assert.Equal(t, []aggregator.BaseQuote{ethUSD, btcUSD, usdtUSD}, pairs)
+	}
 }
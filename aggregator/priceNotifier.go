@@ -12,16 +12,24 @@ import (
 )
 
 const epsilon = 0.0001
+const relativeTolerance = 1e-9
 const minAutoSendInterval = time.Second
 const gweiTicker = "GWEI"
 
 // ArgsPriceNotifier is the argument DTO for the price notifier
 type ArgsPriceNotifier struct {
-	Pairs            []*ArgsPair
-	Aggregator       PriceAggregator
-	GasPriceService  GasPriceService
-	Notifee          PriceNotifee
+	Pairs           []*ArgsPair
+	Aggregator      PriceAggregator
+	GasPriceService GasPriceService
+	Notifee         PriceNotifee
+	// AutoSendInterval is the heartbeat of the pairs not defining their own
 	AutoSendInterval time.Duration
+	// HeartbeatCoalesceWindow anticipates the heartbeats when a transaction is sent anyway: the pairs whose heartbeat
+	// is due in less than this window are added to it. 0 only adds the heartbeats already due
+	HeartbeatCoalesceWindow time.Duration
+	// TimeHandler returns the current time. The wall clock is used when nil, a virtual clock can be provided to
+	// replay historical data
+	TimeHandler func() time.Time
 }
 
 type priceInfo struct {
@@ -43,8 +51,11 @@ type priceNotifier struct {
 	pairs              []*pair
 	lastNotifiedPrices []float64
 	notifee            PriceNotifee
-	autoSendInterval   time.Duration
-	lastTimeAutoSent   time.Time
+	coalesceWindow     time.Duration
+	lastTimesSent      []time.Time
+	lastFetchedPrices  []priceInfo
+	nextPollTimes      []time.Time
+	timeHandler        func() time.Time
 	timeSinceHandler   func(t time.Time) time.Duration
 }
 
@@ -64,18 +75,36 @@ func NewPriceNotifier(args ArgsPriceNotifier) (*priceNotifier, error) {
 		if err != nil {
 			return nil, err
 		}
+		if pair.autoSendInterval == 0 {
+			pair.autoSendInterval = args.AutoSendInterval
+		}
 		pairs = append(pairs, pair)
 	}
 
+	timeHandler := args.TimeHandler
+	if timeHandler == nil {
+		timeHandler = time.Now
+	}
+
+	lastTimesSent := make([]time.Time, len(pairs))
+	for idx := range lastTimesSent {
+		lastTimesSent[idx] = timeHandler()
+	}
+
 	priceNotifier := &priceNotifier{
 		priceAggregator:    args.Aggregator,
 		gasPriceService:    args.GasPriceService,
 		pairs:              pairs,
 		lastNotifiedPrices: make([]float64, len(args.Pairs)),
 		notifee:            args.Notifee,
-		autoSendInterval:   args.AutoSendInterval,
-		lastTimeAutoSent:   time.Now(),
-		timeSinceHandler:   time.Since,
+		coalesceWindow:     args.HeartbeatCoalesceWindow,
+		lastTimesSent:      lastTimesSent,
+		lastFetchedPrices:  make([]priceInfo, len(pairs)),
+		nextPollTimes:      make([]time.Time, len(pairs)),
+		timeHandler:        timeHandler,
+		timeSinceHandler: func(t time.Time) time.Duration {
+			return timeHandler().Sub(t)
+		},
 	}
 
 	return priceNotifier, nil
@@ -89,6 +118,9 @@ func checkArgsPriceNotifier(args ArgsPriceNotifier) error {
 	if args.AutoSendInterval < minAutoSendInterval {
 		return fmt.Errorf("%w, minimum %v, got %v", ErrInvalidAutoSendInterval, minAutoSendInterval, args.AutoSendInterval)
 	}
+	if args.HeartbeatCoalesceWindow < 0 {
+		return fmt.Errorf("%w, negative heartbeat coalesce window %v", ErrInvalidAutoSendInterval, args.HeartbeatCoalesceWindow)
+	}
 	if check.IfNil(args.Notifee) {
 		return ErrNilPriceNotifee
 	}
@@ -102,85 +134,178 @@ func checkArgsPriceNotifier(args ArgsPriceNotifier) error {
 	return nil
 }
 
-// Execute will trigger the price fetching and notification if the new price exceeded provided percentage change
+// Execute will trigger the price fetching and notification if the new price exceeded provided percentage change.
+// Only the pairs due for polling are fetched, the prices of the others are reused from their last fetch
 func (pn *priceNotifier) Execute(ctx context.Context) error {
-	fetchedPrices, err := pn.getAllPrices(ctx)
+	now := pn.timeHandler()
+	duePairs := pn.computeDuePairs(now)
+	if !containsTrue(duePairs) {
+		return nil
+	}
+
+	fetchedPrices, err := pn.getAllPrices(ctx, duePairs)
 	if err != nil {
 		return err
 	}
 
-	fetchedPrices, err = pn.denominateGasPrice(ctx, fetchedPrices)
+	fetchedPrices, err = pn.denominateGasPrice(ctx, fetchedPrices, duePairs)
 	if err != nil {
 		return err
 	}
 
-	notifyArgsSlice := pn.computeNotifyArgsSlice(fetchedPrices)
+	pn.schedulePolls(fetchedPrices, duePairs, now)
+	notifyArgsSlice := pn.computeNotifyArgsSlice(fetchedPrices, duePairs)
 
 	return pn.notify(ctx, notifyArgsSlice)
 }
 
-func (pn *priceNotifier) getAllPrices(ctx context.Context) ([]priceInfo, error) {
-	fetchedPrices := make([]priceInfo, len(pn.pairs))
+// computeDuePairs returns the pairs whose poll interval elapsed or whose heartbeat is due
+func (pn *priceNotifier) computeDuePairs(now time.Time) []bool {
+	pn.mut.Lock()
+	defer pn.mut.Unlock()
+
+	duePairs := make([]bool, len(pn.pairs))
 	for idx, pair := range pn.pairs {
-		var price float64
-		var err error
-		// If the pair is a gas price ticker, we need to fetch the gas price
-		if pair.base != gweiTicker {
-			price, err = pn.priceAggregator.FetchPrice(ctx, pair.base, pair.quote)
+		isHeartbeatDue := pn.timeSinceHandler(pn.lastTimesSent[idx]) > pair.autoSendInterval
+		duePairs[idx] = !now.Before(pn.nextPollTimes[idx]) || isHeartbeatDue
+	}
+
+	return duePairs
+}
+
+func (pn *priceNotifier) schedulePolls(fetchedPrices []priceInfo, duePairs []bool, now time.Time) {
+	pn.mut.Lock()
+	defer pn.mut.Unlock()
+
+	for idx, pair := range pn.pairs {
+		if duePairs[idx] {
+			pn.lastFetchedPrices[idx] = fetchedPrices[idx]
+			pn.nextPollTimes[idx] = now.Add(pair.pollInterval)
 		}
+	}
+}
 
-		if err != nil {
-			return nil, fmt.Errorf("%w while querying the pair %s-%s", err, pair.base, pair.quote)
+func containsTrue(values []bool) bool {
+	for _, value := range values {
+		if value {
+			return true
 		}
+	}
+
+	return false
+}
+
+func (pn *priceNotifier) getAllPrices(ctx context.Context, duePairs []bool) ([]priceInfo, error) {
+	pairsToFetch := make([]BaseQuote, 0, len(pn.pairs))
+	for idx, pair := range pn.pairs {
+		// gas price tickers are converted afterwards by the gas price service
+		if duePairs[idx] && !pair.isGasFeed() {
+			pairsToFetch = append(pairsToFetch, BaseQuote{Base: pair.base, Quote: pair.quote})
+		}
+	}
 
-		fetchedPrice := priceInfo{
+	results := pn.priceAggregator.FetchPrices(ctx, pairsToFetch)
+	timestamp := pn.timeHandler().Unix()
+
+	pn.mut.Lock()
+	fetchedPrices := append(make([]priceInfo, 0, len(pn.lastFetchedPrices)), pn.lastFetchedPrices...)
+	pn.mut.Unlock()
+
+	for idx, pair := range pn.pairs {
+		if !duePairs[idx] {
+			continue
+		}
+
+		var price float64
+		if !pair.isGasFeed() {
+			result, found := results[BaseQuote{Base: pair.base, Quote: pair.quote}]
+			if !found {
+				result.Err = ErrNotEnoughResponses
+			}
+			if result.Err != nil {
+				return nil, fmt.Errorf("%w while querying the pair %s-%s", result.Err, pair.base, pair.quote)
+			}
+			price = result.Price
+		}
+
+		fetchedPrices[idx] = priceInfo{
 			price:     trim(price, pair.trimPrecision),
-			timestamp: time.Now().Unix(),
+			timestamp: timestamp,
 		}
-		fetchedPrices[idx] = fetchedPrice
 	}
 
 	return fetchedPrices, nil
 }
 
-func (pn *priceNotifier) computeNotifyArgsSlice(fetchedPrices []priceInfo) []*notifyArgs {
+func (pn *priceNotifier) computeNotifyArgsSlice(fetchedPrices []priceInfo, duePairs []bool) []*notifyArgs {
 	pn.mut.Lock()
 	defer pn.mut.Unlock()
 
-	shouldNotifyAll := pn.timeSinceHandler(pn.lastTimeAutoSent) > pn.autoSendInterval
-
-	result := make([]*notifyArgs, 0, len(pn.pairs))
+	selected := make([]bool, len(pn.pairs))
+	coalescable := make([]bool, len(pn.pairs))
+	isSending := false
+	allNotifyArgs := make([]*notifyArgs, 0, len(pn.pairs))
 	for idx, pair := range pn.pairs {
+		if !duePairs[idx] {
+			allNotifyArgs = append(allNotifyArgs, nil)
+			continue
+		}
+
 		notifyArgsValue := &notifyArgs{
 			pair:              pair,
 			newPrice:          fetchedPrices[idx],
 			lastNotifiedPrice: pn.lastNotifiedPrices[idx],
 			index:             idx,
 		}
+		allNotifyArgs = append(allNotifyArgs, notifyArgsValue)
 
-		if shouldNotifyAll || shouldNotify(notifyArgsValue) {
-			result = append(result, notifyArgsValue)
-		}
+		timeSinceLastSent := pn.timeSinceHandler(pn.lastTimesSent[idx])
+		selected[idx] = timeSinceLastSent > pair.autoSendInterval || shouldNotify(notifyArgsValue)
+		coalescable[idx] = timeSinceLastSent+pn.coalesceWindow > pair.autoSendInterval
+		isSending = isSending || selected[idx]
+	}
+
+	result := make([]*notifyArgs, 0, len(pn.pairs))
+	if !isSending {
+		return result
 	}
 
-	if shouldNotifyAll {
-		pn.lastTimeAutoSent = time.Now()
+	// the heartbeats due soon ride along with the transaction sent anyway, instead of triggering their own. Only the
+	// pairs polled in this round have a fresh price to send
+	now := pn.timeHandler()
+	for idx, notifyArgsValue := range allNotifyArgs {
+		if selected[idx] || coalescable[idx] {
+			result = append(result, notifyArgsValue)
+			pn.lastTimesSent[idx] = now
+		}
 	}
 
 	return result
 }
 
 func shouldNotify(notifyArgsValue *notifyArgs) bool {
-	percentValue := float64(notifyArgsValue.percentDifferenceToNotify) / 100
-	shouldBypassPercentCheck := notifyArgsValue.lastNotifiedPrice < epsilon || percentValue < epsilon
-	if shouldBypassPercentCheck {
+	if notifyArgsValue.lastNotifiedPrice < epsilon {
 		return true
 	}
 
-	absoluteChange := math.Abs(notifyArgsValue.lastNotifiedPrice - notifyArgsValue.newPrice.price)
-	percentageChange := absoluteChange * 100 / notifyArgsValue.lastNotifiedPrice
+	change := notifyArgsValue.newPrice.price - notifyArgsValue.lastNotifiedPrice
+	absoluteChange := math.Abs(change)
+	// the tolerance absorbs the float errors of a change exactly equal to the threshold
+	if absoluteChange < notifyArgsValue.absoluteChangeToNotify*(1-relativeTolerance) {
+		return false
+	}
 
-	return percentageChange >= float64(notifyArgsValue.percentDifferenceToNotify)
+	thresholdBps := notifyArgsValue.upThresholdBps
+	if change < 0 {
+		thresholdBps = notifyArgsValue.downThresholdBps
+	}
+	if thresholdBps < epsilon {
+		return true
+	}
+
+	changeBps := absoluteChange * basisPointsInOne / notifyArgsValue.lastNotifiedPrice
+
+	return changeBps >= thresholdBps
 }
 
 func (pn *priceNotifier) notify(ctx context.Context, notifyArgsSlice []*notifyArgs) error {
@@ -194,7 +319,7 @@ func (pn *priceNotifier) notify(ctx context.Context, notifyArgsSlice []*notifyAr
 		denominatedPrice := uint64(priceTrimmed * float64(notify.denominationFactor))
 
 		argPriceChanged := &ArgsPriceChanged{
-			Base:             notify.base,
+			Base:             notify.publishedBase(),
 			Quote:            notify.quote,
 			DenominatedPrice: denominatedPrice,
 			Decimals:         notify.decimals,
@@ -211,12 +336,18 @@ func (pn *priceNotifier) notify(ctx context.Context, notifyArgsSlice []*notifyAr
 	return pn.notifee.PriceChanged(ctx, args)
 }
 
-func (pn *priceNotifier) denominateGasPrice(ctx context.Context, fetchedPrices []priceInfo) ([]priceInfo, error) {
+func (pn *priceNotifier) denominateGasPrice(ctx context.Context, fetchedPrices []priceInfo, duePairs []bool) ([]priceInfo, error) {
 	args := make([]gas.ArgsPairInfo, 0, len(pn.pairs))
 	for idx, pair := range pn.pairs {
+		// the gas prices are only fetched for the due gas pairs, the other pairs being reference prices
+		if !duePairs[idx] && pair.isGasFeed() {
+			continue
+		}
+
 		args = append(args, gas.ArgsPairInfo{
 			Base:      pair.base,
 			Quote:     pair.quote,
+			Chain:     pair.chain,
 			Price:     fetchedPrices[idx].price,
 			Timestamp: fetchedPrices[idx].timestamp,
 		})
@@ -232,7 +363,7 @@ func (pn *priceNotifier) denominateGasPrice(ctx context.Context, fetchedPrices [
 
 	for _, gasPrice := range gasPricesInfo {
 		for idx, pair := range pn.pairs {
-			if pair.base != gasPrice.Base || pair.quote != gasPrice.Quote {
+			if !duePairs[idx] || pair.base != gasPrice.Base || pair.quote != gasPrice.Quote || pair.chain != gasPrice.Chain {
 				continue
 			}
 
@@ -68,6 +68,15 @@ func TestNewPriceNotifier(t *testing.T) {
 		assert.True(t, check.IfNil(pn))
 		assert.True(t, errors.Is(err, aggregator.ErrInvalidAutoSendInterval))
 	})
+	t.Run("negative heartbeat coalesce window", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsPriceNotifier()
+		args.HeartbeatCoalesceWindow = -time.Second
+		pn, err := aggregator.NewPriceNotifier(args)
+		assert.True(t, check.IfNil(pn))
+		assert.True(t, errors.Is(err, aggregator.ErrInvalidAutoSendInterval))
+	})
 	t.Run("nil notifee", func(t *testing.T) {
 		t.Parallel()
 
@@ -179,6 +188,59 @@ func TestPriceNotifier_Execute(t *testing.T) {
 		assert.True(t, startTimestamp <= receivedTimestamp)
 		assert.True(t, endTimestamp >= receivedTimestamp)
 	})
+	t.Run("should fetch all the pairs with a single call", func(t *testing.T) {
+		t.Parallel()
+
+		numCalls := 0
+		args := createMockArgsPriceNotifier()
+		args.Pairs = append(args.Pairs, &aggregator.ArgsPair{
+			Base:                      "BASE2",
+			Quote:                     "QUOTE",
+			PercentDifferenceToNotify: 1,
+			Decimals:                  2,
+			Exchanges:                 map[string]struct{}{"Binance": {}},
+		})
+		args.Aggregator = &mock.PriceFetcherStub{
+			FetchPricesCalled: func(ctx context.Context, pairs []aggregator.BaseQuote) map[aggregator.BaseQuote]aggregator.PriceResult {
+				numCalls++
+				assert.Equal(t, []aggregator.BaseQuote{{Base: "BASE", Quote: "QUOTE"}, {Base: "BASE2", Quote: "QUOTE"}}, pairs)
+
+				return map[aggregator.BaseQuote]aggregator.PriceResult{
+					pairs[0]: {Price: 1.5},
+					pairs[1]: {Price: 2.5},
+				}
+			},
+		}
+		var notifiedPrices []uint64
+		args.Notifee = &mock.PriceNotifeeStub{
+			PriceChangedCalled: func(ctx context.Context, args []*aggregator.ArgsPriceChanged) error {
+				for _, arg := range args {
+					notifiedPrices = append(notifiedPrices, arg.DenominatedPrice)
+				}
+				return nil
+			},
+		}
+
+		pn, _ := aggregator.NewPriceNotifier(args)
+		err := pn.Execute(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, 1, numCalls)
+		assert.Equal(t, []uint64{150, 250}, notifiedPrices)
+	})
+	t.Run("missing pair result should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsPriceNotifier()
+		args.Aggregator = &mock.PriceFetcherStub{
+			FetchPricesCalled: func(ctx context.Context, pairs []aggregator.BaseQuote) map[aggregator.BaseQuote]aggregator.PriceResult {
+				return make(map[aggregator.BaseQuote]aggregator.PriceResult)
+			},
+		}
+
+		pn, _ := aggregator.NewPriceNotifier(args)
+		err := pn.Execute(context.Background())
+		assert.True(t, errors.Is(err, aggregator.ErrNotEnoughResponses))
+	})
 	t.Run("double call should notify once", func(t *testing.T) {
 		t.Parallel()
 
@@ -300,11 +362,11 @@ func TestPriceNotifier_Execute(t *testing.T) {
 		require.Nil(t, err)
 		pn.SetLastNotifiedPrices([]float64{1.987654321})
 
-		lastTimeAutoSent := pn.LastTimeAutoSent()
-		assert.True(t, lastTimeAutoSent.Sub(startTime) > 0)
+		lastTimeSent := pn.LastTimesSent()[0]
+		assert.True(t, lastTimeSent.Sub(startTime) > 0)
 
 		pn.SetTimeSinceHandler(func(providedTime time.Time) time.Duration {
-			assert.Equal(t, pn.LastTimeAutoSent(), providedTime)
+			assert.Equal(t, lastTimeSent, providedTime)
 
 			return time.Second * time.Duration(10000)
 		})
@@ -314,7 +376,281 @@ func TestPriceNotifier_Execute(t *testing.T) {
 		err = pn.Execute(context.Background())
 		assert.Nil(t, err)
 		assert.Equal(t, 1, numCalled)
-		assert.True(t, pn.LastTimeAutoSent().Sub(lastTimeAutoSent) > 0)
+		assert.True(t, pn.LastTimesSent()[0].Sub(lastTimeSent) > 0)
+	})
+	t.Run("virtual clock should drive the timestamps and the auto send", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Unix(1700000000, 0)
+		args := createMockArgsPriceNotifier()
+		args.TimeHandler = func() time.Time {
+			return now
+		}
+		args.Aggregator = &mock.PriceFetcherStub{
+			FetchPriceCalled: func(ctx context.Context, base string, quote string) (float64, error) {
+				return 1.987654321, nil
+			},
+		}
+		timestamps := make([]int64, 0)
+		args.Notifee = &mock.PriceNotifeeStub{
+			PriceChangedCalled: func(ctx context.Context, args []*aggregator.ArgsPriceChanged) error {
+				timestamps = append(timestamps, args[0].Timestamp)
+				return nil
+			},
+		}
+
+		pn, err := aggregator.NewPriceNotifier(args)
+		require.Nil(t, err)
+		assert.Equal(t, []time.Time{now}, pn.LastTimesSent())
+
+		err = pn.Execute(context.Background())
+		assert.Nil(t, err)
+
+		now = now.Add(time.Minute)
+		err = pn.Execute(context.Background())
+		assert.Nil(t, err)
+
+		now = now.Add(time.Second)
+		err = pn.Execute(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, []time.Time{now}, pn.LastTimesSent())
+
+		assert.Equal(t, []int64{1700000000, 1700000061}, timestamps)
+	})
+	t.Run("fractional, directional and absolute thresholds", func(t *testing.T) {
+		t.Parallel()
+
+		notifiedPrices := func(argsPair *aggregator.ArgsPair, prices []float64) []uint64 {
+			args := createMockArgsPriceNotifier()
+			args.AutoSendInterval = time.Hour
+			args.Pairs = []*aggregator.ArgsPair{argsPair}
+			idx := 0
+			args.Aggregator = &mock.PriceFetcherStub{
+				FetchPriceCalled: func(ctx context.Context, base string, quote string) (float64, error) {
+					price := prices[idx]
+					idx++
+					return price, nil
+				},
+			}
+			notified := make([]uint64, 0)
+			args.Notifee = &mock.PriceNotifeeStub{
+				PriceChangedCalled: func(ctx context.Context, args []*aggregator.ArgsPriceChanged) error {
+					notified = append(notified, args[0].DenominatedPrice)
+					return nil
+				},
+			}
+
+			pn, err := aggregator.NewPriceNotifier(args)
+			require.Nil(t, err)
+			for range prices {
+				require.Nil(t, pn.Execute(context.Background()))
+			}
+
+			return notified
+		}
+
+		argsPair := createMockArgsPriceNotifier().Pairs[0]
+		argsPair.PercentDifferenceToNotify = 0
+		argsPair.DeviationThresholdBps = 25
+		assert.Equal(t, []uint64{100000, 100300, 100000},
+			notifiedPrices(argsPair, []float64{1000, 1002, 1003, 1001, 1000}))
+
+		argsPair = createMockArgsPriceNotifier().Pairs[0]
+		argsPair.UpThresholdBps = 500
+		argsPair.DownThresholdBps = 50
+		assert.Equal(t, []uint64{100000, 99500, 105000},
+			notifiedPrices(argsPair, []float64{1000, 1040, 995, 1040, 1050}))
+
+		argsPair = createMockArgsPriceNotifier().Pairs[0]
+		argsPair.PercentDifferenceToNotify = 0
+		argsPair.AbsoluteChangeToNotify = 0.05
+		assert.Equal(t, []uint64{10, 15, 10},
+			notifiedPrices(argsPair, []float64{0.1, 0.11, 0.14, 0.15, 0.12, 0.1}))
+	})
+	t.Run("each pair should have its own heartbeat", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Unix(1700000000, 0)
+		args := createMockArgsPriceNotifier()
+		args.TimeHandler = func() time.Time {
+			return now
+		}
+		args.Pairs[0].AutoSendInterval = 24 * time.Hour
+		args.Pairs = append(args.Pairs, &aggregator.ArgsPair{
+			Base:                      "BASE2",
+			Quote:                     "QUOTE",
+			PercentDifferenceToNotify: 1,
+			Decimals:                  2,
+			Exchanges:                 map[string]struct{}{"Binance": {}},
+		})
+		args.Aggregator = &mock.PriceFetcherStub{
+			FetchPriceCalled: func(ctx context.Context, base string, quote string) (float64, error) {
+				return 1.5, nil
+			},
+		}
+		sentBases := make([]string, 0)
+		args.Notifee = &mock.PriceNotifeeStub{
+			PriceChangedCalled: func(ctx context.Context, args []*aggregator.ArgsPriceChanged) error {
+				bases := make([]string, 0, len(args))
+				for _, arg := range args {
+					bases = append(bases, arg.Base)
+				}
+				sentBases = append(sentBases, strings.Join(bases, ","))
+				return nil
+			},
+		}
+
+		pn, err := aggregator.NewPriceNotifier(args)
+		require.Nil(t, err)
+
+		require.Nil(t, pn.Execute(context.Background()))
+		now = now.Add(time.Minute + time.Second)
+		require.Nil(t, pn.Execute(context.Background()))
+		now = now.Add(time.Minute + time.Second)
+		require.Nil(t, pn.Execute(context.Background()))
+		now = now.Add(24 * time.Hour)
+		require.Nil(t, pn.Execute(context.Background()))
+
+		assert.Equal(t, []string{"BASE,BASE2", "BASE2", "BASE2", "BASE,BASE2"}, sentBases)
+	})
+	t.Run("heartbeats due soon should be coalesced with the deviation transaction", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Unix(1700000000, 0)
+		args := createMockArgsPriceNotifier()
+		args.TimeHandler = func() time.Time {
+			return now
+		}
+		args.HeartbeatCoalesceWindow = 30 * time.Second
+		args.Pairs = append(args.Pairs, &aggregator.ArgsPair{
+			Base:                      "BASE2",
+			Quote:                     "QUOTE",
+			PercentDifferenceToNotify: 1,
+			Decimals:                  2,
+			Exchanges:                 map[string]struct{}{"Binance": {}},
+		})
+		basePrice := 1.5
+		args.Aggregator = &mock.PriceFetcherStub{
+			FetchPriceCalled: func(ctx context.Context, base string, quote string) (float64, error) {
+				if base == "BASE" {
+					return basePrice, nil
+				}
+				return 2.5, nil
+			},
+		}
+		sentBases := make([]string, 0)
+		args.Notifee = &mock.PriceNotifeeStub{
+			PriceChangedCalled: func(ctx context.Context, args []*aggregator.ArgsPriceChanged) error {
+				bases := make([]string, 0, len(args))
+				for _, arg := range args {
+					bases = append(bases, arg.Base)
+				}
+				sentBases = append(sentBases, strings.Join(bases, ","))
+				return nil
+			},
+		}
+
+		pn, err := aggregator.NewPriceNotifier(args)
+		require.Nil(t, err)
+
+		require.Nil(t, pn.Execute(context.Background()))
+		// BASE2 is not due yet, its heartbeat would be due in 20 seconds
+		now = now.Add(20 * time.Second)
+		require.Nil(t, pn.Execute(context.Background()))
+		now = now.Add(20 * time.Second)
+		basePrice = 2
+		require.Nil(t, pn.Execute(context.Background()))
+		// both heartbeats were reset by the previous transaction
+		now = now.Add(30 * time.Second)
+		require.Nil(t, pn.Execute(context.Background()))
+
+		assert.Equal(t, []string{"BASE,BASE2", "BASE,BASE2"}, sentBases)
+		assert.Equal(t, []time.Time{now.Add(-30 * time.Second), now.Add(-30 * time.Second)}, pn.LastTimesSent())
+	})
+	t.Run("each pair should be polled at its own interval", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Unix(1700000000, 0)
+		args := createMockArgsPriceNotifier()
+		args.TimeHandler = func() time.Time {
+			return now
+		}
+		args.Pairs[0].PercentDifferenceToNotify = 0
+		args.Pairs = append(args.Pairs, &aggregator.ArgsPair{
+			Base:                      "SLOW",
+			Quote:                     "QUOTE",
+			PercentDifferenceToNotify: 0,
+			Decimals:                  2,
+			Exchanges:                 map[string]struct{}{"Binance": {}},
+			PollInterval:              10 * time.Second,
+		})
+		price := 1.0
+		fetchedBases := make([]string, 0)
+		args.Aggregator = &mock.PriceFetcherStub{
+			FetchPricesCalled: func(ctx context.Context, pairs []aggregator.BaseQuote) map[aggregator.BaseQuote]aggregator.PriceResult {
+				results := make(map[aggregator.BaseQuote]aggregator.PriceResult)
+				bases := make([]string, 0, len(pairs))
+				for _, pair := range pairs {
+					results[pair] = aggregator.PriceResult{Price: price}
+					bases = append(bases, pair.Base)
+				}
+				fetchedBases = append(fetchedBases, strings.Join(bases, ","))
+				return results
+			},
+		}
+		sentBases := make([]string, 0)
+		args.Notifee = &mock.PriceNotifeeStub{
+			PriceChangedCalled: func(ctx context.Context, args []*aggregator.ArgsPriceChanged) error {
+				bases := make([]string, 0, len(args))
+				for _, arg := range args {
+					bases = append(bases, fmt.Sprintf("%s:%d", arg.Base, arg.DenominatedPrice))
+				}
+				sentBases = append(sentBases, strings.Join(bases, ","))
+				return nil
+			},
+		}
+
+		pn, err := aggregator.NewPriceNotifier(args)
+		require.Nil(t, err)
+
+		for i := 0; i < 6; i++ {
+			price++
+			require.Nil(t, pn.Execute(context.Background()))
+			now = now.Add(2 * time.Second)
+		}
+
+		assert.Equal(t, []string{"BASE,SLOW", "BASE", "BASE", "BASE", "BASE", "BASE,SLOW"}, fetchedBases)
+		assert.Equal(t, []string{"BASE:200,SLOW:200", "BASE:300", "BASE:400", "BASE:500", "BASE:600", "BASE:700,SLOW:700"}, sentBases)
+	})
+	t.Run("pairs not due should not trigger a fetch", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Unix(1700000000, 0)
+		args := createMockArgsPriceNotifier()
+		args.TimeHandler = func() time.Time {
+			return now
+		}
+		args.Pairs[0].PollInterval = time.Hour
+		numFetches := 0
+		args.Aggregator = &mock.PriceFetcherStub{
+			FetchPricesCalled: func(ctx context.Context, pairs []aggregator.BaseQuote) map[aggregator.BaseQuote]aggregator.PriceResult {
+				numFetches++
+				return map[aggregator.BaseQuote]aggregator.PriceResult{pairs[0]: {Price: 1.5}}
+			},
+		}
+
+		pn, err := aggregator.NewPriceNotifier(args)
+		require.Nil(t, err)
+
+		require.Nil(t, pn.Execute(context.Background()))
+		now = now.Add(30 * time.Second)
+		require.Nil(t, pn.Execute(context.Background()))
+		assert.Equal(t, 1, numFetches)
+
+		// the heartbeat makes the pair due before its poll interval elapses
+		now = now.Add(31 * time.Second)
+		require.Nil(t, pn.Execute(context.Background()))
+		assert.Equal(t, 2, numFetches)
 	})
 	t.Run("price changed over the limit should notify twice", func(t *testing.T) {
 		t.Parallel()
@@ -475,6 +811,52 @@ func TestPriceNotifier_Execute(t *testing.T) {
 		assert.Equal(t, 1, numCalled)
 	})
 
+	t.Run("gas pairs of several chains should get their own feeds", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsPriceNotifier()
+		args.GasPriceService = &mock.GasPriceServiceStub{
+			ConvertGasPricesCalled: func(ctx context.Context, pairs []gas.ArgsPairInfo) ([]gas.ArgsPairInfo, error) {
+				for i, pair := range pairs {
+					switch pair.Chain {
+					case "":
+						pairs[i].Price = 0.5
+					case "BSC":
+						pairs[i].Price = 0.25
+					}
+				}
+				return pairs, nil
+			},
+		}
+		for _, chain := range []string{"", "BSC"} {
+			args.Pairs = append(args.Pairs, &aggregator.ArgsPair{
+				Base:                      "GWEI",
+				Quote:                     "QUOTE",
+				PercentDifferenceToNotify: 1,
+				Decimals:                  2,
+				Exchanges:                 map[string]struct{}{"Binance": {}},
+				Chain:                     chain,
+			})
+		}
+		var sentArgs []*aggregator.ArgsPriceChanged
+		args.Notifee = &mock.PriceNotifeeStub{
+			PriceChangedCalled: func(ctx context.Context, args []*aggregator.ArgsPriceChanged) error {
+				sentArgs = args
+				return nil
+			},
+		}
+
+		pn, err := aggregator.NewPriceNotifier(args)
+		require.Nil(t, err)
+		require.Nil(t, pn.Execute(context.Background()))
+
+		require.Len(t, sentArgs, 3)
+		assert.Equal(t, "GWEI", sentArgs[1].Base)
+		assert.Equal(t, uint64(50), sentArgs[1].DenominatedPrice)
+		assert.Equal(t, "GWEI-BSC", sentArgs[2].Base)
+		assert.Equal(t, "QUOTE", sentArgs[2].Quote)
+		assert.Equal(t, uint64(25), sentArgs[2].DenominatedPrice)
+	})
 	t.Run("should fail, gas service returns error", func(t *testing.T) {
 		t.Parallel()
 
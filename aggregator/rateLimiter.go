@@ -0,0 +1,163 @@
+package aggregator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const secondsInMinute = 60
+
+// ArgsRateLimiter is the DTO used to create a new rate limiter
+type ArgsRateLimiter struct {
+	// Name identifies the limiter in logs and metrics, usually the name of the exchange
+	Name string
+	// RequestsPerMinute is the rate at which the token bucket is refilled
+	RequestsPerMinute uint64
+	// Burst is the capacity of the token bucket: the number of requests that can be sent at once after an idle period
+	Burst uint64
+}
+
+// rateLimiter delays the requests so they respect a token bucket budget. The http response getter waits on it before
+// each attempt, so the retries are counted in the budget as well
+type rateLimiter struct {
+	name            string
+	tokensPerSecond float64
+	burst           float64
+	timeHandler     func() time.Time
+
+	mut                sync.Mutex
+	tokens             float64
+	lastRefill         time.Time
+	numRequests        uint64
+	numDelayedRequests uint64
+	numCanceledWaits   uint64
+	totalWait          time.Duration
+}
+
+// NewRateLimiter returns a new rate limiter instance
+func NewRateLimiter(args ArgsRateLimiter) (*rateLimiter, error) {
+	err := checkArgsRateLimiter(args)
+	if err != nil {
+		return nil, err
+	}
+
+	limiter := &rateLimiter{
+		name:            args.Name,
+		tokensPerSecond: float64(args.RequestsPerMinute) / secondsInMinute,
+		burst:           float64(args.Burst),
+		timeHandler:     time.Now,
+		tokens:          float64(args.Burst),
+	}
+	limiter.lastRefill = limiter.timeHandler()
+
+	log.Debug("created rate limiter", "name", args.Name, "requests per minute", args.RequestsPerMinute, "burst", args.Burst)
+
+	return limiter, nil
+}
+
+func checkArgsRateLimiter(args ArgsRateLimiter) error {
+	if args.RequestsPerMinute == 0 {
+		return fmt.Errorf("%w for %s, requests per minute: %d", ErrInvalidRateLimit, args.Name, args.RequestsPerMinute)
+	}
+	if args.Burst == 0 {
+		return fmt.Errorf("%w for %s, burst: %d", ErrInvalidRateLimit, args.Name, args.Burst)
+	}
+
+	return nil
+}
+
+// Wait waits until the token bucket allows a new request. The token is given back when the context is done first
+func (limiter *rateLimiter) Wait(ctx context.Context, url string) error {
+	wait := limiter.reserve()
+	if wait <= 0 {
+		return nil
+	}
+
+	log.Debug("rate limiter delaying request", "name", limiter.name, "wait", wait, "url", url)
+
+	timer := time.NewTimer(wait)
+	select {
+	case <-ctx.Done():
+		timer.Stop()
+		limiter.cancelReservation()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve takes a token from the bucket and returns how long the caller should wait for it. The bucket can go below
+// zero, in which case the reservations are served in order as the bucket refills
+func (limiter *rateLimiter) reserve() time.Duration {
+	limiter.mut.Lock()
+	defer limiter.mut.Unlock()
+
+	limiter.refill()
+	limiter.tokens--
+	limiter.numRequests++
+	if limiter.tokens >= 0 {
+		return 0
+	}
+
+	wait := time.Duration(-limiter.tokens / limiter.tokensPerSecond * float64(time.Second))
+	limiter.numDelayedRequests++
+	limiter.totalWait += wait
+
+	return wait
+}
+
+func (limiter *rateLimiter) cancelReservation() {
+	limiter.mut.Lock()
+	defer limiter.mut.Unlock()
+
+	limiter.refill()
+	limiter.tokens++
+	if limiter.tokens > limiter.burst {
+		limiter.tokens = limiter.burst
+	}
+	limiter.numCanceledWaits++
+}
+
+func (limiter *rateLimiter) refill() {
+	now := limiter.timeHandler()
+	elapsed := now.Sub(limiter.lastRefill)
+	limiter.lastRefill = now
+	if elapsed <= 0 {
+		return
+	}
+
+	limiter.tokens += elapsed.Seconds() * limiter.tokensPerSecond
+	if limiter.tokens > limiter.burst {
+		limiter.tokens = limiter.burst
+	}
+}
+
+// Metrics returns the current state of the token bucket and the request counters
+func (limiter *rateLimiter) Metrics() map[string]interface{} {
+	limiter.mut.Lock()
+	defer limiter.mut.Unlock()
+
+	limiter.refill()
+
+	return map[string]interface{}{
+		"available_tokens":     limiter.tokens,
+		"burst":                limiter.burst,
+		"requests_per_minute":  limiter.tokensPerSecond * secondsInMinute,
+		"num_requests":         limiter.numRequests,
+		"num_delayed_requests": limiter.numDelayedRequests,
+		"num_canceled_waits":   limiter.numCanceledWaits,
+		"total_wait_ms":        limiter.totalWait.Milliseconds(),
+	}
+}
+
+// Name returns the name of the limiter
+func (limiter *rateLimiter) Name() string {
+	return limiter.name
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (limiter *rateLimiter) IsInterfaceNil() bool {
+	return limiter == nil
+}
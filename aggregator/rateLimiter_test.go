@@ -0,0 +1,139 @@
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMockArgsRateLimiter() ArgsRateLimiter {
+	return ArgsRateLimiter{
+		Name:              "exchange",
+		RequestsPerMinute: 60,
+		Burst:             2,
+	}
+}
+
+type manualClock struct {
+	mut  sync.Mutex
+	time time.Time
+}
+
+func (clock *manualClock) now() time.Time {
+	clock.mut.Lock()
+	defer clock.mut.Unlock()
+
+	return clock.time
+}
+
+func (clock *manualClock) advance(duration time.Duration) {
+	clock.mut.Lock()
+	clock.time = clock.time.Add(duration)
+	clock.mut.Unlock()
+}
+
+func TestNewRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("zero requests per minute should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsRateLimiter()
+		args.RequestsPerMinute = 0
+		limiter, err := NewRateLimiter(args)
+		assert.True(t, errors.Is(err, ErrInvalidRateLimit))
+		assert.True(t, check.IfNil(limiter))
+	})
+	t.Run("zero burst should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsRateLimiter()
+		args.Burst = 0
+		limiter, err := NewRateLimiter(args)
+		assert.True(t, errors.Is(err, ErrInvalidRateLimit))
+		assert.True(t, check.IfNil(limiter))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		limiter, err := NewRateLimiter(createMockArgsRateLimiter())
+		assert.Nil(t, err)
+		assert.False(t, check.IfNil(limiter))
+		assert.Equal(t, "exchange", limiter.Name())
+	})
+}
+
+func TestRateLimiter_reserve(t *testing.T) {
+	t.Parallel()
+
+	limiter, err := NewRateLimiter(createMockArgsRateLimiter())
+	require.Nil(t, err)
+
+	clock := &manualClock{time: time.Unix(1700000000, 0)}
+	limiter.timeHandler = clock.now
+	limiter.lastRefill = clock.now()
+
+	// the burst is served right away
+	assert.Zero(t, limiter.reserve())
+	assert.Zero(t, limiter.reserve())
+	// then one request per second, the reservations queue up
+	assert.Equal(t, time.Second, limiter.reserve())
+	assert.Equal(t, 2*time.Second, limiter.reserve())
+
+	// the queued reservations are paid back before a new request is served right away
+	clock.advance(3 * time.Second)
+	assert.Zero(t, limiter.reserve())
+
+	clock.advance(time.Minute)
+	metrics := limiter.Metrics()
+	assert.Equal(t, float64(2), metrics["available_tokens"])
+	assert.Equal(t, uint64(5), metrics["num_requests"])
+	assert.Equal(t, uint64(2), metrics["num_delayed_requests"])
+	assert.Equal(t, int64(3000), metrics["total_wait_ms"])
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should delay the requests over budget", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsRateLimiter()
+		args.RequestsPerMinute = 600 // one token each 100ms
+		args.Burst = 1
+		limiter, err := NewRateLimiter(args)
+		require.Nil(t, err)
+
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			require.Nil(t, limiter.Wait(context.Background(), "url"))
+		}
+		assert.GreaterOrEqual(t, time.Since(start), 190*time.Millisecond)
+	})
+	t.Run("context done while waiting should error and give back the token", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsRateLimiter()
+		args.Burst = 1
+		limiter, err := NewRateLimiter(args)
+		require.Nil(t, err)
+
+		require.Nil(t, limiter.Wait(context.Background(), "url"))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		err = limiter.Wait(ctx, "url")
+		assert.Equal(t, context.DeadlineExceeded, err)
+
+		metrics := limiter.Metrics()
+		assert.Equal(t, uint64(1), metrics["num_canceled_waits"])
+		assert.Less(t, metrics["available_tokens"].(float64), float64(0.5))
+		assert.GreaterOrEqual(t, metrics["available_tokens"].(float64), float64(0))
+	})
+}
@@ -0,0 +1,145 @@
+package aggregator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+)
+
+// RecordedResponse is one entry of a cassette: the outcome of a get or a post operation on a URL. The cassettes are
+// written as JSON lines, one entry per line, in the order the responses were received
+type RecordedResponse struct {
+	URL string `json:"url"`
+	// Request is the JSON body of the posted requests, empty for the get operations
+	Request string `json:"request,omitempty"`
+	// StatusCode is 200 for the successful requests and 0 when no response was received
+	StatusCode int `json:"status"`
+	// Body is the full body of the response
+	Body string `json:"body,omitempty"`
+	// Error holds the error message when no response was received
+	Error string `json:"error,omitempty"`
+	// TimestampMs is the unix time in milliseconds when the response was received
+	TimestampMs int64 `json:"timestampMs"`
+}
+
+// ResponseRecorder writes the responses received by the response getters it wraps to a cassette. A single recorder
+// can be shared by several response getters
+type ResponseRecorder struct {
+	mut         sync.Mutex
+	encoder     *json.Encoder
+	timeHandler func() time.Time
+}
+
+// NewResponseRecorder returns a new response recorder writing the cassette entries to the provided writer
+func NewResponseRecorder(writer io.Writer) (*ResponseRecorder, error) {
+	if writer == nil {
+		return nil, ErrNilCassetteWriter
+	}
+
+	return &ResponseRecorder{
+		encoder:     json.NewEncoder(writer),
+		timeHandler: time.Now,
+	}, nil
+}
+
+// Wrap returns a response getter that records every response received by the provided response getter
+func (recorder *ResponseRecorder) Wrap(responseGetter ResponseGetter) (*recordingResponseGetter, error) {
+	if check.IfNilReflect(responseGetter) {
+		return nil, ErrNilResponseGetter
+	}
+
+	return &recordingResponseGetter{
+		responseGetter: responseGetter,
+		recorder:       recorder,
+	}, nil
+}
+
+func (recorder *ResponseRecorder) record(entry *RecordedResponse) {
+	recorder.mut.Lock()
+	defer recorder.mut.Unlock()
+
+	entry.TimestampMs = recorder.timeHandler().UnixMilli()
+	err := recorder.encoder.Encode(entry)
+	if err != nil {
+		log.Warn("failed to record response", "url", entry.URL, "error", err)
+	}
+}
+
+// recordingResponseGetter forwards the requests to the wrapped response getter and records the responses
+type recordingResponseGetter struct {
+	responseGetter ResponseGetter
+	recorder       *ResponseRecorder
+}
+
+// Get forwards the request to the wrapped response getter, records the raw response and decodes it in the
+// response object
+func (getter *recordingResponseGetter) Get(ctx context.Context, url string, response interface{}) error {
+	var body json.RawMessage
+	err := getter.responseGetter.Get(ctx, url, &body)
+	getter.recorder.record(newRecordedResponse(url, body, err))
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, response)
+}
+
+// Post forwards the request to the wrapped response getter, records the raw response along with the request body and
+// decodes it in the response object
+func (getter *recordingResponseGetter) Post(ctx context.Context, url string, request interface{}, response interface{}) error {
+	poster, ok := getter.responseGetter.(ResponsePoster)
+	if !ok {
+		return ErrPostNotSupported
+	}
+
+	requestBody, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	var body json.RawMessage
+	err = poster.Post(ctx, url, json.RawMessage(requestBody), &body)
+	entry := newRecordedResponse(url, body, err)
+	entry.Request = string(requestBody)
+	getter.recorder.record(entry)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, response)
+}
+
+func newRecordedResponse(url string, body json.RawMessage, err error) *RecordedResponse {
+	if err == nil {
+		return &RecordedResponse{
+			URL:        url,
+			StatusCode: http.StatusOK,
+			Body:       string(body),
+		}
+	}
+
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return &RecordedResponse{
+			URL:        url,
+			StatusCode: statusErr.StatusCode,
+			Body:       string(statusErr.Body),
+		}
+	}
+
+	return &RecordedResponse{
+		URL:   url,
+		Error: err.Error(),
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (getter *recordingResponseGetter) IsInterfaceNil() bool {
+	return getter == nil
+}
@@ -0,0 +1,228 @@
+package aggregator_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+	"github.com/klever-io/klv-oracles-go/aggregator/mock"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewResponseRecorder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil writer should error", func(t *testing.T) {
+		t.Parallel()
+
+		recorder, err := aggregator.NewResponseRecorder(nil)
+		assert.Equal(t, aggregator.ErrNilCassetteWriter, err)
+		assert.Nil(t, recorder)
+	})
+	t.Run("nil response getter should error", func(t *testing.T) {
+		t.Parallel()
+
+		recorder, err := aggregator.NewResponseRecorder(&bytes.Buffer{})
+		require.Nil(t, err)
+
+		responseGetter, err := recorder.Wrap(nil)
+		assert.Equal(t, aggregator.ErrNilResponseGetter, err)
+		assert.True(t, check.IfNil(responseGetter))
+	})
+}
+
+func TestRecordingResponseGetter_Get(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/missing" {
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte("not found"))
+			return
+		}
+
+		_, _ = rw.Write([]byte(`{"IntVal":37,"StringVal":"price"}`))
+	}))
+	defer server.Close()
+
+	httpResponseGetter, err := aggregator.NewHttpResponseGetter(createMockArgsHttpResponseGetter())
+	require.Nil(t, err)
+
+	cassette := &bytes.Buffer{}
+	recorder, err := aggregator.NewResponseRecorder(cassette)
+	require.Nil(t, err)
+	responseGetter, err := recorder.Wrap(httpResponseGetter)
+	require.Nil(t, err)
+
+	response := &testStruct{}
+	err = responseGetter.Get(context.Background(), server.URL+"/ticker", response)
+	require.Nil(t, err)
+	assert.Equal(t, &testStruct{IntVal: 37, StringVal: "price"}, response)
+
+	err = responseGetter.Get(context.Background(), server.URL+"/missing", &testStruct{})
+	assert.True(t, errors.Is(err, aggregator.ErrUnexpectedHTTPStatus))
+
+	err = responseGetter.Get(context.Background(), "invalid://url", &testStruct{})
+	require.NotNil(t, err)
+
+	lines := strings.Split(strings.TrimSpace(cassette.String()), "\n")
+	require.Equal(t, 3, len(lines))
+
+	entries := make([]aggregator.RecordedResponse, len(lines))
+	for i, line := range lines {
+		require.Nil(t, json.Unmarshal([]byte(line), &entries[i]))
+		assert.True(t, entries[i].TimestampMs > 0)
+	}
+
+	assert.Equal(t, server.URL+"/ticker", entries[0].URL)
+	assert.Equal(t, http.StatusOK, entries[0].StatusCode)
+	assert.Equal(t, `{"IntVal":37,"StringVal":"price"}`, entries[0].Body)
+	assert.Empty(t, entries[0].Error)
+
+	assert.Equal(t, server.URL+"/missing", entries[1].URL)
+	assert.Equal(t, http.StatusNotFound, entries[1].StatusCode)
+	assert.Equal(t, "not found", entries[1].Body)
+
+	assert.Equal(t, "invalid://url", entries[2].URL)
+	assert.Equal(t, 0, entries[2].StatusCode)
+	assert.Equal(t, err.Error(), entries[2].Error)
+}
+
+func TestRecordingResponseGetter_ShouldReturnInvalidResponsesAsTheyAre(t *testing.T) {
+	t.Parallel()
+
+	recorder, err := aggregator.NewResponseRecorder(&bytes.Buffer{})
+	require.Nil(t, err)
+	responseGetter, err := recorder.Wrap(&mock.HttpResponseGetterStub{
+		GetCalled: func(ctx context.Context, url string, response interface{}) error {
+			return json.Unmarshal([]byte(`{"IntVal":"not a number"}`), response)
+		},
+	})
+	require.Nil(t, err)
+
+	err = responseGetter.Get(context.Background(), "url", &testStruct{})
+	assert.NotNil(t, err)
+}
+
+func TestRecordingResponseGetter_Post(t *testing.T) {
+	t.Parallel()
+
+	t.Run("response getter not able to post should error", func(t *testing.T) {
+		t.Parallel()
+
+		recorder, err := aggregator.NewResponseRecorder(&bytes.Buffer{})
+		require.Nil(t, err)
+		responseGetter, err := recorder.Wrap(&mock.HttpResponseGetterStub{})
+		require.Nil(t, err)
+
+		err = responseGetter.Post(context.Background(), "url", &testStruct{}, &testStruct{})
+		assert.Equal(t, aggregator.ErrPostNotSupported, err)
+	})
+	t.Run("should record the request body along with the response", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			request := &testStruct{}
+			_ = json.NewDecoder(req.Body).Decode(request)
+			if request.IntVal < 0 {
+				rw.WriteHeader(http.StatusBadRequest)
+				_, _ = rw.Write([]byte(`{"error":"negative value"}`))
+				return
+			}
+
+			_ = json.NewEncoder(rw).Encode(&testStruct{IntVal: request.IntVal * 2})
+		}))
+		defer server.Close()
+
+		httpResponseGetter, err := aggregator.NewHttpResponseGetter(createMockArgsHttpResponseGetter())
+		require.Nil(t, err)
+		cassette := &bytes.Buffer{}
+		recorder, err := aggregator.NewResponseRecorder(cassette)
+		require.Nil(t, err)
+		responseGetter, err := recorder.Wrap(httpResponseGetter)
+		require.Nil(t, err)
+
+		response := &testStruct{}
+		err = responseGetter.Post(context.Background(), server.URL, &testStruct{IntVal: 21}, response)
+		require.Nil(t, err)
+		assert.Equal(t, 42, response.IntVal)
+
+		err = responseGetter.Post(context.Background(), server.URL, &testStruct{IntVal: -1}, response)
+		assert.True(t, errors.Is(err, aggregator.ErrUnexpectedHTTPStatus))
+
+		lines := strings.Split(strings.TrimSpace(cassette.String()), "\n")
+		require.Equal(t, 2, len(lines))
+
+		entries := make([]aggregator.RecordedResponse, len(lines))
+		for i, line := range lines {
+			require.Nil(t, json.Unmarshal([]byte(line), &entries[i]))
+		}
+
+		assert.Equal(t, server.URL, entries[0].URL)
+		assert.Equal(t, `{"IntVal":21,"StringVal":""}`, entries[0].Request)
+		assert.Equal(t, http.StatusOK, entries[0].StatusCode)
+		assert.Equal(t, `{"IntVal":42,"StringVal":""}`, strings.TrimSpace(entries[0].Body))
+
+		assert.Equal(t, `{"IntVal":-1,"StringVal":""}`, entries[1].Request)
+		assert.Equal(t, http.StatusBadRequest, entries[1].StatusCode)
+		assert.Equal(t, `{"error":"negative value"}`, entries[1].Body)
+
+		replayGetter, err := aggregator.NewReplayResponseGetter(cassette)
+		require.Nil(t, err)
+		replayed := &testStruct{}
+		err = replayGetter.Post(context.Background(), server.URL, &testStruct{IntVal: -1}, replayed)
+		assert.True(t, errors.Is(err, aggregator.ErrUnexpectedHTTPStatus))
+		err = replayGetter.Post(context.Background(), server.URL, &testStruct{IntVal: 21}, replayed)
+		require.Nil(t, err)
+		assert.Equal(t, 42, replayed.IntVal)
+	})
+}
+
+func TestRecordingAndReplayResponseGetters_ShouldServeTheSameResponses(t *testing.T) {
+	t.Parallel()
+
+	numRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		numRequests++
+		if numRequests == 2 {
+			rw.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		_ = json.NewEncoder(rw).Encode(&testStruct{IntVal: numRequests})
+	}))
+	defer server.Close()
+
+	httpResponseGetter, err := aggregator.NewHttpResponseGetter(createMockArgsHttpResponseGetter())
+	require.Nil(t, err)
+	cassette := &bytes.Buffer{}
+	recorder, err := aggregator.NewResponseRecorder(cassette)
+	require.Nil(t, err)
+	recordingGetter, err := recorder.Wrap(httpResponseGetter)
+	require.Nil(t, err)
+
+	recordedResponses := make([]testStruct, 3)
+	recordedErrors := make([]error, 3)
+	for i := range recordedResponses {
+		recordedErrors[i] = recordingGetter.Get(context.Background(), server.URL, &recordedResponses[i])
+	}
+	server.Close()
+
+	replayGetter, err := aggregator.NewReplayResponseGetter(cassette)
+	require.Nil(t, err)
+	for i := range recordedResponses {
+		response := testStruct{}
+		err = replayGetter.Get(context.Background(), server.URL, &response)
+		assert.Equal(t, recordedResponses[i], response)
+		assert.Equal(t, recordedErrors[i], err)
+	}
+	assert.Equal(t, 0, replayGetter.Remaining())
+}
@@ -0,0 +1,133 @@
+package aggregator
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+const maxCassetteLineSize = 16 * 1024 * 1024
+
+// replayResponseGetter serves the responses of a cassette written by a ResponseRecorder, without any network access.
+// The responses recorded for the same URL, and the same request body for the post operations, are served in the order
+// they were recorded, each of them only once
+type replayResponseGetter struct {
+	mut       sync.Mutex
+	responses map[string][]*RecordedResponse
+	remaining int
+}
+
+// NewReplayResponseGetter returns a new replay response getter serving the cassette read from the provided reader
+func NewReplayResponseGetter(reader io.Reader) (*replayResponseGetter, error) {
+	if reader == nil {
+		return nil, ErrNilCassetteReader
+	}
+
+	getter := &replayResponseGetter{
+		responses: make(map[string][]*RecordedResponse),
+	}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxCassetteLineSize)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		entry := &RecordedResponse{}
+		err := json.Unmarshal(scanner.Bytes(), entry)
+		if err != nil {
+			return nil, fmt.Errorf("%w, line %d: %s", ErrInvalidCassette, lineNumber, err.Error())
+		}
+
+		key := cassetteKey(entry.URL, entry.Request)
+		getter.responses[key] = append(getter.responses[key], entry)
+		getter.remaining++
+	}
+	if scanner.Err() != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCassette, scanner.Err().Error())
+	}
+
+	return getter, nil
+}
+
+// Get serves the next response recorded for the provided URL
+func (getter *replayResponseGetter) Get(_ context.Context, url string, response interface{}) error {
+	return getter.serve(url, "", response)
+}
+
+// Post serves the next response recorded for the provided URL and request. The request is serialized as JSON and has
+// to match the recorded request body
+func (getter *replayResponseGetter) Post(_ context.Context, url string, request interface{}, response interface{}) error {
+	requestBody, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	return getter.serve(url, string(requestBody), response)
+}
+
+func (getter *replayResponseGetter) serve(url string, requestBody string, response interface{}) error {
+	entry, err := getter.next(url, requestBody)
+	if err != nil {
+		return err
+	}
+
+	if len(entry.Error) > 0 {
+		return fmt.Errorf("%w: %s", ErrRecordedRequestFailed, entry.Error)
+	}
+	if entry.StatusCode < http.StatusOK || entry.StatusCode >= http.StatusMultipleChoices {
+		return &HTTPStatusError{
+			URL:         url,
+			StatusCode:  entry.StatusCode,
+			BodySnippet: bodySnippet([]byte(entry.Body)),
+			Body:        []byte(entry.Body),
+		}
+	}
+
+	return json.Unmarshal([]byte(entry.Body), response)
+}
+
+func (getter *replayResponseGetter) next(url string, requestBody string) (*RecordedResponse, error) {
+	getter.mut.Lock()
+	defer getter.mut.Unlock()
+
+	key := cassetteKey(url, requestBody)
+	responses := getter.responses[key]
+	if len(responses) == 0 {
+		if len(requestBody) > 0 {
+			return nil, fmt.Errorf("%w for %s, request: %s", ErrNoRecordedResponse, url, requestBody)
+		}
+		return nil, fmt.Errorf("%w for %s", ErrNoRecordedResponse, url)
+	}
+
+	getter.responses[key] = responses[1:]
+	getter.remaining--
+
+	return responses[0], nil
+}
+
+func cassetteKey(url string, requestBody string) string {
+	if len(requestBody) == 0 {
+		return url
+	}
+
+	return url + " " + requestBody
+}
+
+// Remaining returns the number of recorded responses not served yet
+func (getter *replayResponseGetter) Remaining() int {
+	getter.mut.Lock()
+	defer getter.mut.Unlock()
+
+	return getter.remaining
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (getter *replayResponseGetter) IsInterfaceNil() bool {
+	return getter == nil
+}
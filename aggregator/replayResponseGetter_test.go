@@ -0,0 +1,112 @@
+package aggregator_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCassette = `{"url":"https://exchange/ticker?symbol=ETHUSD","status":200,"body":"{\"IntVal\":1}","timestampMs":1700000000000}
+{"url":"https://exchange/ticker?symbol=BTCUSD","status":200,"body":"{\"IntVal\":100}","timestampMs":1700000000001}
+
+{"url":"https://exchange/ticker?symbol=ETHUSD","status":502,"body":"bad gateway","timestampMs":1700000002000}
+{"url":"https://exchange/ticker?symbol=ETHUSD","error":"dial tcp: connection refused","timestampMs":1700000004000}
+`
+
+func TestNewReplayResponseGetter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil reader should error", func(t *testing.T) {
+		t.Parallel()
+
+		responseGetter, err := aggregator.NewReplayResponseGetter(nil)
+		assert.Equal(t, aggregator.ErrNilCassetteReader, err)
+		assert.Nil(t, responseGetter)
+	})
+	t.Run("invalid entry should error", func(t *testing.T) {
+		t.Parallel()
+
+		responseGetter, err := aggregator.NewReplayResponseGetter(strings.NewReader(testCassette + "not json\n"))
+		assert.True(t, errors.Is(err, aggregator.ErrInvalidCassette))
+		assert.True(t, strings.Contains(err.Error(), "line 6"))
+		assert.Nil(t, responseGetter)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		responseGetter, err := aggregator.NewReplayResponseGetter(strings.NewReader(testCassette))
+		assert.Nil(t, err)
+		assert.False(t, responseGetter.IsInterfaceNil())
+		assert.Equal(t, 4, responseGetter.Remaining())
+	})
+}
+
+func TestReplayResponseGetter_Get(t *testing.T) {
+	t.Parallel()
+
+	responseGetter, err := aggregator.NewReplayResponseGetter(strings.NewReader(testCassette))
+	require.Nil(t, err)
+
+	ethURL := "https://exchange/ticker?symbol=ETHUSD"
+	response := &testStruct{}
+	err = responseGetter.Get(context.Background(), ethURL, response)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, response.IntVal)
+
+	err = responseGetter.Get(context.Background(), ethURL, response)
+	statusErr := &aggregator.HTTPStatusError{}
+	require.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusBadGateway, statusErr.StatusCode)
+	assert.Equal(t, "bad gateway", statusErr.BodySnippet)
+	assert.Equal(t, []byte("bad gateway"), statusErr.Body)
+	assert.Equal(t, ethURL, statusErr.URL)
+
+	err = responseGetter.Get(context.Background(), ethURL, response)
+	assert.True(t, errors.Is(err, aggregator.ErrRecordedRequestFailed))
+	assert.True(t, strings.Contains(err.Error(), "connection refused"))
+
+	err = responseGetter.Get(context.Background(), ethURL, response)
+	assert.True(t, errors.Is(err, aggregator.ErrNoRecordedResponse))
+	assert.Equal(t, 1, responseGetter.Remaining())
+
+	err = responseGetter.Get(context.Background(), "https://exchange/ticker?symbol=BTCUSD", response)
+	assert.Nil(t, err)
+	assert.Equal(t, 100, response.IntVal)
+	assert.Equal(t, 0, responseGetter.Remaining())
+}
+
+func TestReplayResponseGetter_Post(t *testing.T) {
+	t.Parallel()
+
+	rpcCassette := `{"url":"https://node","request":"{\"method\":\"eth_gasPrice\"}","status":200,"body":"{\"IntVal\":20}","timestampMs":1700000000000}
+{"url":"https://node","request":"{\"method\":\"eth_maxPriorityFeePerGas\"}","status":200,"body":"{\"IntVal\":2}","timestampMs":1700000000001}
+{"url":"https://node","status":200,"body":"{\"IntVal\":7}","timestampMs":1700000000002}
+`
+	responseGetter, err := aggregator.NewReplayResponseGetter(strings.NewReader(rpcCassette))
+	require.Nil(t, err)
+
+	// the responses are matched on the request body, not on the recording order
+	response := &testStruct{}
+	err = responseGetter.Post(context.Background(), "https://node", map[string]string{"method": "eth_maxPriorityFeePerGas"}, response)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, response.IntVal)
+
+	err = responseGetter.Post(context.Background(), "https://node", map[string]string{"method": "eth_feeHistory"}, response)
+	assert.True(t, errors.Is(err, aggregator.ErrNoRecordedResponse))
+	assert.True(t, strings.Contains(err.Error(), "eth_feeHistory"))
+
+	err = responseGetter.Post(context.Background(), "https://node", map[string]string{"method": "eth_gasPrice"}, response)
+	assert.Nil(t, err)
+	assert.Equal(t, 20, response.IntVal)
+
+	err = responseGetter.Get(context.Background(), "https://node", response)
+	assert.Nil(t, err)
+	assert.Equal(t, 7, response.IntVal)
+	assert.Equal(t, 0, responseGetter.Remaining())
+}
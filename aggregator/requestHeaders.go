@@ -0,0 +1,28 @@
+package aggregator
+
+import "context"
+
+type requestHeadersKey struct{}
+
+// WithRequestHeaders returns a copy of the provided context that carries the headers to be set on the requests done
+// by the response getters
+func WithRequestHeaders(ctx context.Context, headers map[string]string) context.Context {
+	if len(headers) == 0 {
+		return ctx
+	}
+
+	merged := make(map[string]string)
+	for name, value := range requestHeadersFromContext(ctx) {
+		merged[name] = value
+	}
+	for name, value := range headers {
+		merged[name] = value
+	}
+
+	return context.WithValue(ctx, requestHeadersKey{}, merged)
+}
+
+func requestHeadersFromContext(ctx context.Context) map[string]string {
+	headers, _ := ctx.Value(requestHeadersKey{}).(map[string]string)
+	return headers
+}
@@ -0,0 +1,108 @@
+package simulation
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+	gas "github.com/klever-io/klv-oracles-go/aggregator/gasStation"
+)
+
+// virtualClock is the time source of all the simulated components
+type virtualClock struct {
+	mut sync.RWMutex
+	now time.Time
+}
+
+func (clock *virtualClock) Now() time.Time {
+	clock.mut.RLock()
+	defer clock.mut.RUnlock()
+
+	return clock.now
+}
+
+func (clock *virtualClock) set(now time.Time) {
+	clock.mut.Lock()
+	clock.now = now
+	clock.mut.Unlock()
+}
+
+// onChainPrice is the price the aggregator contract would hold after a simulated transaction
+type onChainPrice struct {
+	price     float64
+	updatedAt time.Time
+}
+
+// simulationNotifee counts the transactions the oracle would have sent and keeps the resulting on-chain prices
+type simulationNotifee struct {
+	baseGasLimit    uint64
+	gasLimitForEach uint64
+	timeHandler     func() time.Time
+
+	numTransactions int
+	numPriceUpdates int
+	totalGasLimit   uint64
+	prices          map[aggregator.BaseQuote]onChainPrice
+	onPriceUpdated  func(pair aggregator.BaseQuote)
+}
+
+// PriceChanged records the transaction that would have been sent
+func (notifee *simulationNotifee) PriceChanged(_ context.Context, priceChanges []*aggregator.ArgsPriceChanged) error {
+	notifee.numTransactions++
+	notifee.numPriceUpdates += len(priceChanges)
+	notifee.totalGasLimit += notifee.baseGasLimit + notifee.gasLimitForEach*uint64(len(priceChanges))
+
+	for _, priceChange := range priceChanges {
+		pair := aggregator.BaseQuote{Base: priceChange.Base, Quote: priceChange.Quote}
+		notifee.onPriceUpdated(pair)
+		notifee.prices[pair] = onChainPrice{
+			price:     float64(priceChange.DenominatedPrice) / math.Pow10(int(priceChange.Decimals)),
+			updatedAt: notifee.timeHandler(),
+		}
+	}
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (notifee *simulationNotifee) IsInterfaceNil() bool {
+	return notifee == nil
+}
+
+// observingAggregator keeps the last prices computed by the wrapped price aggregator
+type observingAggregator struct {
+	aggregator.PriceAggregator
+	lastResults map[aggregator.BaseQuote]aggregator.PriceResult
+}
+
+// FetchPrices forwards the call to the wrapped price aggregator and keeps the results
+func (observer *observingAggregator) FetchPrices(ctx context.Context, pairs []aggregator.BaseQuote) map[aggregator.BaseQuote]aggregator.PriceResult {
+	observer.lastResults = observer.PriceAggregator.FetchPrices(ctx, pairs)
+
+	return observer.lastResults
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (observer *observingAggregator) IsInterfaceNil() bool {
+	return observer == nil
+}
+
+// noGasPriceService leaves the prices unchanged, the simulation does not support gas price pairs
+type noGasPriceService struct{}
+
+// ConvertGasPrices returns the provided pairs
+func (service *noGasPriceService) ConvertGasPrices(_ context.Context, pairs []gas.ArgsPairInfo) ([]gas.ArgsPairInfo, error) {
+	return pairs, nil
+}
+
+// VerifyRequiredPairs returns nil
+func (service *noGasPriceService) VerifyRequiredPairs(_ []gas.ArgsPairInfo) error {
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (service *noGasPriceService) IsInterfaceNil() bool {
+	return service == nil
+}
@@ -0,0 +1,14 @@
+package simulation
+
+import "errors"
+
+var (
+	errInvalidPriceTicks    = errors.New("invalid price ticks")
+	errNoPriceTicks         = errors.New("no price ticks")
+	errNoHistoricalPrice    = errors.New("no historical price")
+	errStaleHistoricalPrice = errors.New("stale historical price")
+	errInvalidPollInterval  = errors.New("invalid poll interval")
+	errGasPairsNotSupported = errors.New("gas price pairs are not supported by the simulation")
+	errNilArgsPair          = errors.New("nil pair argument")
+	errNoSimulatedExchange  = errors.New("no price ticks for the exchanges of the pair")
+)
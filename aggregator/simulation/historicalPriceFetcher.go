@@ -0,0 +1,113 @@
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+)
+
+// historicalPriceFetcher serves the prices one exchange had at the time returned by the virtual clock
+type historicalPriceFetcher struct {
+	name        string
+	timeHandler func() time.Time
+	maxPriceAge time.Duration
+	ticks       map[aggregator.BaseQuote][]PriceTick
+
+	mut   sync.RWMutex
+	pairs map[aggregator.BaseQuote]struct{}
+}
+
+func newHistoricalPriceFetcher(name string, ticks []PriceTick, timeHandler func() time.Time, maxPriceAge time.Duration) *historicalPriceFetcher {
+	fetcher := &historicalPriceFetcher{
+		name:        name,
+		timeHandler: timeHandler,
+		maxPriceAge: maxPriceAge,
+		ticks:       make(map[aggregator.BaseQuote][]PriceTick),
+		pairs:       make(map[aggregator.BaseQuote]struct{}),
+	}
+
+	for _, tick := range ticks {
+		pair := aggregator.BaseQuote{Base: tick.Base, Quote: tick.Quote}
+		fetcher.ticks[pair] = append(fetcher.ticks[pair], tick)
+	}
+	for _, pairTicks := range fetcher.ticks {
+		sort.SliceStable(pairTicks, func(i, j int) bool {
+			return pairTicks[i].Timestamp.Before(pairTicks[j].Timestamp)
+		})
+	}
+
+	return fetcher
+}
+
+// FetchPrice returns the last price recorded for the pair before the current virtual time
+func (fetcher *historicalPriceFetcher) FetchPrice(_ context.Context, base string, quote string) (float64, error) {
+	pair := aggregator.BaseQuote{Base: base, Quote: quote}
+	if !fetcher.hasPair(pair) {
+		return 0, aggregator.ErrPairNotSupported
+	}
+
+	now := fetcher.timeHandler()
+	pairTicks := fetcher.ticks[pair]
+	idx := sort.Search(len(pairTicks), func(i int) bool {
+		return pairTicks[i].Timestamp.After(now)
+	}) - 1
+	if idx < 0 {
+		return 0, fmt.Errorf("%w for %s-%s on %s at %v", errNoHistoricalPrice, base, quote, fetcher.name, now)
+	}
+
+	tick := pairTicks[idx]
+	age := now.Sub(tick.Timestamp)
+	if fetcher.maxPriceAge > 0 && age > fetcher.maxPriceAge {
+		return 0, fmt.Errorf("%w for %s-%s on %s, age %v", errStaleHistoricalPrice, base, quote, fetcher.name, age)
+	}
+
+	return tick.Price, nil
+}
+
+// FetchPrices returns the last prices recorded for the pairs before the current virtual time
+func (fetcher *historicalPriceFetcher) FetchPrices(ctx context.Context, pairs []aggregator.BaseQuote) map[aggregator.BaseQuote]aggregator.PriceResult {
+	results := make(map[aggregator.BaseQuote]aggregator.PriceResult, len(pairs))
+	for _, pair := range pairs {
+		price, err := fetcher.FetchPrice(ctx, pair.Base, pair.Quote)
+		results[pair] = aggregator.PriceResult{
+			Price: price,
+			Err:   err,
+		}
+	}
+
+	return results
+}
+
+func (fetcher *historicalPriceFetcher) hasPair(pair aggregator.BaseQuote) bool {
+	fetcher.mut.RLock()
+	defer fetcher.mut.RUnlock()
+
+	_, found := fetcher.pairs[pair]
+	return found
+}
+
+// AddPair adds the pair to the served pairs
+func (fetcher *historicalPriceFetcher) AddPair(base, quote string) {
+	fetcher.mut.Lock()
+	fetcher.pairs[aggregator.BaseQuote{Base: base, Quote: quote}] = struct{}{}
+	fetcher.mut.Unlock()
+}
+
+// MappedQuote returns the provided quote, the historical prices are already expressed in the pairs' quotes
+func (fetcher *historicalPriceFetcher) MappedQuote(quote string) string {
+	return quote
+}
+
+// Name returns the name of the exchange
+func (fetcher *historicalPriceFetcher) Name() string {
+	return fetcher.name
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (fetcher *historicalPriceFetcher) IsInterfaceNil() bool {
+	return fetcher == nil
+}
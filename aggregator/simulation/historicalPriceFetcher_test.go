@@ -0,0 +1,52 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistoricalPriceFetcher_FetchPrice(t *testing.T) {
+	t.Parallel()
+
+	start := time.Unix(1700000000, 0)
+	now := start
+	ticks := []PriceTick{
+		{Timestamp: start.Add(time.Minute), Exchange: "Binance", Base: "ETH", Quote: "USD", Price: 2001},
+		{Timestamp: start, Exchange: "Binance", Base: "ETH", Quote: "USD", Price: 2000},
+		{Timestamp: start, Exchange: "Binance", Base: "BTC", Quote: "USD", Price: 40000},
+	}
+	fetcher := newHistoricalPriceFetcher("Binance", ticks, func() time.Time {
+		return now
+	}, time.Minute)
+	fetcher.AddPair("ETH", "USD")
+
+	assert.False(t, fetcher.IsInterfaceNil())
+	assert.Equal(t, "Binance", fetcher.Name())
+	assert.Equal(t, "USD", fetcher.MappedQuote("USD"))
+
+	_, err := fetcher.FetchPrice(context.Background(), "BTC", "USD")
+	assert.Equal(t, aggregator.ErrPairNotSupported, err)
+
+	now = start.Add(-time.Second)
+	_, err = fetcher.FetchPrice(context.Background(), "ETH", "USD")
+	assert.True(t, errors.Is(err, errNoHistoricalPrice))
+
+	now = start.Add(59 * time.Second)
+	price, err := fetcher.FetchPrice(context.Background(), "ETH", "USD")
+	assert.Nil(t, err)
+	assert.Equal(t, 2000.0, price)
+
+	now = start.Add(time.Minute)
+	price, err = fetcher.FetchPrice(context.Background(), "ETH", "USD")
+	assert.Nil(t, err)
+	assert.Equal(t, 2001.0, price)
+
+	now = start.Add(2*time.Minute + time.Second)
+	results := fetcher.FetchPrices(context.Background(), []aggregator.BaseQuote{{Base: "ETH", Quote: "USD"}})
+	assert.True(t, errors.Is(results[aggregator.BaseQuote{Base: "ETH", Quote: "USD"}].Err, errStaleHistoricalPrice))
+}
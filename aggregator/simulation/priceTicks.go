@@ -0,0 +1,134 @@
+package simulation
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	timestampColumn = "timestamp"
+	exchangeColumn  = "exchange"
+	baseColumn      = "base"
+	quoteColumn     = "quote"
+	priceColumn     = "price"
+
+	// timestamps above this value are considered to be in milliseconds
+	maxTimestampInSeconds = 100_000_000_000
+)
+
+// PriceTick is a historical price of a pair on one exchange
+type PriceTick struct {
+	Timestamp time.Time
+	Exchange  string
+	Base      string
+	Quote     string
+	Price     float64
+}
+
+// LoadPriceTicks reads the price ticks from a CSV having a header row. The timestamp, base, quote and price columns
+// are required, in any order. The exchange column is optional, defaultExchange is used for the rows without one.
+// The timestamps are unix seconds, unix milliseconds or RFC3339 dates
+func LoadPriceTicks(reader io.Reader, defaultExchange string) ([]PriceTick, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w, header: %s", errInvalidPriceTicks, err.Error())
+	}
+
+	columns := make(map[string]int)
+	for idx, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = idx
+	}
+	for _, required := range []string{timestampColumn, baseColumn, quoteColumn, priceColumn} {
+		if _, found := columns[required]; !found {
+			return nil, fmt.Errorf("%w, missing the %s column", errInvalidPriceTicks, required)
+		}
+	}
+	exchangeIdx, hasExchange := columns[exchangeColumn]
+	if !hasExchange && len(defaultExchange) == 0 {
+		return nil, fmt.Errorf("%w, missing the %s column", errInvalidPriceTicks, exchangeColumn)
+	}
+
+	ticks := make([]PriceTick, 0)
+	for line := 2; ; line++ {
+		record, errRead := csvReader.Read()
+		if errRead == io.EOF {
+			return ticks, nil
+		}
+		if errRead != nil {
+			return nil, fmt.Errorf("%w, line %d: %s", errInvalidPriceTicks, line, errRead.Error())
+		}
+
+		tick := PriceTick{
+			Exchange: defaultExchange,
+			Base:     strings.ToUpper(record[columns[baseColumn]]),
+			Quote:    strings.ToUpper(record[columns[quoteColumn]]),
+		}
+		if hasExchange && len(record[exchangeIdx]) > 0 {
+			tick.Exchange = record[exchangeIdx]
+		}
+
+		tick.Timestamp, err = parseTimestamp(record[columns[timestampColumn]])
+		if err != nil {
+			return nil, fmt.Errorf("%w, line %d: %s", errInvalidPriceTicks, line, err.Error())
+		}
+
+		tick.Price, err = strconv.ParseFloat(record[columns[priceColumn]], 64)
+		if err != nil || tick.Price <= 0 {
+			return nil, fmt.Errorf("%w, line %d: invalid price %s", errInvalidPriceTicks, line, record[columns[priceColumn]])
+		}
+
+		ticks = append(ticks, tick)
+	}
+}
+
+func parseTimestamp(value string) (time.Time, error) {
+	timestamp, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Parse(time.RFC3339, value)
+	}
+
+	if timestamp > maxTimestampInSeconds {
+		return time.UnixMilli(timestamp), nil
+	}
+
+	return time.Unix(timestamp, 0), nil
+}
+
+// LoadPriceTicksFromFiles reads the price ticks from the provided CSV files. The rows without an exchange belong to
+// the exchange named after the file, e.g. the rows of prices/Binance.csv are Binance prices
+func LoadPriceTicksFromFiles(paths []string) ([]PriceTick, error) {
+	ticks := make([]PriceTick, 0)
+	for _, path := range paths {
+		fileTicks, err := loadPriceTicksFromFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w, file %s", err, path)
+		}
+
+		ticks = append(ticks, fileTicks...)
+	}
+
+	return ticks, nil
+}
+
+func loadPriceTicksFromFile(path string) ([]PriceTick, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	exchange := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+	return LoadPriceTicks(file, exchange)
+}
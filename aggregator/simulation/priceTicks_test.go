@@ -0,0 +1,95 @@
+package simulation
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadPriceTicks(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing required column should error", func(t *testing.T) {
+		t.Parallel()
+
+		ticks, err := LoadPriceTicks(strings.NewReader("timestamp,exchange,base,price\n"), "")
+		assert.True(t, errors.Is(err, errInvalidPriceTicks))
+		assert.True(t, strings.Contains(err.Error(), "quote"))
+		assert.Nil(t, ticks)
+	})
+	t.Run("missing exchange should error", func(t *testing.T) {
+		t.Parallel()
+
+		ticks, err := LoadPriceTicks(strings.NewReader("timestamp,base,quote,price\n"), "")
+		assert.True(t, errors.Is(err, errInvalidPriceTicks))
+		assert.True(t, strings.Contains(err.Error(), "exchange"))
+		assert.Nil(t, ticks)
+	})
+	t.Run("invalid price should error", func(t *testing.T) {
+		t.Parallel()
+
+		csv := "timestamp,base,quote,price\n1700000000,ETH,USD,2000\n1700000060,ETH,USD,-1\n"
+		ticks, err := LoadPriceTicks(strings.NewReader(csv), "Binance")
+		assert.True(t, errors.Is(err, errInvalidPriceTicks))
+		assert.True(t, strings.Contains(err.Error(), "line 3"))
+		assert.Nil(t, ticks)
+	})
+	t.Run("invalid timestamp should error", func(t *testing.T) {
+		t.Parallel()
+
+		csv := "timestamp,base,quote,price\nyesterday,ETH,USD,2000\n"
+		ticks, err := LoadPriceTicks(strings.NewReader(csv), "Binance")
+		assert.True(t, errors.Is(err, errInvalidPriceTicks))
+		assert.True(t, strings.Contains(err.Error(), "line 2"))
+		assert.Nil(t, ticks)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		csv := "Price, Quote, Base, Exchange, Timestamp\n" +
+			"2000.5,usd,eth,Kraken,1700000000\n" +
+			"2001,USD,ETH,,1700000060000\n" +
+			"2002,USD,ETH,Kraken,2023-11-14T22:15:20Z\n"
+		ticks, err := LoadPriceTicks(strings.NewReader(csv), "Binance")
+		require.Nil(t, err)
+
+		expectedTicks := []PriceTick{
+			{Timestamp: time.Unix(1700000000, 0), Exchange: "Kraken", Base: "ETH", Quote: "USD", Price: 2000.5},
+			{Timestamp: time.Unix(1700000060, 0), Exchange: "Binance", Base: "ETH", Quote: "USD", Price: 2001},
+			{Timestamp: time.Unix(1700000120, 0), Exchange: "Kraken", Base: "ETH", Quote: "USD", Price: 2002},
+		}
+		require.Equal(t, len(expectedTicks), len(ticks))
+		for i := range expectedTicks {
+			assert.True(t, expectedTicks[i].Timestamp.Equal(ticks[i].Timestamp))
+			ticks[i].Timestamp = expectedTicks[i].Timestamp
+		}
+		assert.Equal(t, expectedTicks, ticks)
+	})
+}
+
+func TestLoadPriceTicksFromFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	binanceFile := filepath.Join(dir, "Binance.csv")
+	require.Nil(t, os.WriteFile(binanceFile, []byte("timestamp,base,quote,price\n1700000000,ETH,USD,2000\n"), 0600))
+	mixedFile := filepath.Join(dir, "mixed.csv")
+	require.Nil(t, os.WriteFile(mixedFile, []byte("timestamp,exchange,base,quote,price\n1700000000,Kraken,ETH,USD,2001\n"), 0600))
+
+	ticks, err := LoadPriceTicksFromFiles([]string{binanceFile, mixedFile})
+	require.Nil(t, err)
+	require.Equal(t, 2, len(ticks))
+	assert.Equal(t, "Binance", ticks[0].Exchange)
+	assert.Equal(t, "Kraken", ticks[1].Exchange)
+
+	ticks, err = LoadPriceTicksFromFiles([]string{binanceFile, filepath.Join(dir, "missing.csv")})
+	assert.NotNil(t, err)
+	assert.True(t, strings.Contains(err.Error(), "missing.csv"))
+	assert.Nil(t, ticks)
+}
@@ -0,0 +1,108 @@
+package simulation
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+)
+
+const deviationPercentile = 95
+
+// Report holds the outcome of a simulation
+type Report struct {
+	Start            time.Time
+	End              time.Time
+	NumRounds        int
+	NumFailedRounds  int
+	LastFailedReason string
+	NumTransactions  int
+	NumPriceUpdates  int
+	TotalGasLimit    uint64
+	EstimatedCost    uint64
+	Pairs            []*PairReport
+}
+
+// PairReport holds the on-chain updates of a pair and the deviation between the on-chain price and the aggregated
+// price, in percent, measured each round after the first update
+type PairReport struct {
+	Base                  string
+	Quote                 string
+	NumUpdates            int
+	NumMeasurements       int
+	MaxDeviation          float64
+	MeanDeviation         float64
+	P95Deviation          float64
+	MaxTimeBetweenUpdates time.Duration
+}
+
+type pairStats struct {
+	numUpdates            int
+	lastUpdate            time.Time
+	maxTimeBetweenUpdates time.Duration
+	deviations            []float64
+}
+
+func (stats *pairStats) createPairReport(pair aggregator.BaseQuote, end time.Time) *PairReport {
+	pairReport := &PairReport{
+		Base:                  pair.Base,
+		Quote:                 pair.Quote,
+		NumUpdates:            stats.numUpdates,
+		NumMeasurements:       len(stats.deviations),
+		MaxTimeBetweenUpdates: maxDuration(stats.maxTimeBetweenUpdates, end.Sub(stats.lastUpdate)),
+	}
+	if len(stats.deviations) == 0 {
+		return pairReport
+	}
+
+	sorted := make([]float64, len(stats.deviations))
+	copy(sorted, stats.deviations)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	for _, deviation := range sorted {
+		sum += deviation
+	}
+
+	pairReport.MaxDeviation = sorted[len(sorted)-1]
+	pairReport.MeanDeviation = sum / float64(len(sorted))
+	pairReport.P95Deviation = sorted[int(math.Ceil(float64(len(sorted))*deviationPercentile/100))-1]
+
+	return pairReport
+}
+
+// Write writes the report as human readable text
+func (report *Report) Write(writer io.Writer) error {
+	tw := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+
+	lines := []string{
+		fmt.Sprintf("period\t%s - %s (%v)", report.Start.UTC().Format(time.RFC3339), report.End.UTC().Format(time.RFC3339),
+			report.End.Sub(report.Start)),
+		fmt.Sprintf("rounds\t%d, %d failed", report.NumRounds, report.NumFailedRounds),
+		fmt.Sprintf("transactions\t%d", report.NumTransactions),
+		fmt.Sprintf("price updates\t%d", report.NumPriceUpdates),
+		fmt.Sprintf("gas limit\t%d", report.TotalGasLimit),
+		fmt.Sprintf("estimated cost\t%d", report.EstimatedCost),
+	}
+	if len(report.LastFailedReason) > 0 {
+		lines = append(lines, fmt.Sprintf("last failure\t%s", report.LastFailedReason))
+	}
+	lines = append(lines, "", "pair\tupdates\tmax gap\tmax deviation %\tmean deviation %\tp95 deviation %")
+	for _, pair := range report.Pairs {
+		lines = append(lines, fmt.Sprintf("%s-%s\t%d\t%v\t%.4f\t%.4f\t%.4f", pair.Base, pair.Quote, pair.NumUpdates,
+			pair.MaxTimeBetweenUpdates, pair.MaxDeviation, pair.MeanDeviation, pair.P95Deviation))
+	}
+
+	for _, line := range lines {
+		_, err := fmt.Fprintln(tw, line)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,266 @@
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+	logger "github.com/multiversx/mx-chain-logger-go"
+)
+
+const gweiTicker = "GWEI"
+
+var log = logger.GetOrCreate("klv-oracle-go/aggregator/simulation")
+
+type executor interface {
+	Execute(ctx context.Context) error
+}
+
+// ArgsSimulator is the argument DTO for the simulator
+type ArgsSimulator struct {
+	PriceTicks       []PriceTick
+	Pairs            []*aggregator.ArgsPair
+	MinResultsNum    int
+	QuoteConversions []aggregator.ArgsQuoteConversion
+	// PollInterval is the virtual time between two rounds of the price notifier
+	PollInterval            time.Duration
+	AutoSendInterval        time.Duration
+	HeartbeatCoalesceWindow time.Duration
+	// MaxPriceAge discards the historical prices older than this at the simulated time. 0 disables the check
+	MaxPriceAge     time.Duration
+	BaseGasLimit    uint64
+	GasLimitForEach uint64
+	// GasPrice is the price of a gas unit, used to estimate the cost of the transactions
+	GasPrice uint64
+}
+
+type simulator struct {
+	args             ArgsSimulator
+	ticksByExchange  map[string][]PriceTick
+	start            time.Time
+	end              time.Time
+	pairs            []aggregator.BaseQuote
+	pairReports      map[aggregator.BaseQuote]*pairStats
+	clock            *virtualClock
+	notifee          *simulationNotifee
+	observer         *observingAggregator
+	numFailedRounds  int
+	numRounds        int
+	lastFailedReason string
+}
+
+// NewSimulator creates a simulator feeding the historical prices through the price aggregator and the price notifier
+func NewSimulator(args ArgsSimulator) (*simulator, error) {
+	err := checkArgsSimulator(args)
+	if err != nil {
+		return nil, err
+	}
+
+	sim := &simulator{
+		args:            args,
+		ticksByExchange: make(map[string][]PriceTick),
+		start:           args.PriceTicks[0].Timestamp,
+		end:             args.PriceTicks[0].Timestamp,
+	}
+	for _, tick := range args.PriceTicks {
+		sim.ticksByExchange[tick.Exchange] = append(sim.ticksByExchange[tick.Exchange], tick)
+		if tick.Timestamp.Before(sim.start) {
+			sim.start = tick.Timestamp
+		}
+		if tick.Timestamp.After(sim.end) {
+			sim.end = tick.Timestamp
+		}
+	}
+
+	for _, argsPair := range args.Pairs {
+		if !sim.hasTicksForExchanges(argsPair.Exchanges) {
+			return nil, fmt.Errorf("%w %s-%s", errNoSimulatedExchange, argsPair.Base, argsPair.Quote)
+		}
+	}
+
+	return sim, nil
+}
+
+func checkArgsSimulator(args ArgsSimulator) error {
+	if len(args.PriceTicks) == 0 {
+		return errNoPriceTicks
+	}
+	if args.PollInterval <= 0 {
+		return fmt.Errorf("%w, %v", errInvalidPollInterval, args.PollInterval)
+	}
+	for idx, argsPair := range args.Pairs {
+		if argsPair == nil {
+			return fmt.Errorf("%w, index %d", errNilArgsPair, idx)
+		}
+		if argsPair.Base == gweiTicker {
+			return fmt.Errorf("%w, %s-%s", errGasPairsNotSupported, argsPair.Base, argsPair.Quote)
+		}
+	}
+
+	return nil
+}
+
+func (sim *simulator) hasTicksForExchanges(exchanges map[string]struct{}) bool {
+	for exchange := range exchanges {
+		if len(sim.ticksByExchange[exchange]) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Run replays the historical prices from the first to the last price tick, one price notifier round every poll
+// interval of virtual time, and reports the transactions that would have been sent and the deviation between the
+// on-chain prices and the aggregated prices
+func (sim *simulator) Run(ctx context.Context) (*Report, error) {
+	priceNotifier, err := sim.createComponents()
+	if err != nil {
+		return nil, err
+	}
+
+	for now := sim.start; !now.After(sim.end); now = now.Add(sim.args.PollInterval) {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		sim.clock.set(now)
+		sim.numRounds++
+		sim.observer.lastResults = nil
+
+		err = priceNotifier.Execute(ctx)
+		if err != nil {
+			sim.numFailedRounds++
+			sim.lastFailedReason = err.Error()
+			log.Debug("simulated round failed", "time", now, "error", err)
+		}
+
+		sim.recordDeviations()
+	}
+
+	return sim.createReport(), nil
+}
+
+func (sim *simulator) createComponents() (executor, error) {
+	sim.clock = &virtualClock{now: sim.start}
+	sim.numRounds = 0
+	sim.numFailedRounds = 0
+	sim.lastFailedReason = ""
+	sim.pairs = make([]aggregator.BaseQuote, 0, len(sim.args.Pairs))
+	sim.pairReports = make(map[aggregator.BaseQuote]*pairStats, len(sim.args.Pairs))
+	for _, argsPair := range sim.args.Pairs {
+		pair := aggregator.BaseQuote{Base: argsPair.Base, Quote: argsPair.Quote}
+		sim.pairs = append(sim.pairs, pair)
+		sim.pairReports[pair] = &pairStats{lastUpdate: sim.start}
+	}
+
+	exchanges := make([]string, 0, len(sim.ticksByExchange))
+	for exchange := range sim.ticksByExchange {
+		exchanges = append(exchanges, exchange)
+	}
+	sort.Strings(exchanges)
+
+	priceFetchers := make([]aggregator.PriceFetcher, 0, len(exchanges))
+	for _, exchange := range exchanges {
+		fetcher := newHistoricalPriceFetcher(exchange, sim.ticksByExchange[exchange], sim.clock.Now, sim.args.MaxPriceAge)
+		for _, argsPair := range sim.args.Pairs {
+			if _, found := argsPair.Exchanges[exchange]; found {
+				fetcher.AddPair(argsPair.Base, argsPair.Quote)
+			}
+		}
+		for _, conversion := range sim.args.QuoteConversions {
+			fetcher.AddPair(conversion.From, conversion.To)
+		}
+		priceFetchers = append(priceFetchers, fetcher)
+	}
+
+	priceAggregator, err := aggregator.NewPriceAggregator(aggregator.ArgsPriceAggregator{
+		PriceFetchers:    priceFetchers,
+		MinResultsNum:    sim.args.MinResultsNum,
+		QuoteConversions: sim.args.QuoteConversions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sim.observer = &observingAggregator{
+		PriceAggregator: priceAggregator,
+	}
+	sim.notifee = &simulationNotifee{
+		baseGasLimit:    sim.args.BaseGasLimit,
+		gasLimitForEach: sim.args.GasLimitForEach,
+		timeHandler:     sim.clock.Now,
+		prices:          make(map[aggregator.BaseQuote]onChainPrice),
+		onPriceUpdated:  sim.recordUpdate,
+	}
+
+	return aggregator.NewPriceNotifier(aggregator.ArgsPriceNotifier{
+		Pairs:                   sim.args.Pairs,
+		Aggregator:              sim.observer,
+		GasPriceService:         &noGasPriceService{},
+		Notifee:                 sim.notifee,
+		AutoSendInterval:        sim.args.AutoSendInterval,
+		HeartbeatCoalesceWindow: sim.args.HeartbeatCoalesceWindow,
+		TimeHandler:             sim.clock.Now,
+	})
+}
+
+func (sim *simulator) recordUpdate(pair aggregator.BaseQuote) {
+	stats := sim.pairReports[pair]
+	now := sim.clock.Now()
+
+	stats.numUpdates++
+	stats.maxTimeBetweenUpdates = maxDuration(stats.maxTimeBetweenUpdates, now.Sub(stats.lastUpdate))
+	stats.lastUpdate = now
+}
+
+// recordDeviations compares the prices the aggregator contract would hold with the prices aggregated in this round.
+// The pairs without an aggregated price, e.g. during an outage, are not compared
+func (sim *simulator) recordDeviations() {
+	for _, pair := range sim.pairs {
+		result, found := sim.observer.lastResults[pair]
+		if !found || result.Err != nil || result.Price <= 0 {
+			continue
+		}
+		onChain, found := sim.notifee.prices[pair]
+		if !found {
+			continue
+		}
+
+		deviation := math.Abs(onChain.price-result.Price) * 100 / result.Price
+		sim.pairReports[pair].deviations = append(sim.pairReports[pair].deviations, deviation)
+	}
+}
+
+func (sim *simulator) createReport() *Report {
+	report := &Report{
+		Start:            sim.start,
+		End:              sim.end,
+		NumRounds:        sim.numRounds,
+		NumFailedRounds:  sim.numFailedRounds,
+		LastFailedReason: sim.lastFailedReason,
+		NumTransactions:  sim.notifee.numTransactions,
+		NumPriceUpdates:  sim.notifee.numPriceUpdates,
+		TotalGasLimit:    sim.notifee.totalGasLimit,
+		EstimatedCost:    sim.notifee.totalGasLimit * sim.args.GasPrice,
+		Pairs:            make([]*PairReport, 0, len(sim.pairs)),
+	}
+
+	for _, pair := range sim.pairs {
+		stats := sim.pairReports[pair]
+		report.Pairs = append(report.Pairs, stats.createPairReport(pair, sim.end))
+	}
+
+	return report
+}
+
+func maxDuration(first time.Duration, second time.Duration) time.Duration {
+	if first > second {
+		return first
+	}
+
+	return second
+}
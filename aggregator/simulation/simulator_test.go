@@ -0,0 +1,198 @@
+package simulation
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var simulationStart = time.Unix(1700000000, 0)
+
+func createPriceTicks(exchange string, prices []float64) []PriceTick {
+	ticks := make([]PriceTick, 0, len(prices))
+	for i, price := range prices {
+		ticks = append(ticks, PriceTick{
+			Timestamp: simulationStart.Add(time.Duration(i) * time.Minute),
+			Exchange:  exchange,
+			Base:      "ETH",
+			Quote:     "USD",
+			Price:     price,
+		})
+	}
+
+	return ticks
+}
+
+func createMockArgsSimulator() ArgsSimulator {
+	prices := []float64{100, 100.5, 101.2, 101.3, 99}
+
+	return ArgsSimulator{
+		PriceTicks: append(createPriceTicks("Binance", prices), createPriceTicks("Kraken", prices)...),
+		Pairs: []*aggregator.ArgsPair{
+			{
+				Base:                      "ETH",
+				Quote:                     "USD",
+				PercentDifferenceToNotify: 1,
+				Decimals:                  2,
+				Exchanges:                 map[string]struct{}{"Binance": {}, "Kraken": {}},
+			},
+		},
+		MinResultsNum:    2,
+		PollInterval:     time.Minute,
+		AutoSendInterval: time.Hour,
+		BaseGasLimit:     100,
+		GasLimitForEach:  10,
+		GasPrice:         2,
+	}
+}
+
+func TestNewSimulator(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no price ticks should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsSimulator()
+		args.PriceTicks = nil
+		sim, err := NewSimulator(args)
+		assert.Equal(t, errNoPriceTicks, err)
+		assert.Nil(t, sim)
+	})
+	t.Run("invalid poll interval should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsSimulator()
+		args.PollInterval = 0
+		sim, err := NewSimulator(args)
+		assert.True(t, errors.Is(err, errInvalidPollInterval))
+		assert.Nil(t, sim)
+	})
+	t.Run("gas price pair should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsSimulator()
+		args.Pairs[0].Base = "GWEI"
+		sim, err := NewSimulator(args)
+		assert.True(t, errors.Is(err, errGasPairsNotSupported))
+		assert.Nil(t, sim)
+	})
+	t.Run("pair without price ticks should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsSimulator()
+		args.Pairs[0].Exchanges = map[string]struct{}{"Bitfinex": {}}
+		sim, err := NewSimulator(args)
+		assert.True(t, errors.Is(err, errNoSimulatedExchange))
+		assert.Nil(t, sim)
+	})
+}
+
+func TestSimulator_Run(t *testing.T) {
+	t.Parallel()
+
+	t.Run("price deviations should trigger the transactions", func(t *testing.T) {
+		t.Parallel()
+
+		sim, err := NewSimulator(createMockArgsSimulator())
+		require.Nil(t, err)
+
+		report, err := sim.Run(context.Background())
+		require.Nil(t, err)
+
+		assert.True(t, simulationStart.Equal(report.Start))
+		assert.True(t, simulationStart.Add(4*time.Minute).Equal(report.End))
+		assert.Equal(t, 5, report.NumRounds)
+		assert.Equal(t, 0, report.NumFailedRounds)
+		// 100 on the first round, 101.2 and 99 crossed the 1% threshold
+		assert.Equal(t, 3, report.NumTransactions)
+		assert.Equal(t, 3, report.NumPriceUpdates)
+		assert.Equal(t, uint64(330), report.TotalGasLimit)
+		assert.Equal(t, uint64(660), report.EstimatedCost)
+
+		require.Equal(t, 1, len(report.Pairs))
+		pairReport := report.Pairs[0]
+		assert.Equal(t, 3, pairReport.NumUpdates)
+		assert.Equal(t, 5, pairReport.NumMeasurements)
+		assert.Equal(t, 2*time.Minute, pairReport.MaxTimeBetweenUpdates)
+		assert.InDelta(t, 0.5/100.5*100, pairReport.MaxDeviation, 1e-9)
+		assert.InDelta(t, 0.5/100.5*100, pairReport.P95Deviation, 1e-9)
+		assert.InDelta(t, (0.5/100.5*100+0.1/101.3*100)/5, pairReport.MeanDeviation, 1e-9)
+
+		// the simulation can be run again with the same outcome
+		secondReport, err := sim.Run(context.Background())
+		require.Nil(t, err)
+		assert.Equal(t, report, secondReport)
+	})
+	t.Run("auto send interval should trigger the transactions", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsSimulator()
+		args.Pairs[0].PercentDifferenceToNotify = 50
+		args.AutoSendInterval = 2 * time.Minute
+		sim, err := NewSimulator(args)
+		require.Nil(t, err)
+
+		report, err := sim.Run(context.Background())
+		require.Nil(t, err)
+		assert.Equal(t, 2, report.NumTransactions)
+		assert.Equal(t, 3*time.Minute, report.Pairs[0].MaxTimeBetweenUpdates)
+		// 101.3 was sent on the fourth round, the last round aggregated 99
+		assert.InDelta(t, 2.3/99*100, report.Pairs[0].MaxDeviation, 1e-9)
+	})
+	t.Run("stale prices should fail the rounds", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsSimulator()
+		args.PriceTicks = append(createPriceTicks("Binance", []float64{100, 100, 100, 100, 100}),
+			createPriceTicks("Kraken", []float64{100, 100})...)
+		args.MaxPriceAge = 90 * time.Second
+		sim, err := NewSimulator(args)
+		require.Nil(t, err)
+
+		report, err := sim.Run(context.Background())
+		require.Nil(t, err)
+		assert.Equal(t, 5, report.NumRounds)
+		assert.Equal(t, 2, report.NumFailedRounds)
+		assert.True(t, strings.Contains(report.LastFailedReason, aggregator.ErrNotEnoughResponses.Error()))
+		assert.Equal(t, 1, report.NumTransactions)
+		assert.Equal(t, 3, report.Pairs[0].NumMeasurements)
+	})
+	t.Run("canceled context should error", func(t *testing.T) {
+		t.Parallel()
+
+		sim, err := NewSimulator(createMockArgsSimulator())
+		require.Nil(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		report, err := sim.Run(ctx)
+		assert.Equal(t, context.Canceled, err)
+		assert.Nil(t, report)
+	})
+}
+
+func TestReport_Write(t *testing.T) {
+	t.Parallel()
+
+	sim, err := NewSimulator(createMockArgsSimulator())
+	require.Nil(t, err)
+	report, err := sim.Run(context.Background())
+	require.Nil(t, err)
+
+	buff := &bytes.Buffer{}
+	err = report.Write(buff)
+	require.Nil(t, err)
+
+	output := buff.String()
+	assert.True(t, strings.Contains(output, "2023-11-14T22:13:20Z - 2023-11-14T22:17:20Z (4m0s)"), output)
+	assert.True(t, strings.Contains(output, "transactions    3"), output)
+	assert.True(t, strings.Contains(output, "estimated cost  660"), output)
+	assert.True(t, strings.Contains(output, "ETH-USD"), output)
+}
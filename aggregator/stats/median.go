@@ -0,0 +1,27 @@
+package stats
+
+import (
+	"errors"
+	"sort"
+)
+
+// ErrInvalidNumOfElementsToComputeMedian signals that an invalid number of elements to compute the median was provided
+var ErrInvalidNumOfElementsToComputeMedian = errors.New("invalid number of elements to compute the median")
+
+// Median returns the median of the values, the mean of the two middle ones for an even number of values. The provided
+// slice is not reordered
+func Median(values []float64) (float64, error) {
+	if len(values) == 0 {
+		return 0, ErrInvalidNumOfElementsToComputeMedian
+	}
+
+	sorted := append(make([]float64, 0, len(values)), values...)
+	sort.Float64s(sorted)
+
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[middle], nil
+	}
+
+	return (sorted[middle-1] + sorted[middle]) / 2, nil
+}
@@ -1,27 +1,27 @@
-package aggregator_test
+package stats_test
 
 import (
 	"testing"
 
-	"github.com/klever-io/klv-oracles-go/aggregator"
+	"github.com/klever-io/klv-oracles-go/aggregator/stats"
 	"github.com/stretchr/testify/assert"
 )
 
-func TestComputeMedian(t *testing.T) {
+func TestMedian(t *testing.T) {
 	t.Parallel()
 
 	t.Run("nil slice should err", func(t *testing.T) {
 		t.Parallel()
 
-		median, err := aggregator.ComputeMedian(nil)
+		median, err := stats.Median(nil)
 		assert.Equal(t, 0.0, median)
-		assert.Equal(t, aggregator.ErrInvalidNumOfElementsToComputeMedian, err)
+		assert.Equal(t, stats.ErrInvalidNumOfElementsToComputeMedian, err)
 	})
 	t.Run("one value should return that value", func(t *testing.T) {
 		t.Parallel()
 
 		prices := []float64{1.0045}
-		median, err := aggregator.ComputeMedian(prices)
+		median, err := stats.Median(prices)
 		assert.Equal(t, 1.0045, median)
 		assert.Nil(t, err)
 	})
@@ -29,7 +29,7 @@ func TestComputeMedian(t *testing.T) {
 		t.Parallel()
 
 		prices := []float64{1.0045, 1.0047}
-		median, err := aggregator.ComputeMedian(prices)
+		median, err := stats.Median(prices)
 		assert.Equal(t, 1.0046, median)
 		assert.Nil(t, err)
 	})
@@ -37,7 +37,7 @@ func TestComputeMedian(t *testing.T) {
 		t.Parallel()
 
 		prices := []float64{1.0045, 1.0047, 1.0049}
-		median, err := aggregator.ComputeMedian(prices)
+		median, err := stats.Median(prices)
 		assert.Equal(t, 1.0047, median)
 		assert.Nil(t, err)
 	})
@@ -45,8 +45,17 @@ func TestComputeMedian(t *testing.T) {
 		t.Parallel()
 
 		prices := []float64{0.0001, 1.0045, 1.0047, 892789.0}
-		median, err := aggregator.ComputeMedian(prices)
+		median, err := stats.Median(prices)
 		assert.Equal(t, 1.0046, median)
 		assert.Nil(t, err)
 	})
+	t.Run("the values should not be reordered", func(t *testing.T) {
+		t.Parallel()
+
+		prices := []float64{3, 1, 2}
+		median, err := stats.Median(prices)
+		assert.Equal(t, 2.0, median)
+		assert.Nil(t, err)
+		assert.Equal(t, []float64{3, 1, 2}, prices)
+	})
 }
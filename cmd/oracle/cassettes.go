@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+)
+
+// replayer serves the recorded responses of a cassette
+type replayer interface {
+	aggregator.ResponseGetter
+	Remaining() int
+}
+
+type executor interface {
+	Execute(ctx context.Context) error
+}
+
+// recordResponses makes the response getters created afterwards record all the responses in the provided cassette
+func (factory *responseGetterFactory) recordResponses(cassettePath string) (io.Closer, error) {
+	file, err := os.Create(cassettePath)
+	if err != nil {
+		return nil, err
+	}
+
+	factory.recorder, err = aggregator.NewResponseRecorder(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	log.Info("recording the responses of the price and gas sources", "cassette", cassettePath)
+
+	return file, nil
+}
+
+// replayResponses makes the factory serve the responses recorded in the provided cassette to all the sources
+func (factory *responseGetterFactory) replayResponses(cassettePath string) (replayer, error) {
+	file, err := os.Open(cassettePath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	replayResponseGetter, err := aggregator.NewReplayResponseGetter(file)
+	if err != nil {
+		return nil, err
+	}
+	factory.replayer = replayResponseGetter
+
+	log.Info("replaying the recorded responses", "cassette", cassettePath,
+		"num responses", replayResponseGetter.Remaining())
+
+	return replayResponseGetter, nil
+}
+
+// logNotifee logs the price changes instead of sending them to the aggregator contract
+type logNotifee struct{}
+
+// PriceChanged logs the price changes
+func (notifee *logNotifee) PriceChanged(_ context.Context, priceChanges []*aggregator.ArgsPriceChanged) error {
+	for _, priceChange := range priceChanges {
+		log.Info("price changed", "base", priceChange.Base, "quote", priceChange.Quote,
+			"denominated price", priceChange.DenominatedPrice, "decimals", priceChange.Decimals,
+			"timestamp", priceChange.Timestamp)
+	}
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (notifee *logNotifee) IsInterfaceNil() bool {
+	return notifee == nil
+}
+
+// replayOracleRun executes the price notifier rounds until all the recorded responses were served
+func replayOracleRun(priceNotifier executor, recordedResponses replayer) {
+	for round := 1; recordedResponses.Remaining() > 0; round++ {
+		remaining := recordedResponses.Remaining()
+		err := priceNotifier.Execute(context.Background())
+		if err != nil {
+			log.Warn("replayed round failed", "round", round, "error", err)
+		}
+
+		if recordedResponses.Remaining() == remaining {
+			log.Warn("the remaining recorded responses were not requested by the configured sources",
+				"num responses", remaining)
+			break
+		}
+	}
+
+	log.Info("replay finished")
+}
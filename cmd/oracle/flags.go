@@ -45,6 +45,19 @@ var (
 		Name:  "log-logger-name",
 		Usage: "Boolean option for logger name in the logs.",
 	}
+	recordResponses = cli.StringFlag{
+		Name: "record-responses",
+		Usage: "The `[path]` of a cassette file where all the responses received from the price and gas sources " +
+			"are recorded, one JSON entry per line. The file is truncated at startup.",
+		Value: "",
+	}
+	replayResponses = cli.StringFlag{
+		Name: "replay-responses",
+		Usage: "The `[path]` of a cassette file written with the record-responses flag. The recorded responses are" +
+			" served instead of querying the sources, the price changes are logged instead of being sent to the" +
+			" blockchain and the oracle stops once all the responses were served.",
+		Value: "",
+	}
 )
 
 func getFlags() []cli.Flag {
@@ -56,6 +69,8 @@ func getFlags() []cli.Flag {
 		logSaveFile,
 		logWithLoggerName,
 		restApiInterface,
+		recordResponses,
+		replayResponses,
 	}
 }
 func getFlagsConfig(ctx *cli.Context) config.ContextFlagsConfig {
@@ -68,6 +83,8 @@ func getFlagsConfig(ctx *cli.Context) config.ContextFlagsConfig {
 	flagsConfig.SaveLogFile = ctx.GlobalBool(logSaveFile.Name)
 	flagsConfig.EnableLogName = ctx.GlobalBool(logWithLoggerName.Name)
 	flagsConfig.RestApiInterface = ctx.GlobalString(restApiInterface.Name)
+	flagsConfig.RecordResponsesFile = ctx.GlobalString(recordResponses.Name)
+	flagsConfig.ReplayResponsesFile = ctx.GlobalString(replayResponses.Name)
 
 	return flagsConfig
 }
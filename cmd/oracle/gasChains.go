@@ -0,0 +1,319 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+	"github.com/klever-io/klv-oracles-go/aggregator/fetchers"
+	gas "github.com/klever-io/klv-oracles-go/aggregator/gasStation"
+	"github.com/klever-io/klv-oracles-go/config"
+)
+
+const (
+	defaultGasStationSelector = "SafeGasPrice"
+	ethTicker                 = "ETH"
+	usdTicker                 = "USD"
+)
+
+// gasPriceService is the gas price service, also reporting the request counters of its gas sources
+type gasPriceService interface {
+	aggregator.GasPriceService
+	Metrics() map[string]interface{}
+}
+
+// gasChainSource holds the native token and the gas sources of a chain
+type gasChainSource struct {
+	nativeToken        string
+	gasStationAPI      string
+	gasPriceNodeURL    string
+	gasRestJSONSources []string
+	gasMinResultsNum   int
+}
+
+// collectGasChains gathers the GasChains entries and checks that the gas station pairs only refer to them
+func collectGasChains(cfg config.PriceNotifierConfig) (map[string]*gasChainSource, error) {
+	chains := make(map[string]*gasChainSource, len(cfg.GasChains))
+	for _, chain := range cfg.GasChains {
+		if len(chain.Chain) == 0 {
+			return nil, fmt.Errorf("missing Chain for a gas chain")
+		}
+		if _, found := chains[chain.Chain]; found {
+			return nil, fmt.Errorf("the gas chain %s is defined twice", chain.Chain)
+		}
+		if len(chain.NativeToken) == 0 {
+			return nil, fmt.Errorf("missing NativeToken for the gas chain %s", chain.Chain)
+		}
+		if len(chain.GasStationAPI) == 0 && len(chain.GasPriceNodeURL) == 0 && len(chain.GasRestJSONSources) == 0 {
+			return nil, fmt.Errorf("missing GasStationAPI, GasPriceNodeURL or GasRestJSONSources for the gas chain %s",
+				chain.Chain)
+		}
+
+		chains[chain.Chain] = &gasChainSource{
+			nativeToken:        chain.NativeToken,
+			gasStationAPI:      chain.GasStationAPI,
+			gasPriceNodeURL:    chain.GasPriceNodeURL,
+			gasRestJSONSources: chain.GasRestJSONSources,
+			gasMinResultsNum:   chain.GasMinResultsNum,
+		}
+	}
+
+	for _, pair := range cfg.GasStationPair {
+		if len(pair.Chain) == 0 {
+			continue
+		}
+		if _, found := chains[pair.Chain]; !found {
+			return nil, fmt.Errorf("unknown gas chain %s for the gas station pair %s-%s, it should be defined in the "+
+				"GasChains", pair.Chain, gasPairBase(pair), pair.Quote)
+		}
+	}
+
+	return chains, nil
+}
+
+// createGasPriceService creates the gas price service and returns the gas sources of the chains, the default chain
+// being the empty one
+func createGasPriceService(
+	cfg config.PriceNotifierConfig,
+	responseGetters *responseGetterFactory,
+	priceAggregator aggregator.PriceAggregator,
+) (gasPriceService, map[string][]aggregator.PriceFetcher, error) {
+	chains, err := collectGasChains(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gasSources := make(map[string][]aggregator.PriceFetcher, len(chains)+1)
+	defaultSource := &gasChainSource{
+		gasStationAPI:      cfg.GeneralConfig.GasStationAPI,
+		gasPriceNodeURL:    cfg.GasPriceNode.NodeURL,
+		gasRestJSONSources: cfg.GeneralConfig.GasRestJSONSources,
+		gasMinResultsNum:   cfg.GeneralConfig.GasMinResultsNum,
+	}
+	gasSources[""], err = createGasSources(cfg, responseGetters, "", defaultSource)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gasServiceArgs := gas.ArgsGasPriceService{
+		PriceAggregator:  priceAggregator,
+		GasPriceFetchers: toGasPriceFetchers(gasSources[""]),
+		MinResultsNum:    gasMinResultsNum(defaultSource),
+		Chains:           make([]gas.ArgsGasChain, 0, len(chains)),
+		Smoothing:        createGasSmoothing(cfg.GasStationPair),
+	}
+	for chain, source := range chains {
+		gasSources[chain], err = createGasSources(cfg, responseGetters, chain, source)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		gasServiceArgs.Chains = append(gasServiceArgs.Chains, gas.ArgsGasChain{
+			Chain:            chain,
+			NativeToken:      source.nativeToken,
+			GasPriceFetchers: toGasPriceFetchers(gasSources[chain]),
+			MinResultsNum:    gasMinResultsNum(source),
+		})
+		log.Info("gas chain", "chain", chain, "native token", source.nativeToken,
+			"num gas sources", len(gasSources[chain]), "min results", gasMinResultsNum(source))
+	}
+	sort.Slice(gasServiceArgs.Chains, func(i, j int) bool {
+		return gasServiceArgs.Chains[i].Chain < gasServiceArgs.Chains[j].Chain
+	})
+
+	gasService, err := gas.NewGasPriceService(gasServiceArgs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return gasService, gasSources, nil
+}
+
+// addGasReferencePairs adds to the price fetchers of the GasReferenceExchanges the token/USD pairs the gas prices are
+// converted with: the native token of each chain and the quotes of the GWEI pairs. The price aggregator requests them
+// when they are not published
+func addGasReferencePairs(cfg config.PriceNotifierConfig, priceFetchers []aggregator.PriceFetcher) error {
+	chains, err := collectGasChains(cfg)
+	if err != nil {
+		return err
+	}
+
+	tokens := make(map[string]struct{})
+	for _, pair := range cfg.GasStationPair {
+		if gasPairBase(pair) != gweiTicker {
+			continue
+		}
+
+		nativeToken := ethTicker
+		if len(pair.Chain) > 0 {
+			nativeToken = chains[pair.Chain].nativeToken
+		}
+		tokens[nativeToken] = struct{}{}
+		tokens[pair.Quote] = struct{}{}
+	}
+	delete(tokens, usdTicker)
+
+	exchanges := getMapFromSlice(cfg.GeneralConfig.GasReferenceExchanges)
+	for token := range tokens {
+		addPairToFetchers(aggregator.ArgsPair{
+			Base:      token,
+			Quote:     usdTicker,
+			Exchanges: exchanges,
+		}, priceFetchers)
+	}
+
+	return nil
+}
+
+// createGasSmoothing returns the smoothing of the gas station pairs having one
+func createGasSmoothing(pairs []config.Pair) []gas.ArgsGasSmoothing {
+	smoothing := make([]gas.ArgsGasSmoothing, 0)
+	for _, pair := range pairs {
+		if len(pair.GasSmoothing) == 0 {
+			continue
+		}
+
+		smoothing = append(smoothing, gas.ArgsGasSmoothing{
+			Chain:      pair.Chain,
+			Base:       gasPairBase(pair),
+			Quote:      pair.Quote,
+			Method:     pair.GasSmoothing,
+			Alpha:      pair.GasSmoothingAlpha,
+			WindowSize: pair.GasSmoothingWindowSize,
+			Percentile: pair.GasSmoothingPercentile,
+		})
+		log.Info("gas smoothing", "chain", pair.Chain, "base", gasPairBase(pair), "quote", pair.Quote,
+			"method", pair.GasSmoothing)
+	}
+
+	return smoothing
+}
+
+// gasPairBase returns the base of a gas station pair: the pairs quoted in GWEI are gas fee feeds, named by their base,
+// the others quote the value of 1 GWEI
+func gasPairBase(pair config.Pair) string {
+	if pair.Quote == gweiTicker {
+		return pair.Base
+	}
+
+	return gweiTicker
+}
+
+// createGasSources creates the gas sources of a chain: the EVM node, the gas station and the REST JSON sources that
+// are set. The chain names the source of the rate limits and of the timeouts, e.g. "EVM JSON-RPC BSC"
+func createGasSources(
+	cfg config.PriceNotifierConfig,
+	responseGetters *responseGetterFactory,
+	chain string,
+	source *gasChainSource,
+) ([]aggregator.PriceFetcher, error) {
+	sources := make([]aggregator.PriceFetcher, 0, len(source.gasRestJSONSources)+2)
+
+	if len(source.gasPriceNodeURL) > 0 {
+		nodeResponseGetter, err := responseGetters.create(gasSourceName(fetchers.EVMJSONRPCName, chain))
+		if err != nil {
+			return nil, err
+		}
+
+		nodeConfig := cfg.GasPriceNode
+		nodeConfig.NodeURL = source.gasPriceNodeURL
+
+		nodeFetcher, err := fetchers.NewPriceFetcher(fetchers.ArgsPriceFetcher{
+			FetcherName:      fetchers.EVMJSONRPCName,
+			ResponseGetter:   nodeResponseGetter,
+			EVMJSONRPCConfig: nodeConfig,
+		})
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, nodeFetcher)
+	}
+
+	// the gas station remains the source of the default chain when no other source is set, even without its URL
+	if len(source.gasStationAPI) > 0 || len(sources) == 0 && len(source.gasRestJSONSources) == 0 {
+		gasStationResponseGetter, err := responseGetters.create(gasSourceName(fetchers.EVMGasPriceStation, chain))
+		if err != nil {
+			return nil, err
+		}
+
+		gasStationFetcher, err := fetchers.NewPriceFetcher(fetchers.ArgsPriceFetcher{
+			FetcherName:    fetchers.EVMGasPriceStation,
+			ResponseGetter: gasStationResponseGetter,
+			EVMGasConfig: fetchers.EVMGasPriceFetcherConfig{
+				ApiURL:   source.gasStationAPI,
+				Selector: defaultGasStationSelector,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, gasStationFetcher)
+	}
+
+	for _, name := range source.gasRestJSONSources {
+		restJSONFetcher, err := createGasRestJSONSource(cfg, responseGetters, chain, name)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, restJSONFetcher)
+	}
+
+	// the gas sources are asked for the GWEI/USD gas price, whatever the quotes of the gas station pairs
+	for _, gasSource := range sources {
+		gasSource.AddPair(gweiTicker, usdTicker)
+	}
+
+	return sources, nil
+}
+
+// createGasRestJSONSource creates a dedicated instance of the named REST JSON fetcher, asked for the GWEI/USD pair
+func createGasRestJSONSource(
+	cfg config.PriceNotifierConfig,
+	responseGetters *responseGetterFactory,
+	chain string,
+	name string,
+) (aggregator.PriceFetcher, error) {
+	for _, restJSONConfig := range cfg.RestJSONFetchers {
+		if restJSONConfig.Name != name {
+			continue
+		}
+
+		responseGetter, err := responseGetters.create(gasSourceName(name, chain))
+		if err != nil {
+			return nil, err
+		}
+
+		return fetchers.NewPriceFetcher(fetchers.ArgsPriceFetcher{
+			FetcherName:    fetchers.RestJSONFetcherName,
+			ResponseGetter: responseGetter,
+			RestJSONConfig: restJSONConfig,
+		})
+	}
+
+	return nil, fmt.Errorf("unknown RestJSONFetchers %q in the gas sources of chain %q", name, chain)
+}
+
+func toGasPriceFetchers(priceFetchers []aggregator.PriceFetcher) []gas.PriceFetcher {
+	gasPriceFetchers := make([]gas.PriceFetcher, 0, len(priceFetchers))
+	for _, priceFetcher := range priceFetchers {
+		gasPriceFetchers = append(gasPriceFetchers, priceFetcher)
+	}
+
+	return gasPriceFetchers
+}
+
+func gasMinResultsNum(source *gasChainSource) int {
+	if source.gasMinResultsNum == 0 {
+		return 1
+	}
+
+	return source.gasMinResultsNum
+}
+
+func gasSourceName(fetcherName string, chain string) string {
+	if len(chain) == 0 {
+		return fetcherName
+	}
+
+	return fmt.Sprintf("%s %s", fetcherName, chain)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gas "github.com/klever-io/klv-oracles-go/aggregator/gasStation"
+	"github.com/klever-io/klv-oracles-go/aggregator/mock"
+	"github.com/klever-io/klv-oracles-go/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateGasPriceService_GasPairQuotedInAnotherToken(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"1","message":"OK","result":{"SafeGasPrice":"30"}}`))
+	}))
+	defer server.Close()
+
+	cfg := config.PriceNotifierConfig{
+		GeneralConfig: config.GeneralNotifierConfig{
+			GasStationAPI: server.URL,
+		},
+		GasStationPair: []config.Pair{
+			{Base: gweiTicker, Quote: "KLV", Decimals: 18},
+		},
+	}
+	responseGetters, err := newResponseGetterFactory(cfg.HTTPClient, nil)
+	require.Nil(t, err)
+
+	priceAggregator := &mock.PriceFetcherStub{
+		FetchPriceCalled: func(ctx context.Context, base string, quote string) (float64, error) {
+			usdPrices := map[string]float64{ethTicker: 2000, "KLV": 0.002}
+			return usdPrices[base], nil
+		},
+	}
+	gasService, gasSources, err := createGasPriceService(cfg, responseGetters, priceAggregator)
+	require.Nil(t, err)
+	require.Len(t, gasSources[""], 1)
+
+	// only the GWEI/KLV pair is added by the price notifier, like in the main function
+	gasSources[""][0].AddPair(gweiTicker, "KLV")
+
+	result, err := gasService.ConvertGasPrices(context.Background(), []gas.ArgsPairInfo{
+		{Base: gweiTicker, Quote: "KLV"},
+	})
+	require.Nil(t, err)
+	// 30 GWEI at 2000 USD per ETH and 0.002 USD per KLV = 30 * 1e-9 * 2000 / 0.002
+	assert.InDelta(t, 0.03, result[0].Price, 1e-12)
+}
@@ -9,16 +9,13 @@ import (
 	"time"
 
 	"github.com/klever-io/klv-bridge-eth-go/clients/klever/blockchain/address"
-	nonceHandler "github.com/klever-io/klv-bridge-eth-go/clients/klever/interactors/nonceHandlerV2"
 	"github.com/klever-io/klv-bridge-eth-go/clients/klever/proxy"
 	"github.com/klever-io/klv-bridge-eth-go/clients/klever/proxy/models"
 	"github.com/klever-io/klv-oracles-go/aggregator"
 	"github.com/klever-io/klv-oracles-go/aggregator/api/gin"
 	"github.com/klever-io/klv-oracles-go/aggregator/fetchers"
-	gas "github.com/klever-io/klv-oracles-go/aggregator/gasStation"
 	"github.com/klever-io/klv-oracles-go/aggregator/notifees"
 	"github.com/klever-io/klv-oracles-go/config"
-	"github.com/klever-io/klv-oracles-go/tools/wallet"
 	chainCore "github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	chainFactory "github.com/multiversx/mx-chain-go/cmd/node/factory"
@@ -32,6 +29,7 @@ import (
 const (
 	defaultLogsPath = "logs"
 	logFilePrefix   = "klv-oracle"
+	gweiTicker      = "GWEI"
 )
 
 var log = logger.GetOrCreate("priceFeeder/main")
@@ -66,6 +64,10 @@ func main() {
 	app.Action = func(c *cli.Context) error {
 		return startOracle(c, app.Version)
 	}
+	app.Commands = []cli.Command{
+		getSimulateCommand(),
+		getWalletCommand(),
+	}
 
 	err := app.Run(os.Args)
 	if err != nil {
@@ -108,128 +110,119 @@ func startOracle(ctx *cli.Context, version string) error {
 		log.Info("read xExchange token IDs mapping", "key", key, "quote", val.Quote, "base", val.Base)
 	}
 
-	if len(cfg.GeneralConfig.NetworkAddress) == 0 {
-		return fmt.Errorf("empty NetworkAddress in config file")
-	}
-
-	argsProxy := proxy.ArgsProxy{
-		ProxyURL:            cfg.GeneralConfig.NetworkAddress,
-		SameScState:         false,
-		ShouldBeSynced:      false,
-		FinalityCheck:       cfg.GeneralConfig.ProxyFinalityCheck,
-		AllowedDeltaToFinal: cfg.GeneralConfig.ProxyMaxNoncesDelta,
-		CacheExpirationTime: time.Second * time.Duration(cfg.GeneralConfig.ProxyCacherExpirationSeconds),
-		EntityType:          models.RestAPIEntityType(cfg.GeneralConfig.ProxyRestAPIEntityType),
-	}
-	proxy, err := proxy.NewProxy(argsProxy)
-	if err != nil {
-		return err
+	for exchange, mappings := range cfg.QuoteMappings {
+		for quote, mappedQuote := range mappings {
+			log.Info("read quote mapping", "exchange", exchange, "quote", quote, "mapped quote", mappedQuote)
+		}
 	}
 
-	args := nonceHandler.ArgsNonceTransactionsHandlerV2{
-		Proxy:            proxy,
-		IntervalToResend: time.Second * time.Duration(cfg.GeneralConfig.IntervalToResendTxsInSeconds),
-	}
-	txNonceHandler, err := nonceHandler.NewNonceTransactionHandlerV2(args)
-	if err != nil {
-		return err
+	if len(flagsConfig.RecordResponsesFile) > 0 && len(flagsConfig.ReplayResponsesFile) > 0 {
+		return fmt.Errorf("the record-responses and replay-responses flags can not be used together")
 	}
 
-	aggregatorAddress, err := address.NewAddress(cfg.GeneralConfig.AggregatorContractAddress)
+	responseGetters, err := newResponseGetterFactory(cfg.HTTPClient, cfg.RateLimits)
 	if err != nil {
 		return err
 	}
 
-	oracleWallet, err := wallet.NewWalletFromPEM(cfg.GeneralConfig.PrivateKeyFile)
-	if err != nil {
-		return err
+	if len(flagsConfig.RecordResponsesFile) > 0 {
+		cassette, errRecord := responseGetters.recordResponses(flagsConfig.RecordResponsesFile)
+		if errRecord != nil {
+			return errRecord
+		}
+		defer func() {
+			_ = cassette.Close()
+		}()
 	}
 
-	httpResponseGetter, err := aggregator.NewHttpResponseGetter()
-	if err != nil {
-		return err
+	var recordedResponses replayer
+	var priceNotifee aggregator.PriceNotifee
+	var kcNotifee keysNotifee
+	var keys *oracleKeys
+	if len(flagsConfig.ReplayResponsesFile) > 0 {
+		recordedResponses, err = responseGetters.replayResponses(flagsConfig.ReplayResponsesFile)
+		if err != nil {
+			return err
+		}
+		priceNotifee = &logNotifee{}
+	} else {
+		kcNotifee, keys, err = createKCNotifee(cfg, responseGetters)
+		if err != nil {
+			return err
+		}
+		defer keys.close()
+		priceNotifee = kcNotifee
 	}
 
-	priceFetchers, err := createPriceFetchers(httpResponseGetter)
+	priceFetchers, err := createPriceFetchers(responseGetters, cfg)
 	if err != nil {
 		return err
 	}
 
 	argsPriceAggregator := aggregator.ArgsPriceAggregator{
-		PriceFetchers: priceFetchers,
-		MinResultsNum: cfg.GeneralConfig.MinResultsNum,
+		PriceFetchers:    priceFetchers,
+		MinResultsNum:    cfg.GeneralConfig.MinResultsNum,
+		QuoteConversions: make([]aggregator.ArgsQuoteConversion, 0, len(cfg.QuoteConversions)),
+	}
+	for _, conversion := range cfg.QuoteConversions {
+		argsPriceAggregator.QuoteConversions = append(argsPriceAggregator.QuoteConversions, aggregator.ArgsQuoteConversion{
+			From:          conversion.From,
+			To:            conversion.To,
+			MinResultsNum: conversion.MinResultsNum,
+		})
+		conversionPair := aggregator.ArgsPair{
+			Base:      conversion.From,
+			Quote:     conversion.To,
+			Exchanges: getMapFromSlice(conversion.Exchanges),
+		}
+		addPairToFetchers(conversionPair, priceFetchers)
 	}
 	priceAggregator, err := aggregator.NewPriceAggregator(argsPriceAggregator)
 	if err != nil {
 		return err
 	}
 
-	argsNotifee := notifees.ArgsKCNotifee{
-		Proxy:           proxy,
-		TxNonceHandler:  txNonceHandler,
-		ContractAddress: aggregatorAddress,
-		Wallet:          oracleWallet,
-		BaseGasLimit:    cfg.GeneralConfig.BaseGasLimit,
-		GasLimitForEach: cfg.GeneralConfig.GasLimitForEach,
-	}
-	kcNotifee, err := notifees.NewKCNotifee(argsNotifee)
-	if err != nil {
-		return err
-	}
-
-	gasStationFetcherArgs := fetchers.ArgsPriceFetcher{
-		FetcherName:    fetchers.EVMGasPriceStation,
-		ResponseGetter: httpResponseGetter,
-		EVMGasConfig: fetchers.EVMGasPriceFetcherConfig{
-			ApiURL:   cfg.GeneralConfig.GasStationAPI,
-			Selector: "SafeGasPrice",
-		},
-	}
-
-	gasPriceFetcher, err := fetchers.NewPriceFetcher(gasStationFetcherArgs)
-	if err != nil {
-		return err
-	}
-
-	gasServiceArgs := gas.ArgsGasPriceService{
-		GasPriceFetcher: gasPriceFetcher,
-	}
-
-	gasService, err := gas.NewGasPriceService(gasServiceArgs)
+	gasService, gasSources, err := createGasPriceService(cfg, responseGetters, priceAggregator)
 	if err != nil {
 		return err
 	}
 
 	argsPriceNotifier := aggregator.ArgsPriceNotifier{
-		Pairs:            []*aggregator.ArgsPair{},
-		Aggregator:       priceAggregator,
-		GasPriceService:  gasService,
-		Notifee:          kcNotifee,
-		AutoSendInterval: time.Second * time.Duration(cfg.GeneralConfig.AutoSendIntervalInSeconds),
+		Pairs:                   []*aggregator.ArgsPair{},
+		Aggregator:              priceAggregator,
+		GasPriceService:         gasService,
+		Notifee:                 priceNotifee,
+		AutoSendInterval:        time.Second * time.Duration(cfg.GeneralConfig.AutoSendIntervalInSeconds),
+		HeartbeatCoalesceWindow: time.Second * time.Duration(cfg.GeneralConfig.HeartbeatCoalesceWindowInSeconds),
 	}
 	for _, pair := range cfg.Pairs {
-		argsPair := aggregator.ArgsPair{
-			Base:                      pair.Base,
-			Quote:                     pair.Quote,
-			PercentDifferenceToNotify: pair.PercentDifferenceToNotify,
-			Decimals:                  pair.Decimals,
-			Exchanges:                 getMapFromSlice(pair.Exchanges),
+		argsPair, errPair := createArgsPair(pair)
+		if errPair != nil {
+			return errPair
 		}
-		addPairToFetchers(argsPair, priceFetchers)
-		argsPriceNotifier.Pairs = append(argsPriceNotifier.Pairs, &argsPair)
+		addPairToFetchers(*argsPair, priceFetchers)
+		argsPriceNotifier.Pairs = append(argsPriceNotifier.Pairs, argsPair)
 	}
 
 	for _, pair := range cfg.GasStationPair {
-		gasArgsPair := aggregator.ArgsPair{
-			Base:                      "GWEI",
-			Quote:                     pair.Quote,
-			PercentDifferenceToNotify: pair.PercentDifferenceToNotify,
-			Decimals:                  pair.Decimals,
-			Exchanges:                 getMapFromSlice(pair.Exchanges),
+		gasArgsPair, errPair := createArgsPair(pair)
+		if errPair != nil {
+			return errPair
 		}
+		gasArgsPair.Base = gasPairBase(pair)
 
-		gasPriceFetcher.AddPair(gasArgsPair.Base, gasArgsPair.Quote)
-		argsPriceNotifier.Pairs = append(argsPriceNotifier.Pairs, &gasArgsPair)
+		for _, gasSource := range gasSources[pair.Chain] {
+			gasSource.AddPair(gasArgsPair.Base, gasArgsPair.Quote)
+		}
+		argsPriceNotifier.Pairs = append(argsPriceNotifier.Pairs, gasArgsPair)
+	}
+	err = addGasReferencePairs(cfg, priceFetchers)
+	if err != nil {
+		return err
+	}
+
+	for _, unknownMapping := range fetchers.CheckSymbolMappings(cfg.SymbolMappings, priceFetchers) {
+		log.Warn("unknown symbol mapping", "mapping", unknownMapping)
 	}
 
 	priceNotifier, err := aggregator.NewPriceNotifier(argsPriceNotifier)
@@ -237,6 +230,11 @@ func startOracle(ctx *cli.Context, version string) error {
 		return err
 	}
 
+	if recordedResponses != nil {
+		replayOracleRun(priceNotifier, recordedResponses)
+		return nil
+	}
+
 	argsPollingHandler := polling.ArgsPollingHandler{
 		Log:              log,
 		Name:             "price notifier polling handler",
@@ -255,6 +253,22 @@ func startOracle(ctx *cli.Context, version string) error {
 		return err
 	}
 
+	for _, source := range responseGetters.unusedRateLimits() {
+		log.Warn("rate limit configured for an unknown price source", "source", source)
+	}
+	err = responseGetters.addRateLimitersMetrics(httpServerWrapper)
+	if err != nil {
+		return err
+	}
+	err = httpServerWrapper.AddMetricsProvider("gas price service", gasService)
+	if err != nil {
+		return err
+	}
+	err = httpServerWrapper.AddMetricsProvider("oracle keys", keys)
+	if err != nil {
+		return err
+	}
+
 	err = httpServerWrapper.StartHttpServer()
 	if err != nil {
 		return err
@@ -268,9 +282,11 @@ func startOracle(ctx *cli.Context, version string) error {
 	}
 
 	sigs := make(chan os.Signal, 1)
-	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
 
-	<-sigs
+	for sig := <-sigs; sig == syscall.SIGHUP; sig = <-sigs {
+		reloadOracleKeys(flagsConfig.ConfigurationFile, keys, kcNotifee)
+	}
 
 	log.Info("application closing, closing polling handler...")
 
@@ -278,6 +294,90 @@ func startOracle(ctx *cli.Context, version string) error {
 	return err
 }
 
+// keysNotifee is the notifee whose oracle keys can be replaced while running
+type keysNotifee interface {
+	aggregator.PriceNotifee
+	SetKeys(keys []notifees.OracleKey) error
+}
+
+// createKCNotifee creates the notifee sending the price changes to the aggregator contract, spreading them over the
+// oracle keys
+func createKCNotifee(cfg config.PriceNotifierConfig, responseGetters *responseGetterFactory) (keysNotifee, *oracleKeys, error) {
+	if len(cfg.GeneralConfig.NetworkAddress) == 0 {
+		return nil, nil, fmt.Errorf("empty NetworkAddress in config file")
+	}
+
+	argsProxy := proxy.ArgsProxy{
+		ProxyURL:            cfg.GeneralConfig.NetworkAddress,
+		SameScState:         false,
+		ShouldBeSynced:      false,
+		FinalityCheck:       cfg.GeneralConfig.ProxyFinalityCheck,
+		AllowedDeltaToFinal: cfg.GeneralConfig.ProxyMaxNoncesDelta,
+		CacheExpirationTime: time.Second * time.Duration(cfg.GeneralConfig.ProxyCacherExpirationSeconds),
+		EntityType:          models.RestAPIEntityType(cfg.GeneralConfig.ProxyRestAPIEntityType),
+	}
+	proxy, err := proxy.NewProxy(argsProxy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signingPolicy, err := newSigningPolicyWrapper(cfg, responseGetters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keys := newOracleKeys(cfg, proxy, signingPolicy)
+	loadedKeys, err := keys.load(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	aggregatorAddress, err := address.NewAddress(cfg.GeneralConfig.AggregatorContractAddress)
+	if err != nil {
+		keys.close()
+		return nil, nil, err
+	}
+
+	argsNotifee := notifees.ArgsKCNotifee{
+		Proxy:           proxy,
+		Keys:            loadedKeys,
+		KeySelection:    notifees.KeySelection(cfg.OracleKeys.Selection),
+		ContractAddress: aggregatorAddress,
+		BaseGasLimit:    cfg.GeneralConfig.BaseGasLimit,
+		GasLimitForEach: cfg.GeneralConfig.GasLimitForEach,
+	}
+	kcNotifee, err := notifees.NewKCNotifee(argsNotifee)
+	if err != nil {
+		keys.close()
+		return nil, nil, err
+	}
+	log.Info("oracle keys", "num keys", len(loadedKeys), "selection", cfg.OracleKeys.Selection)
+
+	return kcNotifee, keys, nil
+}
+
+// reloadOracleKeys reloads the oracle keys from the configuration file, keeping the current keys on errors
+func reloadOracleKeys(configurationFile string, keys *oracleKeys, notifee keysNotifee) {
+	log.Info("reloading the oracle keys", "file", configurationFile)
+
+	cfg, err := loadConfig(configurationFile)
+	if err != nil {
+		log.Error("can not reload the oracle keys", "error", err)
+		return
+	}
+
+	loadedKeys, err := keys.load(cfg)
+	if err != nil {
+		log.Error("can not reload the oracle keys", "error", err)
+		return
+	}
+
+	err = notifee.SetKeys(loadedKeys)
+	if err != nil {
+		log.Error("can not set the reloaded oracle keys", "error", err)
+	}
+}
+
 func loadConfig(filepath string) (config.PriceNotifierConfig, error) {
 	cfg := config.PriceNotifierConfig{}
 	err := chainCore.LoadTomlFile(&cfg, filepath)
@@ -285,30 +385,189 @@ func loadConfig(filepath string) (config.PriceNotifierConfig, error) {
 		return config.PriceNotifierConfig{}, err
 	}
 
+	err = renameExchanges(&cfg)
+	if err != nil {
+		return config.PriceNotifierConfig{}, err
+	}
+
+	err = fetchers.CheckRestJSONFetcherConfigs(cfg.RestJSONFetchers)
+	if err != nil {
+		return config.PriceNotifierConfig{}, err
+	}
+
+	err = checkPollIntervals(cfg)
+	if err != nil {
+		return config.PriceNotifierConfig{}, err
+	}
+
+	_, err = collectGasChains(cfg)
+	if err != nil {
+		return config.PriceNotifierConfig{}, err
+	}
+
 	return cfg, nil
 }
 
-func createPriceFetchers(httpReponseGetter aggregator.ResponseGetter) ([]aggregator.PriceFetcher, error) {
+// checkPollIntervals rejects the pair poll intervals the scheduler tick can not honor. The pairs are only fetched on
+// the ticks, every GeneralConfig.PollIntervalInSeconds, so their poll interval has to be a multiple of the tick
+func checkPollIntervals(cfg config.PriceNotifierConfig) error {
+	tick := cfg.GeneralConfig.PollIntervalInSeconds
+	pairs := append(append([]config.Pair{}, cfg.Pairs...), cfg.GasStationPair...)
+	for _, pair := range pairs {
+		if pair.PollIntervalInSeconds == 0 {
+			continue
+		}
+		if tick == 0 || pair.PollIntervalInSeconds%tick != 0 {
+			return fmt.Errorf("the poll interval of %d seconds of the pair %s-%s is not a multiple of the general "+
+				"poll interval of %d seconds", pair.PollIntervalInSeconds, pair.Base, pair.Quote, tick)
+		}
+	}
+
+	return nil
+}
+
+// renameExchanges replaces the former names of the renamed exchanges with their current names, so older config
+// files keep working. A section holding an exchange under both its former and current names is rejected
+func renameExchanges(cfg *config.PriceNotifierConfig) error {
+	for i := range cfg.Pairs {
+		cfg.Pairs[i].Exchanges = renameExchangesInSlice(cfg.Pairs[i].Exchanges)
+	}
+	for i := range cfg.GasStationPair {
+		cfg.GasStationPair[i].Exchanges = renameExchangesInSlice(cfg.GasStationPair[i].Exchanges)
+	}
+	for i := range cfg.QuoteConversions {
+		cfg.QuoteConversions[i].Exchanges = renameExchangesInSlice(cfg.QuoteConversions[i].Exchanges)
+	}
+	cfg.SigningPolicy.ReferenceExchanges = renameExchangesInSlice(cfg.SigningPolicy.ReferenceExchanges)
+	cfg.GeneralConfig.GasReferenceExchanges = renameExchangesInSlice(cfg.GeneralConfig.GasReferenceExchanges)
+
+	err := renameExchangesInMap(cfg.QuoteMappings, "quote mappings")
+	if err != nil {
+		return err
+	}
+	err = renameExchangesInMap(cfg.SymbolMappings, "symbol mappings")
+	if err != nil {
+		return err
+	}
+	err = renameExchangesInMap(cfg.RateLimits, "rate limits")
+	if err != nil {
+		return err
+	}
+	err = renameExchangesInMap(cfg.HTTPClient.SourceTimeoutsInMilliseconds, "source timeouts")
+	if err != nil {
+		return err
+	}
+
+	return renameExchangesInMap(cfg.HTTPClient.SourceIPs, "source IPs")
+}
+
+func renameExchangesInMap[T any](exchangesMap map[string]T, section string) error {
+	for exchange := range exchangesMap {
+		currentName := fetchers.CurrentExchangeName(exchange)
+		if currentName == exchange {
+			continue
+		}
+		if _, found := exchangesMap[currentName]; found {
+			return fmt.Errorf("the %s hold both %s and its former name %s, only one of them should be kept",
+				section, currentName, exchange)
+		}
+	}
+
+	for exchange, value := range exchangesMap {
+		currentName := fetchers.CurrentExchangeName(exchange)
+		if currentName != exchange {
+			log.Warn("renamed exchange in "+section, "former name", exchange, "current name", currentName)
+			delete(exchangesMap, exchange)
+			exchangesMap[currentName] = value
+		}
+	}
+
+	return nil
+}
+
+func renameExchangesInSlice(exchanges []string) []string {
+	renamed := make([]string, 0, len(exchanges))
+	for _, exchange := range exchanges {
+		currentName := fetchers.CurrentExchangeName(exchange)
+		if currentName != exchange {
+			log.Warn("renamed exchange", "former name", exchange, "current name", currentName)
+		}
+		renamed = append(renamed, currentName)
+	}
+
+	return renamed
+}
+
+func createPriceFetchers(
+	responseGetters *responseGetterFactory,
+	cfg config.PriceNotifierConfig,
+) ([]aggregator.PriceFetcher, error) {
 	exchanges := fetchers.ImplementedFetchers
-	priceFetchers := make([]aggregator.PriceFetcher, 0, len(exchanges))
+	priceFetchers := make([]aggregator.PriceFetcher, 0, len(exchanges)+len(cfg.RestJSONFetchers))
 
 	for exchangeName := range exchanges {
-		args := fetchers.ArgsPriceFetcher{
-			FetcherName:    exchangeName,
-			ResponseGetter: httpReponseGetter,
+		priceFetcher, err := createExchangePriceFetcher(responseGetters, cfg, exchangeName)
+		if err != nil {
+			return nil, err
 		}
 
-		priceFetcher, err := fetchers.NewPriceFetcher(args)
+		priceFetchers = append(priceFetchers, priceFetcher)
+	}
+
+	for _, restJSONConfig := range cfg.RestJSONFetchers {
+		priceFetcher, err := createRestJSONPriceFetcher(responseGetters, cfg, restJSONConfig)
 		if err != nil {
 			return nil, err
 		}
 
+		log.Info("created REST JSON price fetcher", "name", restJSONConfig.Name, "URL template", restJSONConfig.URLTemplate)
 		priceFetchers = append(priceFetchers, priceFetcher)
 	}
 
 	return priceFetchers, nil
 }
 
+func createExchangePriceFetcher(
+	responseGetters *responseGetterFactory,
+	cfg config.PriceNotifierConfig,
+	exchangeName string,
+) (aggregator.PriceFetcher, error) {
+	responseGetter, err := responseGetters.create(exchangeName)
+	if err != nil {
+		return nil, err
+	}
+
+	args := fetchers.ArgsPriceFetcher{
+		FetcherName:    exchangeName,
+		ResponseGetter: responseGetter,
+		QuoteMappings:  cfg.QuoteMappings[exchangeName],
+		SymbolMapping:  cfg.SymbolMappings[exchangeName],
+	}
+
+	return fetchers.NewPriceFetcher(args)
+}
+
+func createRestJSONPriceFetcher(
+	responseGetters *responseGetterFactory,
+	cfg config.PriceNotifierConfig,
+	restJSONConfig fetchers.RestJSONFetcherConfig,
+) (aggregator.PriceFetcher, error) {
+	responseGetter, err := responseGetters.create(restJSONConfig.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	args := fetchers.ArgsPriceFetcher{
+		FetcherName:    fetchers.RestJSONFetcherName,
+		ResponseGetter: responseGetter,
+		QuoteMappings:  cfg.QuoteMappings[restJSONConfig.Name],
+		SymbolMapping:  cfg.SymbolMappings[restJSONConfig.Name],
+		RestJSONConfig: restJSONConfig,
+	}
+
+	return fetchers.NewPriceFetcher(args)
+}
+
 func addPairToFetchers(argsPair aggregator.ArgsPair, priceFetchers []aggregator.PriceFetcher) {
 	for _, fetcher := range priceFetchers {
 		_, ok := argsPair.Exchanges[fetcher.Name()]
@@ -318,6 +577,36 @@ func addPairToFetchers(argsPair aggregator.ArgsPair, priceFetchers []aggregator.
 	}
 }
 
+func createArgsPair(pair config.Pair) (*aggregator.ArgsPair, error) {
+	argsPair := &aggregator.ArgsPair{
+		Base:                      pair.Base,
+		Quote:                     pair.Quote,
+		PercentDifferenceToNotify: pair.PercentDifferenceToNotify,
+		Decimals:                  pair.Decimals,
+		Exchanges:                 getMapFromSlice(pair.Exchanges),
+		AbsoluteChangeToNotify:    pair.AbsoluteChangeToNotify,
+		AutoSendInterval:          time.Second * time.Duration(pair.AutoSendIntervalInSeconds),
+		PollInterval:              time.Second * time.Duration(pair.PollIntervalInSeconds),
+		Chain:                     pair.Chain,
+	}
+
+	var err error
+	argsPair.DeviationThresholdBps, err = aggregator.ParseDeviationThreshold(pair.DeviationThreshold)
+	if err != nil {
+		return nil, fmt.Errorf("%w for pair %s-%s", err, pair.Base, pair.Quote)
+	}
+	argsPair.UpThresholdBps, err = aggregator.ParseDeviationThreshold(pair.UpDeviationThreshold)
+	if err != nil {
+		return nil, fmt.Errorf("%w for pair %s-%s", err, pair.Base, pair.Quote)
+	}
+	argsPair.DownThresholdBps, err = aggregator.ParseDeviationThreshold(pair.DownDeviationThreshold)
+	if err != nil {
+		return nil, fmt.Errorf("%w for pair %s-%s", err, pair.Base, pair.Quote)
+	}
+
+	return argsPair, nil
+}
+
 func getMapFromSlice(exchangesSlice []string) map[string]struct{} {
 	exchangesMap := make(map[string]struct{})
 	for _, exchange := range exchangesSlice {
@@ -0,0 +1,337 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	nonceHandler "github.com/klever-io/klv-bridge-eth-go/clients/klever/interactors/nonceHandlerV2"
+	"github.com/klever-io/klv-bridge-eth-go/clients/klever/proxy"
+	"github.com/klever-io/klv-oracles-go/aggregator/api/gin"
+	"github.com/klever-io/klv-oracles-go/aggregator/notifees"
+	"github.com/klever-io/klv-oracles-go/config"
+	"github.com/klever-io/klv-oracles-go/tools/signer"
+	"github.com/klever-io/klv-oracles-go/tools/wallet"
+)
+
+const defaultRetiredKeyDrain = 5 * time.Minute
+
+type oracleKey struct {
+	address      string
+	wallet       wallet.Wallet
+	nonceHandler notifees.ClosableNonceHandler
+}
+
+// oracleKeys loads the oracle keys, each one with its own nonce handler, and reloads them on demand. The nonce
+// handlers of the kept keys are reused, while the ones of the removed keys keep resending their pending transactions
+// during the drain period before being closed. A removed key loaded again before the end of its drain period gets its
+// nonce handler back
+type oracleKeys struct {
+	proxy            proxy.Proxy
+	intervalToResend time.Duration
+	signingPolicy    *signingPolicyWrapper
+
+	mut       sync.RWMutex
+	keys      []*oracleKey
+	retired   map[string]*oracleKey
+	passwords map[string]string
+}
+
+func newOracleKeys(cfg config.PriceNotifierConfig, proxy proxy.Proxy, signingPolicy *signingPolicyWrapper) *oracleKeys {
+	return &oracleKeys{
+		proxy:            proxy,
+		intervalToResend: time.Second * time.Duration(cfg.GeneralConfig.IntervalToResendTxsInSeconds),
+		signingPolicy:    signingPolicy,
+		retired:          make(map[string]*oracleKey),
+		passwords:        make(map[string]string),
+	}
+}
+
+// load loads the configured keys, replacing the current ones, and returns them for the notifee
+func (keys *oracleKeys) load(cfg config.PriceNotifierConfig) ([]notifees.OracleKey, error) {
+	wallets, err := keys.loadWallets(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	keys.mut.Lock()
+	defer keys.mut.Unlock()
+
+	reusable := make(map[string]*oracleKey, len(keys.keys)+len(keys.retired))
+	for address, key := range keys.retired {
+		reusable[address] = key
+	}
+	for _, key := range keys.keys {
+		reusable[key.address] = key
+	}
+
+	loaded := make([]*oracleKey, 0, len(wallets))
+	created := make([]*oracleKey, 0, len(wallets))
+	isLoaded := make(map[string]struct{}, len(wallets))
+	for _, oracleWallet := range wallets {
+		key, errKey := keys.createKey(oracleWallet, reusable)
+		if errKey == nil {
+			if _, found := isLoaded[key.address]; found {
+				errKey = fmt.Errorf("the oracle key %s is loaded twice", key.address)
+			}
+		}
+		if errKey != nil {
+			closeNonceHandlers(created)
+			return nil, errKey
+		}
+		if _, found := reusable[key.address]; !found {
+			created = append(created, key)
+		}
+
+		isLoaded[key.address] = struct{}{}
+		loaded = append(loaded, key)
+	}
+	for address := range isLoaded {
+		delete(keys.retired, address)
+	}
+
+	retired := make([]*oracleKey, 0)
+	for _, key := range keys.keys {
+		if _, found := isLoaded[key.address]; !found {
+			retired = append(retired, key)
+		}
+	}
+	keys.keys = loaded
+	keys.retire(retired, cfg.OracleKeys.RetiredKeyDrainInSeconds)
+
+	notifeeKeys := make([]notifees.OracleKey, 0, len(loaded))
+	for _, key := range loaded {
+		notifeeKeys = append(notifeeKeys, notifees.OracleKey{
+			Wallet:         key.wallet,
+			TxNonceHandler: key.nonceHandler,
+		})
+		log.Info("oracle key", "address", key.address)
+	}
+
+	return notifeeKeys, nil
+}
+
+// createKey wraps the wallet with the signing policy and reuses the nonce handler of the current or retired key with the
+// same address, so its nonces and pending transactions are kept
+func (keys *oracleKeys) createKey(oracleWallet wallet.Wallet, reusable map[string]*oracleKey) (*oracleKey, error) {
+	walletAddress, err := oracleWallet.Address()
+	if err != nil {
+		return nil, err
+	}
+	oracleWallet, err = keys.signingPolicy.wrap(oracleWallet)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &oracleKey{
+		address: walletAddress.Bech32(),
+		wallet:  oracleWallet,
+	}
+	if reusableKey, found := reusable[key.address]; found {
+		key.nonceHandler = reusableKey.nonceHandler
+		return key, nil
+	}
+
+	key.nonceHandler, err = notifees.NewResettableNonceHandler(func() (notifees.ClosableNonceHandler, error) {
+		return nonceHandler.NewNonceTransactionHandlerV2(nonceHandler.ArgsNonceTransactionsHandlerV2{
+			Proxy:            keys.proxy,
+			IntervalToResend: keys.intervalToResend,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// retire keeps the removed keys aside and closes their nonce handlers once their pending transactions had the time to
+// be resent, unless they are loaded again in the meantime. It must be called under the mutex
+func (keys *oracleKeys) retire(retired []*oracleKey, drainInSeconds uint64) {
+	drain := time.Second * time.Duration(drainInSeconds)
+	if drain == 0 {
+		drain = defaultRetiredKeyDrain
+	}
+
+	for _, key := range retired {
+		log.Info("retiring oracle key", "address", key.address, "drain", drain)
+		keys.retired[key.address] = key
+
+		time.AfterFunc(drain, func() {
+			keys.closeRetired(key)
+		})
+	}
+}
+
+func (keys *oracleKeys) closeRetired(key *oracleKey) {
+	keys.mut.Lock()
+	defer keys.mut.Unlock()
+
+	if keys.retired[key.address] != key {
+		return
+	}
+	delete(keys.retired, key.address)
+	_ = key.nonceHandler.Close()
+	log.Debug("closed the nonce handler of the retired oracle key", "address", key.address)
+}
+
+func closeNonceHandlers(keys []*oracleKey) {
+	for _, key := range keys {
+		_ = key.nonceHandler.Close()
+	}
+}
+
+// close closes the nonce handlers of the current and retired keys
+func (keys *oracleKeys) close() {
+	keys.mut.Lock()
+	defer keys.mut.Unlock()
+
+	closeNonceHandlers(keys.keys)
+	for address, key := range keys.retired {
+		_ = key.nonceHandler.Close()
+		delete(keys.retired, address)
+	}
+}
+
+// loadWallets returns the remote signer wallet when configured, the wallets of the configured key files otherwise,
+// falling back to the PrivateKeyFile
+func (keys *oracleKeys) loadWallets(cfg config.PriceNotifierConfig) ([]wallet.Wallet, error) {
+	if len(cfg.RemoteSigner.URL) > 0 {
+		if len(cfg.OracleKeys.Files) > 0 {
+			log.Warn("the OracleKeys files are ignored when a remote signer is configured")
+		}
+		remoteWallet, err := createRemoteWallet(cfg.RemoteSigner)
+		if err != nil {
+			return nil, err
+		}
+
+		return []wallet.Wallet{remoteWallet}, nil
+	}
+
+	files := cfg.OracleKeys.Files
+	if len(files) == 0 {
+		files = []config.OracleKeyFileConfig{{
+			File:         cfg.GeneralConfig.PrivateKeyFile,
+			PasswordFile: cfg.GeneralConfig.PrivateKeyPasswordFile,
+		}}
+	}
+
+	wallets := make([]wallet.Wallet, 0, len(files))
+	for _, file := range files {
+		indexes := file.Indexes
+		if len(indexes) == 0 {
+			indexes = []int{0}
+		}
+
+		for _, index := range indexes {
+			oracleWallet, err := keys.loadPEMWallet(file, index)
+			if err != nil {
+				return nil, fmt.Errorf("%w for the key %d of %s", err, index, file.File)
+			}
+			wallets = append(wallets, oracleWallet)
+		}
+	}
+
+	return wallets, nil
+}
+
+// loadPEMWallet loads the wallet of the key at index of the PEM file, asking for its password only when the key is
+// encrypted
+func (keys *oracleKeys) loadPEMWallet(file config.OracleKeyFileConfig, index int) (wallet.Wallet, error) {
+	encrypted, err := wallet.IsEncryptedPEMFile(file.File)
+	if err != nil {
+		return nil, err
+	}
+	if !encrypted {
+		log.Warn("the oracle key is not encrypted, consider running the wallet encrypt command", "file", file.File)
+		return wallet.NewWalletFromPEMIndex(file.File, index, "")
+	}
+
+	password, err := keys.password(file)
+	if err != nil {
+		return nil, err
+	}
+
+	oracleWallet, err := wallet.NewWalletFromPEMIndex(file.File, index, password)
+	if err != nil {
+		return nil, err
+	}
+	keys.rememberPassword(file, password)
+
+	return oracleWallet, nil
+}
+
+// password reads the password of the PEM file, from its password file or from the ones remembered, prompting for it
+// otherwise
+func (keys *oracleKeys) password(file config.OracleKeyFileConfig) (string, error) {
+	if len(file.PasswordFile) > 0 {
+		return readPEMPassword(file.PasswordFile, false)
+	}
+
+	keys.mut.RLock()
+	password, found := keys.passwords[file.File]
+	keys.mut.RUnlock()
+	if found {
+		return password, nil
+	}
+
+	return readPEMPassword("", false)
+}
+
+// rememberPassword remembers the prompted password once it decrypted a key of the PEM file, so the reloads do not
+// prompt for it again
+func (keys *oracleKeys) rememberPassword(file config.OracleKeyFileConfig, password string) {
+	if len(file.PasswordFile) > 0 {
+		return
+	}
+
+	keys.mut.Lock()
+	keys.passwords[file.File] = password
+	keys.mut.Unlock()
+}
+
+func createRemoteWallet(cfg config.RemoteSignerConfig) (wallet.Wallet, error) {
+	args := signer.ArgsRemoteWallet{
+		URL:            cfg.URL,
+		CACertFile:     cfg.CACertFile,
+		ClientCertFile: cfg.ClientCertFile,
+		ClientKeyFile:  cfg.ClientKeyFile,
+		Timeout:        time.Duration(cfg.TimeoutInMilliseconds) * time.Millisecond,
+	}
+	remoteWallet, err := signer.NewRemoteWallet(context.Background(), args)
+	if err != nil {
+		return nil, err
+	}
+
+	oracleAddress, err := remoteWallet.Address()
+	if err != nil {
+		return nil, err
+	}
+	log.Info("signing the transactions with the remote signer", "url", cfg.URL, "address", oracleAddress.Bech32())
+
+	return remoteWallet, nil
+}
+
+// Metrics returns the addresses of the oracle keys, along with the signing policy metrics of each key
+func (keys *oracleKeys) Metrics() map[string]interface{} {
+	keys.mut.RLock()
+	defer keys.mut.RUnlock()
+
+	metrics := make(map[string]interface{}, len(keys.keys))
+	for _, key := range keys.keys {
+		keyMetrics := make(map[string]interface{})
+		provider, ok := key.wallet.(gin.MetricsProvider)
+		if ok {
+			keyMetrics["signing policy"] = provider.Metrics()
+		}
+		metrics[key.address] = keyMetrics
+	}
+
+	return metrics
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (keys *oracleKeys) IsInterfaceNil() bool {
+	return keys == nil
+}
@@ -0,0 +1,12 @@
+package main
+
+import "github.com/klever-io/klv-oracles-go/tools/wallet"
+
+// pemPasswordEnvVariable holds the password of the encrypted PEM file when no password file is configured
+const pemPasswordEnvVariable = "KLV_ORACLE_PEM_PASSWORD"
+
+// readPEMPassword returns the PEM password read from the password file when provided, from the environment variable
+// otherwise, and prompts for it as a last resort. The prompted password is asked twice when confirming
+func readPEMPassword(passwordFile string, confirm bool) (string, error) {
+	return wallet.ReadPEMPassword(passwordFile, pemPasswordEnvVariable, confirm)
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+	"github.com/klever-io/klv-oracles-go/aggregator/api/gin"
+	"github.com/klever-io/klv-oracles-go/config"
+)
+
+type metricsRegistry interface {
+	AddMetricsProvider(name string, provider gin.MetricsProvider) error
+}
+
+// responseGetterFactory creates the response getters of the price and gas sources. The sources bound to the same
+// source IP share the same transport, so the keep-alive connections are reused, while each source can have its own
+// timeout and rate limit. The fetchers of the same source share its response getter, and so its rate limit
+type responseGetterFactory struct {
+	transports   map[string]http.RoundTripper
+	getters      map[string]aggregator.ResponseGetter
+	cfg          config.HTTPClientConfig
+	rateLimits   map[string]config.RateLimitConfig
+	rateLimiters map[string]gin.MetricsProvider
+	recorder     *aggregator.ResponseRecorder
+	replayer     replayer
+}
+
+func newResponseGetterFactory(cfg config.HTTPClientConfig, rateLimits map[string]config.RateLimitConfig) (*responseGetterFactory, error) {
+	factory := &responseGetterFactory{
+		transports:   make(map[string]http.RoundTripper),
+		getters:      make(map[string]aggregator.ResponseGetter),
+		cfg:          cfg,
+		rateLimits:   rateLimits,
+		rateLimiters: make(map[string]gin.MetricsProvider),
+	}
+
+	// the default transport is created upfront so the proxy and TLS settings are validated at startup
+	_, err := factory.transport(cfg.SourceIP)
+	if err != nil {
+		return nil, err
+	}
+
+	return factory, nil
+}
+
+func (factory *responseGetterFactory) transport(sourceIP string) (http.RoundTripper, error) {
+	transport, found := factory.transports[sourceIP]
+	if found {
+		return transport, nil
+	}
+
+	transport, err := aggregator.NewHttpTransport(aggregator.ArgsHttpTransport{
+		MaxIdleConnsPerHost: factory.cfg.MaxIdleConnectionsPerHost,
+		ProxyURL:            factory.cfg.ProxyURL,
+		CACertFile:          factory.cfg.CACertFile,
+		ClientCertFile:      factory.cfg.ClientCertFile,
+		ClientKeyFile:       factory.cfg.ClientKeyFile,
+		SourceIP:            sourceIP,
+	})
+	if err != nil {
+		return nil, err
+	}
+	factory.transports[sourceIP] = transport
+
+	return transport, nil
+}
+
+func (factory *responseGetterFactory) create(source string) (aggregator.ResponseGetter, error) {
+	if factory.replayer != nil {
+		return factory.replayer, nil
+	}
+	getter, found := factory.getters[source]
+	if found {
+		return getter, nil
+	}
+
+	getter, err := factory.createResponseGetter(source)
+	if err != nil {
+		return nil, err
+	}
+	factory.getters[source] = getter
+
+	return getter, nil
+}
+
+func (factory *responseGetterFactory) createResponseGetter(source string) (aggregator.ResponseGetter, error) {
+	sourceIP := factory.cfg.SourceIP
+	if ip, found := factory.cfg.SourceIPs[source]; found {
+		sourceIP = ip
+	}
+	transport, err := factory.transport(sourceIP)
+	if err != nil {
+		return nil, fmt.Errorf("%w for source %s", err, source)
+	}
+
+	args := aggregator.DefaultArgsHttpResponseGetter()
+	args.Transport = transport
+	args.MaxRetries = factory.cfg.MaxRetries
+	if factory.cfg.TimeoutInMilliseconds > 0 {
+		args.Timeout = time.Millisecond * time.Duration(factory.cfg.TimeoutInMilliseconds)
+	}
+	if factory.cfg.RetryBaseDelayInMilliseconds > 0 {
+		args.RetryBaseDelay = time.Millisecond * time.Duration(factory.cfg.RetryBaseDelayInMilliseconds)
+	}
+	if factory.cfg.RetryMaxDelayInMilliseconds > 0 {
+		args.RetryMaxDelay = time.Millisecond * time.Duration(factory.cfg.RetryMaxDelayInMilliseconds)
+	}
+	sourceTimeout, found := factory.cfg.SourceTimeoutsInMilliseconds[source]
+	if found {
+		args.Timeout = time.Millisecond * time.Duration(sourceTimeout)
+	}
+
+	// the rate limiter is waited on by the http response getter before each attempt, so the retries are limited too
+	rateLimit, found := factory.rateLimits[source]
+	if found {
+		rateLimiter, errLimiter := aggregator.NewRateLimiter(aggregator.ArgsRateLimiter{
+			Name:              source,
+			RequestsPerMinute: rateLimit.RequestsPerMinute,
+			Burst:             rateLimit.Burst,
+		})
+		if errLimiter != nil {
+			return nil, errLimiter
+		}
+
+		log.Info("rate limiting price source", "source", source,
+			"requests per minute", rateLimit.RequestsPerMinute, "burst", rateLimit.Burst)
+		factory.rateLimiters[source] = rateLimiter
+		args.RateLimiter = rateLimiter
+	}
+
+	log.Debug("created response getter", "source", source, "timeout", args.Timeout, "max retries", args.MaxRetries,
+		"source IP", sourceIP)
+
+	var httpResponseGetter aggregator.ResponseGetter
+	httpResponseGetter, err = aggregator.NewHttpResponseGetter(args)
+	if err != nil {
+		return nil, err
+	}
+	if factory.recorder != nil {
+		httpResponseGetter, err = factory.recorder.Wrap(httpResponseGetter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return httpResponseGetter, nil
+}
+
+// addRateLimitersMetrics exposes the state of the created rate limiters on the /metrics route
+func (factory *responseGetterFactory) addRateLimitersMetrics(webServer metricsRegistry) error {
+	for source, rateLimiter := range factory.rateLimiters {
+		err := webServer.AddMetricsProvider(fmt.Sprintf("rate limiter %s", source), rateLimiter)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// unusedRateLimits returns the configured rate limits of the sources that do not exist
+func (factory *responseGetterFactory) unusedRateLimits() []string {
+	unused := make([]string, 0)
+	for source := range factory.rateLimits {
+		if _, found := factory.rateLimiters[source]; !found {
+			unused = append(unused, source)
+		}
+	}
+	sort.Strings(unused)
+
+	return unused
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+	"github.com/klever-io/klv-oracles-go/aggregator/fetchers"
+	"github.com/klever-io/klv-oracles-go/config"
+	"github.com/klever-io/klv-oracles-go/tools/signer"
+	"github.com/klever-io/klv-oracles-go/tools/wallet"
+)
+
+// signingPolicyWrapper wraps the oracle wallets with the same signing policy, so the daily fee cap is shared by all
+// the oracle keys
+type signingPolicyWrapper struct {
+	policy   signer.SignPolicy
+	alerters []signer.Alerter
+}
+
+// newSigningPolicyWrapper returns nil when the signing policy is disabled
+func newSigningPolicyWrapper(cfg config.PriceNotifierConfig, responseGetters *responseGetterFactory) (*signingPolicyWrapper, error) {
+	if !cfg.SigningPolicy.Enabled {
+		return nil, nil
+	}
+
+	policy, err := createSigningPolicy(cfg, responseGetters)
+	if err != nil {
+		return nil, err
+	}
+
+	alerters := make([]signer.Alerter, 0, 1)
+	if len(cfg.SigningPolicy.AlertWebhookURL) > 0 {
+		transport, errTransport := responseGetters.transport(cfg.HTTPClient.SourceIP)
+		if errTransport != nil {
+			return nil, errTransport
+		}
+		alerter, errAlerter := signer.NewWebhookAlerter(signer.ArgsWebhookAlerter{
+			URL:       cfg.SigningPolicy.AlertWebhookURL,
+			Transport: transport,
+		})
+		if errAlerter != nil {
+			return nil, errAlerter
+		}
+		alerters = append(alerters, alerter)
+	}
+
+	return &signingPolicyWrapper{
+		policy:   policy,
+		alerters: alerters,
+	}, nil
+}
+
+// wrap returns the wallet only signing the transactions accepted by the policy, or the wallet itself when the policy
+// is disabled
+func (wrapper *signingPolicyWrapper) wrap(oracleWallet wallet.Wallet) (wallet.Wallet, error) {
+	if wrapper == nil {
+		return oracleWallet, nil
+	}
+
+	return signer.NewPolicyWallet(signer.ArgsPolicyWallet{
+		Wallet:   oracleWallet,
+		Policy:   wrapper.policy,
+		Alerters: wrapper.alerters,
+	})
+}
+
+// createSigningPolicy combines the enabled checks, the daily fee cap being the last one so only the fees of the
+// accepted transactions are counted
+func createSigningPolicy(cfg config.PriceNotifierConfig, responseGetters *responseGetterFactory) (signer.SignPolicy, error) {
+	policyCfg := cfg.SigningPolicy
+
+	submitBatchPolicy, err := signer.NewSubmitBatchPolicy(cfg.GeneralConfig.AggregatorContractAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	maxPairs := policyCfg.MaxPairs
+	if maxPairs == 0 {
+		maxPairs = len(cfg.Pairs) + len(cfg.GasStationPair)
+	}
+	pairsCountPolicy, err := signer.NewPairsCountPolicy(max(policyCfg.MinPairs, 1), maxPairs)
+	if err != nil {
+		return nil, err
+	}
+	policies := []signer.SignPolicy{submitBatchPolicy, pairsCountPolicy}
+	log.Info("signing policy", "aggregator contract", cfg.GeneralConfig.AggregatorContractAddress,
+		"min pairs", max(policyCfg.MinPairs, 1), "max pairs", maxPairs)
+
+	if policyCfg.MaxReferenceDeviationPercent > 0 {
+		referencePolicy, errReference := createReferencePricePolicy(cfg, responseGetters)
+		if errReference != nil {
+			return nil, errReference
+		}
+		policies = append(policies, referencePolicy)
+	}
+
+	if policyCfg.DailyFeeCap > 0 {
+		feeCapPolicy, errFeeCap := signer.NewDailyFeeCapPolicy(signer.ArgsDailyFeeCapPolicy{
+			DailyFeeCap: policyCfg.DailyFeeCap,
+		})
+		if errFeeCap != nil {
+			return nil, errFeeCap
+		}
+		policies = append(policies, feeCapPolicy)
+		log.Info("signing policy", "daily fee cap", policyCfg.DailyFeeCap)
+	}
+
+	return signer.NewPolicies(policies...)
+}
+
+// createReferencePricePolicy compares the prices of the [[Pairs]] with the median price of the ReferenceExchanges.
+// The reference exchanges have their own price fetchers, so the pairs they are queried for never reach the fetchers
+// of the oracle. They are queried once per batch, when its call is checked, the signed transaction being only matched
+// against the checked call
+func createReferencePricePolicy(cfg config.PriceNotifierConfig, responseGetters *responseGetterFactory) (signer.SignPolicy, error) {
+	policyCfg := cfg.SigningPolicy
+
+	referenceFetchers := make([]aggregator.PriceFetcher, 0, len(policyCfg.ReferenceExchanges))
+	for _, exchange := range policyCfg.ReferenceExchanges {
+		priceFetcher, err := createReferencePriceFetcher(cfg, responseGetters, exchange)
+		if err != nil {
+			return nil, err
+		}
+		referenceFetchers = append(referenceFetchers, priceFetcher)
+	}
+
+	referencePairs := make([]aggregator.BaseQuote, 0, len(cfg.Pairs))
+	for _, pair := range cfg.Pairs {
+		referencePairs = append(referencePairs, aggregator.BaseQuote{Base: pair.Base, Quote: pair.Quote})
+		for _, priceFetcher := range referenceFetchers {
+			priceFetcher.AddPair(pair.Base, pair.Quote)
+		}
+	}
+
+	referenceAggregator, err := aggregator.NewPriceAggregator(aggregator.ArgsPriceAggregator{
+		PriceFetchers: referenceFetchers,
+		MinResultsNum: max(policyCfg.ReferenceMinResultsNum, 1),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("signing policy", "reference exchanges", policyCfg.ReferenceExchanges,
+		"max reference deviation percent", policyCfg.MaxReferenceDeviationPercent)
+
+	return signer.NewReferencePricePolicy(signer.ArgsReferencePricePolicy{
+		Provider:            referenceAggregator,
+		Pairs:               referencePairs,
+		MaxDeviationPercent: policyCfg.MaxReferenceDeviationPercent,
+	})
+}
+
+func createReferencePriceFetcher(
+	cfg config.PriceNotifierConfig,
+	responseGetters *responseGetterFactory,
+	exchange string,
+) (aggregator.PriceFetcher, error) {
+	if _, found := fetchers.ImplementedFetchers[exchange]; found {
+		return createExchangePriceFetcher(responseGetters, cfg, exchange)
+	}
+	for _, restJSONConfig := range cfg.RestJSONFetchers {
+		if restJSONConfig.Name == exchange {
+			return createRestJSONPriceFetcher(responseGetters, cfg, restJSONConfig)
+		}
+	}
+
+	return nil, fmt.Errorf("unknown signing policy reference exchange %s", exchange)
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+	"github.com/klever-io/klv-oracles-go/aggregator/simulation"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/urfave/cli"
+)
+
+var (
+	simulationPrices = cli.StringSliceFlag{
+		Name: "prices",
+		Usage: "The `[path]` of a CSV file holding historical prices, with a header row and the timestamp, base," +
+			" quote, price and optional exchange columns. The rows without an exchange belong to the exchange named" +
+			" after the file, e.g. Binance.csv. Can be provided multiple times.",
+	}
+	simulationPollInterval = cli.Uint64Flag{
+		Name:  "poll-interval",
+		Usage: "The virtual time in seconds between two rounds. Defaults to PollIntervalInSeconds from the config file.",
+	}
+	simulationMaxPriceAge = cli.Uint64Flag{
+		Name:  "max-price-age",
+		Usage: "The age in seconds after which a historical price is considered missing. 0 disables the check.",
+	}
+	simulationGasPrice = cli.Uint64Flag{
+		Name:  "gas-price",
+		Usage: "The price of a gas unit used to estimate the cost of the transactions.",
+	}
+)
+
+func getSimulateCommand() cli.Command {
+	return cli.Command{
+		Name: "simulate",
+		Usage: "Feeds historical prices through the price aggregator and the price notifier configured in the config" +
+			" file, and reports the transactions that would have been sent and the on-chain price deviations",
+		Flags: []cli.Flag{
+			simulationPrices,
+			simulationPollInterval,
+			simulationMaxPriceAge,
+			simulationGasPrice,
+		},
+		Action: simulate,
+	}
+}
+
+func simulate(ctx *cli.Context) error {
+	err := logger.SetLogLevel(ctx.GlobalString(logLevel.Name))
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(ctx.GlobalString(configurationFile.Name))
+	if err != nil {
+		return err
+	}
+
+	pricesFiles := ctx.StringSlice(simulationPrices.Name)
+	if len(pricesFiles) == 0 {
+		return fmt.Errorf("no historical prices provided, use the %s flag", simulationPrices.Name)
+	}
+	priceTicks, err := simulation.LoadPriceTicksFromFiles(pricesFiles)
+	if err != nil {
+		return err
+	}
+
+	pollIntervalInSeconds := cfg.GeneralConfig.PollIntervalInSeconds
+	if ctx.IsSet(simulationPollInterval.Name) {
+		pollIntervalInSeconds = ctx.Uint64(simulationPollInterval.Name)
+	}
+
+	args := simulation.ArgsSimulator{
+		PriceTicks:              priceTicks,
+		Pairs:                   make([]*aggregator.ArgsPair, 0, len(cfg.Pairs)),
+		MinResultsNum:           cfg.GeneralConfig.MinResultsNum,
+		QuoteConversions:        make([]aggregator.ArgsQuoteConversion, 0, len(cfg.QuoteConversions)),
+		PollInterval:            time.Second * time.Duration(pollIntervalInSeconds),
+		AutoSendInterval:        time.Second * time.Duration(cfg.GeneralConfig.AutoSendIntervalInSeconds),
+		HeartbeatCoalesceWindow: time.Second * time.Duration(cfg.GeneralConfig.HeartbeatCoalesceWindowInSeconds),
+		MaxPriceAge:             time.Second * time.Duration(ctx.Uint64(simulationMaxPriceAge.Name)),
+		BaseGasLimit:            cfg.GeneralConfig.BaseGasLimit,
+		GasLimitForEach:         cfg.GeneralConfig.GasLimitForEach,
+		GasPrice:                ctx.Uint64(simulationGasPrice.Name),
+	}
+	for _, conversion := range cfg.QuoteConversions {
+		args.QuoteConversions = append(args.QuoteConversions, aggregator.ArgsQuoteConversion{
+			From:          conversion.From,
+			To:            conversion.To,
+			MinResultsNum: conversion.MinResultsNum,
+		})
+	}
+	for _, pair := range cfg.Pairs {
+		argsPair, errPair := createArgsPair(pair)
+		if errPair != nil {
+			return errPair
+		}
+		args.Pairs = append(args.Pairs, argsPair)
+	}
+	if len(cfg.GasStationPair) > 0 {
+		log.Warn("the gas station pairs are not simulated", "num pairs", len(cfg.GasStationPair))
+	}
+
+	simulator, err := simulation.NewSimulator(args)
+	if err != nil {
+		return err
+	}
+
+	report, err := simulator.Run(context.Background())
+	if err != nil {
+		return err
+	}
+
+	return report.Write(os.Stdout)
+}
@@ -0,0 +1,428 @@
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/klever-io/klv-bridge-eth-go/clients/klever/blockchain/address"
+	"github.com/klever-io/klv-oracles-go/tools/wallet"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/urfave/cli"
+)
+
+var (
+	walletPemFile = cli.StringFlag{
+		Name:  "pem",
+		Usage: "The `[path]` of the PEM file. Defaults to the PrivateKeyFile of the configuration file",
+		Value: "",
+	}
+	walletPasswordFile = cli.StringFlag{
+		Name: "password-file",
+		Usage: "The `[path]` of the file holding the PEM password. Defaults to the PrivateKeyPasswordFile of the " +
+			"configuration file, then to the " + pemPasswordEnvVariable + " environment variable and to a prompt",
+		Value: "",
+	}
+	walletOutFile = cli.StringFlag{
+		Name:  "out",
+		Usage: "The `[path]` of the PEM file to create, never overwritten",
+		Value: "keys/oracle.pem",
+	}
+	walletEncrypt = cli.BoolFlag{
+		Name:  "encrypt",
+		Usage: "Encrypts the created PEM file with a password read like the oracle reads it",
+	}
+	walletMnemonicFile = cli.StringFlag{
+		Name:  "mnemonic-file",
+		Usage: "The `[path]` of the file holding the mnemonic. Defaults to the standard input",
+		Value: "",
+	}
+	walletHDPrefix = cli.IntFlag{
+		Name:  "hd-prefix",
+		Usage: "The coin `type` of the derivation path m/44'/<hd-prefix>'/0'/0'/<index>'",
+		Value: 690,
+	}
+	walletIndex = cli.IntFlag{
+		Name:  "index",
+		Usage: "The `index` of the first derived key, or of the key of the PEM file",
+		Value: 0,
+	}
+	walletCount = cli.IntFlag{
+		Name:  "count",
+		Usage: "The `number` of keys derived at the consecutive indexes, written to the same PEM file",
+		Value: 1,
+	}
+	walletMessage = cli.StringFlag{
+		Name:  "message",
+		Usage: "The `message` to sign or verify",
+		Value: "",
+	}
+	walletMessageFile = cli.StringFlag{
+		Name:  "message-file",
+		Usage: "The `[path]` of the file holding the message to sign or verify, replacing the message flag",
+		Value: "",
+	}
+	walletVerifyAddress = cli.StringFlag{
+		Name:  "address",
+		Usage: "The klv1 `address` the signature is verified against",
+		Value: "",
+	}
+	walletSignature = cli.StringFlag{
+		Name:  "signature",
+		Usage: "The hex `signature` to verify",
+		Value: "",
+	}
+)
+
+func getWalletCommand() cli.Command {
+	return cli.Command{
+		Name:  "wallet",
+		Usage: "Manages the oracle key",
+		Subcommands: []cli.Command{
+			{
+				Name:   "encrypt",
+				Usage:  "Encrypts in place the plaintext PEM file with a password",
+				Flags:  []cli.Flag{walletPemFile, walletPasswordFile},
+				Action: encryptWallet,
+			},
+			{
+				Name: "generate",
+				Usage: "Generates a new mnemonic, printed once, and writes the keys derived from it to a new PEM " +
+					"file",
+				Flags:  []cli.Flag{walletOutFile, walletEncrypt, walletPasswordFile, walletHDPrefix, walletIndex, walletCount},
+				Action: generateWallet,
+			},
+			{
+				Name:   "derive",
+				Usage:  "Writes the keys derived from an existing mnemonic to a new PEM file",
+				Flags:  []cli.Flag{walletOutFile, walletEncrypt, walletPasswordFile, walletMnemonicFile, walletHDPrefix, walletIndex, walletCount},
+				Action: deriveWallet,
+			},
+			{
+				Name:   "address",
+				Usage:  "Prints the klv1 address and the public key of a key of the PEM file",
+				Flags:  []cli.Flag{walletPemFile, walletPasswordFile, walletIndex},
+				Action: printWalletAddress,
+			},
+			{
+				Name: "sign",
+				Usage: "Signs a message with a key of the PEM file, proving the ownership of its address. The " +
+					"message is prefixed before being signed, so the signature can never be used for a transaction",
+				Flags:  []cli.Flag{walletPemFile, walletPasswordFile, walletIndex, walletMessage, walletMessageFile},
+				Action: signWalletMessage,
+			},
+			{
+				Name:   "verify",
+				Usage:  "Verifies the signature of a message made by the sign command",
+				Flags:  []cli.Flag{walletVerifyAddress, walletMessage, walletMessageFile, walletSignature},
+				Action: verifyWalletMessage,
+			},
+		},
+	}
+}
+
+func encryptWallet(ctx *cli.Context) error {
+	err := logger.SetLogLevel(ctx.GlobalString(logLevel.Name))
+	if err != nil {
+		return err
+	}
+
+	pemFile, passwordFile, err := walletFiles(ctx)
+	if err != nil {
+		return err
+	}
+
+	encrypted, err := wallet.IsEncryptedPEMFile(pemFile)
+	if err != nil {
+		return err
+	}
+	if encrypted {
+		return fmt.Errorf("the PEM file %s is already encrypted", pemFile)
+	}
+
+	password, err := readPEMPassword(passwordFile, true)
+	if err != nil {
+		return err
+	}
+
+	err = wallet.EncryptPEMFile(pemFile, password)
+	if err != nil {
+		return err
+	}
+
+	log.Info("encrypted the PEM file", "file", pemFile)
+
+	return nil
+}
+
+// walletFiles returns the PEM and password files from the flags, falling back to the configuration file
+func walletFiles(ctx *cli.Context) (string, string, error) {
+	pemFile := ctx.String(walletPemFile.Name)
+	passwordFile := ctx.String(walletPasswordFile.Name)
+	if len(pemFile) > 0 && len(passwordFile) > 0 {
+		return pemFile, passwordFile, nil
+	}
+
+	cfg, err := loadConfig(ctx.GlobalString(configurationFile.Name))
+	if err != nil {
+		if len(pemFile) > 0 {
+			return pemFile, passwordFile, nil
+		}
+		return "", "", err
+	}
+	if len(pemFile) == 0 {
+		pemFile = cfg.GeneralConfig.PrivateKeyFile
+	}
+	if len(passwordFile) == 0 {
+		passwordFile = cfg.GeneralConfig.PrivateKeyPasswordFile
+	}
+
+	return pemFile, passwordFile, nil
+}
+
+func generateWallet(ctx *cli.Context) error {
+	err := logger.SetLogLevel(ctx.GlobalString(logLevel.Name))
+	if err != nil {
+		return err
+	}
+
+	mnemonic, err := wallet.NewMnemonic()
+	if err != nil {
+		return err
+	}
+
+	err = writeDerivedWallets(ctx, mnemonic)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("mnemonic, write it down and keep it offline, it is not stored anywhere:")
+	fmt.Println(mnemonic)
+
+	return nil
+}
+
+func deriveWallet(ctx *cli.Context) error {
+	err := logger.SetLogLevel(ctx.GlobalString(logLevel.Name))
+	if err != nil {
+		return err
+	}
+
+	err = checkNewPEMFile(ctx.String(walletOutFile.Name))
+	if err != nil {
+		return err
+	}
+
+	mnemonic, err := readMnemonic(ctx.String(walletMnemonicFile.Name))
+	if err != nil {
+		return err
+	}
+
+	return writeDerivedWallets(ctx, mnemonic)
+}
+
+// writeDerivedWallets derives the keys at the consecutive indexes and writes them to the new PEM file, printing their
+// addresses
+func writeDerivedWallets(ctx *cli.Context, mnemonic string) error {
+	outFile := ctx.String(walletOutFile.Name)
+	firstIndex := ctx.Int(walletIndex.Name)
+	count := ctx.Int(walletCount.Name)
+	if firstIndex < 0 || count < 1 {
+		return fmt.Errorf("invalid index %d or count %d", firstIndex, count)
+	}
+	err := checkNewPEMFile(outFile)
+	if err != nil {
+		return err
+	}
+
+	wallets := make([]wallet.Wallet, 0, count)
+	for index := firstIndex; index < firstIndex+count; index++ {
+		derived, err := wallet.NewWalletFromMnemonic(mnemonic, wallet.WOHDPath{
+			Prefix: ctx.Int(walletHDPrefix.Name),
+			Index:  index,
+		})
+		if err != nil {
+			return err
+		}
+		wallets = append(wallets, derived)
+	}
+
+	password := ""
+	if ctx.Bool(walletEncrypt.Name) {
+		password, err = readPEMPassword(ctx.String(walletPasswordFile.Name), true)
+		if err != nil {
+			return err
+		}
+	} else {
+		log.Warn("the PEM file is not encrypted, consider using the encrypt flag", "file", outFile)
+	}
+
+	err = os.MkdirAll(filepath.Dir(outFile), 0700)
+	if err != nil {
+		return err
+	}
+	err = wallet.WritePEMFile(outFile, wallets, password)
+	if err != nil {
+		return err
+	}
+
+	log.Info("wrote the PEM file", "file", outFile, "num keys", len(wallets), "encrypted", len(password) > 0)
+	for i, derived := range wallets {
+		walletAddress, errAddress := derived.Address()
+		if errAddress != nil {
+			return errAddress
+		}
+		fmt.Printf("m/44'/%d'/0'/0'/%d'\t%s\n", ctx.Int(walletHDPrefix.Name), firstIndex+i, walletAddress.Bech32())
+	}
+
+	return nil
+}
+
+func checkNewPEMFile(outFile string) error {
+	if _, err := os.Stat(outFile); err == nil {
+		return fmt.Errorf("the PEM file %s already exists", outFile)
+	}
+
+	return nil
+}
+
+// readMnemonic reads the mnemonic from the file when provided, from the standard input otherwise
+func readMnemonic(mnemonicFile string) (string, error) {
+	var content string
+	if len(mnemonicFile) > 0 {
+		buff, err := os.ReadFile(filepath.Clean(mnemonicFile))
+		if err != nil {
+			return "", fmt.Errorf("%w while reading the mnemonic file", err)
+		}
+		content = string(buff)
+	} else {
+		_, _ = fmt.Fprint(os.Stderr, "Mnemonic: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && len(line) == 0 {
+			return "", fmt.Errorf("%w while reading the mnemonic", err)
+		}
+		content = line
+	}
+
+	mnemonic := strings.Join(strings.Fields(content), " ")
+	if !wallet.IsMnemonicValid(mnemonic) {
+		return "", fmt.Errorf("invalid mnemonic")
+	}
+
+	return mnemonic, nil
+}
+
+func printWalletAddress(ctx *cli.Context) error {
+	oracleWallet, err := loadWalletFromFlags(ctx)
+	if err != nil {
+		return err
+	}
+
+	walletAddress, err := oracleWallet.Address()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(walletAddress.Bech32())
+	fmt.Println(hex.EncodeToString(oracleWallet.PublicKey()))
+
+	return nil
+}
+
+func signWalletMessage(ctx *cli.Context) error {
+	message, err := readMessage(ctx)
+	if err != nil {
+		return err
+	}
+
+	oracleWallet, err := loadWalletFromFlags(ctx)
+	if err != nil {
+		return err
+	}
+
+	signature, err := wallet.SignMessage(oracleWallet, message)
+	if err != nil {
+		return err
+	}
+
+	walletAddress, err := oracleWallet.Address()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(walletAddress.Bech32())
+	fmt.Println(hex.EncodeToString(signature))
+
+	return nil
+}
+
+func verifyWalletMessage(ctx *cli.Context) error {
+	message, err := readMessage(ctx)
+	if err != nil {
+		return err
+	}
+
+	walletAddress, err := address.NewAddress(ctx.String(walletVerifyAddress.Name))
+	if err != nil {
+		return err
+	}
+
+	signature, err := hex.DecodeString(ctx.String(walletSignature.Name))
+	if err != nil {
+		return fmt.Errorf("%w while decoding the signature", err)
+	}
+
+	if !wallet.VerifyMessage(walletAddress.Bytes(), message, signature) {
+		return fmt.Errorf("the signature was not made by %s for this message", walletAddress.Bech32())
+	}
+
+	fmt.Println("valid signature")
+
+	return nil
+}
+
+// readMessage returns the content of the message file when provided, the message flag otherwise
+func readMessage(ctx *cli.Context) ([]byte, error) {
+	messageFile := ctx.String(walletMessageFile.Name)
+	if len(messageFile) > 0 {
+		return os.ReadFile(filepath.Clean(messageFile))
+	}
+
+	message := ctx.String(walletMessage.Name)
+	if len(message) == 0 {
+		return nil, fmt.Errorf("empty message, provide it with the message or message-file flag")
+	}
+
+	return []byte(message), nil
+}
+
+// loadWalletFromFlags loads the key at the index flag of the PEM file, reading its password only when encrypted
+func loadWalletFromFlags(ctx *cli.Context) (wallet.Wallet, error) {
+	err := logger.SetLogLevel(ctx.GlobalString(logLevel.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	pemFile, passwordFile, err := walletFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := wallet.IsEncryptedPEMFile(pemFile)
+	if err != nil {
+		return nil, err
+	}
+
+	password := ""
+	if encrypted {
+		password, err = readPEMPassword(passwordFile, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return wallet.NewWalletFromPEMIndex(pemFile, ctx.Int(walletIndex.Name), password)
+}
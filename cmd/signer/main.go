@@ -0,0 +1,240 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime"
+	"syscall"
+	"time"
+
+	"github.com/klever-io/klv-oracles-go/tools/signer"
+	"github.com/klever-io/klv-oracles-go/tools/wallet"
+	chainCommon "github.com/multiversx/mx-chain-go/common"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/urfave/cli"
+)
+
+const (
+	// pemPasswordEnvVariable holds the password of the encrypted PEM file when no password file is provided
+	pemPasswordEnvVariable = "KLV_SIGNER_PEM_PASSWORD"
+	shutdownTimeout        = 5 * time.Second
+	readHeaderTimeout      = 5 * time.Second
+)
+
+var log = logger.GetOrCreate("signer/main")
+
+// appVersion should be populated at build time using ldflags
+var appVersion = chainCommon.UnVersionedAppString
+
+var (
+	logLevel = cli.StringFlag{
+		Name:  "log-level",
+		Usage: "This flag specifies the logger `level(s)`, for example *:INFO",
+		Value: "*:" + logger.LogInfo.String(),
+	}
+	listenAddress = cli.StringFlag{
+		Name:  "listen",
+		Usage: "The `address and port` the signer listens on",
+		Value: ":8443",
+	}
+	pemFile = cli.StringFlag{
+		Name:  "pem",
+		Usage: "The `[path]` of the PEM file holding the oracle key, plaintext or encrypted",
+		Value: "keys/oracle.pem",
+	}
+	passwordFile = cli.StringFlag{
+		Name: "password-file",
+		Usage: "The `[path]` of the file holding the password of the encrypted PEM file. Defaults to the " +
+			pemPasswordEnvVariable + " environment variable, then to a prompt",
+		Value: "",
+	}
+	tlsCertFile = cli.StringFlag{
+		Name:  "tls-cert",
+		Usage: "The `[path]` of the PEM certificate the signer serves",
+		Value: "",
+	}
+	tlsKeyFile = cli.StringFlag{
+		Name:  "tls-key",
+		Usage: "The `[path]` of the PEM key of the served certificate",
+		Value: "",
+	}
+	clientCAFile = cli.StringFlag{
+		Name:  "client-ca",
+		Usage: "The `[path]` of the PEM bundle with the authorities issuing the oracles client certificates",
+		Value: "",
+	}
+	aggregatorContract = cli.StringFlag{
+		Name:  "aggregator-contract",
+		Usage: "The bech32 `address` of the aggregator contract, the only destination the signer accepts",
+		Value: "",
+	}
+	maxPairs = cli.IntFlag{
+		Name:  "max-pairs",
+		Usage: "The max `number` of price changes of a signed batch, 0 meaning no limit",
+		Value: 0,
+	}
+	dailyFeeCap = cli.Uint64Flag{
+		Name:  "daily-fee-cap",
+		Usage: "The max sum of the fees, in the smallest KLV unit, signed during a UTC day, 0 meaning no cap",
+		Value: 0,
+	}
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "Oracle signer"
+	app.Usage = "Signer daemon holding the oracle key, only signing the submitBatch calls of the aggregator contract" +
+		" requested by the oracles authenticated with mutual TLS"
+	app.Version = fmt.Sprintf("%s/%s/%s-%s", appVersion, runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	app.Flags = []cli.Flag{
+		logLevel,
+		listenAddress,
+		pemFile,
+		passwordFile,
+		tlsCertFile,
+		tlsKeyFile,
+		clientCAFile,
+		aggregatorContract,
+		maxPairs,
+		dailyFeeCap,
+	}
+	app.Authors = []cli.Author{
+		{
+			Name:  "The Klever Blockchain Team",
+			Email: "contact@klever.io",
+		},
+	}
+	app.Action = startSigner
+
+	err := app.Run(os.Args)
+	if err != nil {
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+}
+
+func startSigner(ctx *cli.Context) error {
+	err := logger.SetLogLevel(ctx.GlobalString(logLevel.Name))
+	if err != nil {
+		return err
+	}
+
+	policy, err := createPolicy(ctx)
+	if err != nil {
+		return err
+	}
+
+	oracleWallet, err := loadWallet(ctx.GlobalString(pemFile.Name), ctx.GlobalString(passwordFile.Name))
+	if err != nil {
+		return err
+	}
+
+	handler, err := signer.NewSignerHandler(signer.ArgsSignerHandler{
+		Wallet: oracleWallet,
+		Policy: policy,
+	})
+	if err != nil {
+		return err
+	}
+
+	tlsConfig, err := signer.NewMutualTLSConfig(
+		ctx.GlobalString(tlsCertFile.Name),
+		ctx.GlobalString(tlsKeyFile.Name),
+		ctx.GlobalString(clientCAFile.Name),
+	)
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{
+		Addr:              ctx.GlobalString(listenAddress.Name),
+		Handler:           handler,
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	oracleAddress, err := oracleWallet.Address()
+	if err != nil {
+		return err
+	}
+	log.Info("starting signer", "version", appVersion, "address", oracleAddress.Bech32(), "listen", server.Addr)
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServeTLS("", "")
+	}()
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+
+	select {
+	case err = <-serverErr:
+		return err
+	case <-sigs:
+	}
+
+	log.Info("application closing, closing the signer...")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	err = server.Shutdown(shutdownCtx)
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+
+	return err
+}
+
+// createPolicy returns the policy only signing the submitBatch calls of the aggregator contract, within the optional
+// pairs and daily fee bounds
+func createPolicy(ctx *cli.Context) (signer.SignPolicy, error) {
+	submitBatchPolicy, err := signer.NewSubmitBatchPolicy(ctx.GlobalString(aggregatorContract.Name))
+	if err != nil {
+		return nil, err
+	}
+	policies := []signer.SignPolicy{submitBatchPolicy}
+
+	if ctx.GlobalInt(maxPairs.Name) > 0 {
+		pairsCountPolicy, errPairs := signer.NewPairsCountPolicy(1, ctx.GlobalInt(maxPairs.Name))
+		if errPairs != nil {
+			return nil, errPairs
+		}
+		policies = append(policies, pairsCountPolicy)
+	}
+
+	if ctx.GlobalUint64(dailyFeeCap.Name) > 0 {
+		feeCapPolicy, errFeeCap := signer.NewDailyFeeCapPolicy(signer.ArgsDailyFeeCapPolicy{
+			DailyFeeCap: ctx.GlobalUint64(dailyFeeCap.Name),
+		})
+		if errFeeCap != nil {
+			return nil, errFeeCap
+		}
+		policies = append(policies, feeCapPolicy)
+	}
+
+	return signer.NewPolicies(policies...)
+}
+
+// loadWallet loads the wallet from the PEM file, reading the password from the password file, the environment
+// variable or a prompt when the key is encrypted
+func loadWallet(pemPath string, passwordPath string) (wallet.Wallet, error) {
+	encrypted, err := wallet.IsEncryptedPEMFile(pemPath)
+	if err != nil {
+		return nil, err
+	}
+	if !encrypted {
+		log.Warn("the oracle key is not encrypted", "file", pemPath)
+		return wallet.NewWalletFromPEM(pemPath)
+	}
+
+	password, err := wallet.ReadPEMPassword(passwordPath, pemPasswordEnvVariable, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return wallet.NewWalletFromEncryptedPEM(pemPath, password)
+}
@@ -9,25 +9,54 @@ type PriceNotifierConfig struct {
 	Pairs                     []Pair
 	GasStationPair            []Pair
 	XExchangeTokenIDsMappings map[string]fetchers.XExchangeTokensPair
+	QuoteMappings             map[string]map[string]string
+	SymbolMappings            map[string]fetchers.SymbolMapping
+	RestJSONFetchers          []fetchers.RestJSONFetcherConfig
+	QuoteConversions          []QuoteConversion
+	HTTPClient                HTTPClientConfig
+	RateLimits                map[string]RateLimitConfig
+	// GasChains are the EVM chains, other than the default one, the gas station pairs refer to by their Chain
+	GasChains []GasChainConfig
+	// GasPriceNode is the EVM node queried over JSON-RPC for the gas prices, next to the gas station, when set
+	GasPriceNode fetchers.EVMJSONRPCGasFetcherConfig
+	// RemoteSigner is the signer daemon holding the oracle key, used instead of the PrivateKeyFile when its URL is set
+	RemoteSigner RemoteSignerConfig
+	// SigningPolicy is checked before signing each transaction, whatever wallet holds the key
+	SigningPolicy SigningPolicyConfig
+	// OracleKeys are the keys the transactions are spread over, replacing the PrivateKeyFile when set
+	OracleKeys OracleKeysConfig
 }
 
 // GeneralNotifierConfig general price notifier configuration struct
 type GeneralNotifierConfig struct {
-	NetworkAddress               string
-	GasStationAPI                string
-	PrivateKeyFile               string
+	NetworkAddress string
+	GasStationAPI  string
+	PrivateKeyFile string
+	// PrivateKeyPasswordFile holds the password of the encrypted PrivateKeyFile. When not set, the password is read
+	// from the KLV_ORACLE_PEM_PASSWORD environment variable or prompted for
+	PrivateKeyPasswordFile       string
 	IntervalToResendTxsInSeconds uint64
 	ProxyCacherExpirationSeconds uint64
 	AggregatorContractAddress    string
 	BaseGasLimit                 uint64
 	GasLimitForEach              uint64
 	MinResultsNum                int
-	PollIntervalInSeconds        uint64
-	AutoSendIntervalInSeconds    uint64
-	ProxyRestAPIEntityType       string
-	ProxyMaxNoncesDelta          int
-	ProxyFinalityCheck           bool
-	Logs                         LogsConfig
+	// GasRestJSONSources names the RestJSONFetchers queried for the gas price of the default chain, next to the gas
+	// station and the gas price node
+	GasRestJSONSources []string
+	// GasReferenceExchanges are the exchanges the USD prices of the gas native tokens and of the GWEI pairs quotes
+	// are fetched from when they are not published
+	GasReferenceExchanges []string
+	// GasMinResultsNum is the minimum number of gas sources of the default chain that must respond, 0 meaning 1
+	GasMinResultsNum          int
+	PollIntervalInSeconds     uint64
+	AutoSendIntervalInSeconds uint64
+	// HeartbeatCoalesceWindowInSeconds adds to a transaction the pairs whose heartbeat is due in less than this
+	HeartbeatCoalesceWindowInSeconds uint64
+	ProxyRestAPIEntityType           string
+	ProxyMaxNoncesDelta              int
+	ProxyFinalityCheck               bool
+	Logs                             LogsConfig
 }
 
 // LogsConfig will hold settings related to the logging sub-system
@@ -49,15 +78,126 @@ type Pair struct {
 	PercentDifferenceToNotify uint32
 	Decimals                  uint64
 	Exchanges                 []string
+	// DeviationThreshold, written as a percent ("0.25%") or as basis points ("25bps"), replaces the whole
+	// PercentDifferenceToNotify when set
+	DeviationThreshold string
+	// UpDeviationThreshold and DownDeviationThreshold replace the deviation threshold for the price increases,
+	// respectively the price decreases, when set
+	UpDeviationThreshold   string
+	DownDeviationThreshold string
+	// AbsoluteChangeToNotify is the minimum price change, in quote units, able to trigger a notification
+	AbsoluteChangeToNotify float64
+	// AutoSendIntervalInSeconds overrides GeneralConfig.AutoSendIntervalInSeconds for the pair when not 0
+	AutoSendIntervalInSeconds uint64
+	// PollIntervalInSeconds is the time between two price fetches of the pair, a multiple of the
+	// GeneralConfig.PollIntervalInSeconds. 0 fetches it on every GeneralConfig.PollIntervalInSeconds
+	PollIntervalInSeconds uint64
+	// Chain is the GasChains entry of a gas station pair, the default chain, paid in ETH and using the general gas
+	// sources, being used when empty
+	Chain string
+	// GasSmoothing, "EMA" or "PERCENTILE", smooths the gas values of a gas station pair before their conversion. The
+	// EMA uses GasSmoothingAlpha, the percentile uses GasSmoothingWindowSize and GasSmoothingPercentile
+	GasSmoothing           string
+	GasSmoothingAlpha      float64
+	GasSmoothingWindowSize int
+	GasSmoothingPercentile float64
+}
+
+// GasChainConfig describes an EVM chain whose gas prices are paid in its NativeToken. At least one of the gas sources,
+// GasStationAPI, GasPriceNodeURL and GasRestJSONSources, must be set
+type GasChainConfig struct {
+	Chain              string
+	NativeToken        string
+	GasStationAPI      string
+	GasPriceNodeURL    string
+	GasRestJSONSources []string
+	// GasMinResultsNum is the minimum number of gas sources of the chain that must respond, 0 meaning 1
+	GasMinResultsNum int
+}
+
+// QuoteConversion defines the conversion leg applied to prices fetched in a substitute quote
+type QuoteConversion struct {
+	From          string
+	To            string
+	MinResultsNum int
+	Exchanges     []string
+}
+
+// HTTPClientConfig defines the HTTP client used by the price and gas fetchers. Zero values keep the defaults
+type HTTPClientConfig struct {
+	TimeoutInMilliseconds        uint64
+	MaxRetries                   int
+	RetryBaseDelayInMilliseconds uint64
+	RetryMaxDelayInMilliseconds  uint64
+	MaxIdleConnectionsPerHost    int
+	SourceTimeoutsInMilliseconds map[string]uint64
+	ProxyURL                     string
+	CACertFile                   string
+	ClientCertFile               string
+	ClientKeyFile                string
+	SourceIP                     string
+	SourceIPs                    map[string]string
+}
+
+// RemoteSignerConfig defines the signer daemon the transactions are signed by, reached over HTTPS with mutual TLS
+type RemoteSignerConfig struct {
+	URL                   string
+	CACertFile            string
+	ClientCertFile        string
+	ClientKeyFile         string
+	TimeoutInMilliseconds uint64
+}
+
+// OracleKeysConfig defines the keys the oracle transactions are sent with, each key having its own nonces
+type OracleKeysConfig struct {
+	// Selection is "round-robin", spreading the transactions over the keys, or "failover", sending them with the first
+	// key and using the next ones only when it fails
+	Selection string
+	// RetiredKeyDrainInSeconds is the time the pending transactions of the keys removed by a reload keep being resent
+	RetiredKeyDrainInSeconds uint64
+	Files                    []OracleKeyFileConfig
+}
+
+// OracleKeyFileConfig defines the keys loaded from a PEM file
+type OracleKeyFileConfig struct {
+	File string
+	// Indexes are the positions of the keys in the PEM file, 0 being the first key. Only the first key is loaded when
+	// empty
+	Indexes      []int
+	PasswordFile string
+}
+
+// SigningPolicyConfig defines the conditions a transaction must meet to be signed
+type SigningPolicyConfig struct {
+	Enabled  bool
+	MinPairs int
+	MaxPairs int
+	// MaxReferenceDeviationPercent is the max difference between the price of a pair and its reference price, fetched
+	// from the ReferenceExchanges. 0 disables the reference check
+	MaxReferenceDeviationPercent float64
+	ReferenceExchanges           []string
+	ReferenceMinResultsNum       int
+	// DailyFeeCap is the max sum of the fees signed during a UTC day. 0 disables the cap
+	DailyFeeCap uint64
+	// AlertWebhookURL receives, when set, each policy violation as JSON, besides the error log
+	AlertWebhookURL string
+}
+
+// RateLimitConfig defines the token bucket that limits the requests sent to one price source
+type RateLimitConfig struct {
+	RequestsPerMinute uint64
+	Burst             uint64
 }
 
 // ContextFlagsConfig holds the configuration for flags
 type ContextFlagsConfig struct {
-	WorkingDir        string
-	LogLevel          string
-	DisableAnsiColor  bool
-	ConfigurationFile string
-	SaveLogFile       bool
-	EnableLogName     bool
-	RestApiInterface  string
+	WorkingDir          string
+	LogLevel            string
+	DisableAnsiColor    bool
+	ConfigurationFile   string
+	SaveLogFile         bool
+	EnableLogName       bool
+	RestApiInterface    string
+	RecordResponsesFile string
+	ReplayResponsesFile string
 }
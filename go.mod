@@ -16,6 +16,7 @@ require (
 	github.com/stretchr/testify v1.9.0
 	github.com/urfave/cli v1.22.10
 	github.com/xdg-go/pbkdf2 v1.0.0
+	golang.org/x/term v0.25.0
 	google.golang.org/protobuf v1.35.1
 )
 
@@ -0,0 +1,103 @@
+package harness
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+)
+
+const certificateValidity = 24 * time.Hour
+
+// certificateAuthority issues, on demand, the server certificates of the intercepted exchange hosts
+type certificateAuthority struct {
+	mut         sync.Mutex
+	certificate *x509.Certificate
+	certPEM     []byte
+	key         *ecdsa.PrivateKey
+	serial      int64
+	issued      map[string]*tls.Certificate
+}
+
+func newCertificateAuthority() (*certificateAuthority, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "klv-oracles-go test harness CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(certificateValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return &certificateAuthority{
+		certificate: certificate,
+		certPEM:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		key:         key,
+		serial:      1,
+		issued:      make(map[string]*tls.Certificate),
+	}, nil
+}
+
+// certificateFor returns the certificate of the provided host, issuing it on the first call
+func (ca *certificateAuthority) certificateFor(host string) (*tls.Certificate, error) {
+	ca.mut.Lock()
+	defer ca.mut.Unlock()
+
+	cert, found := ca.issued[host]
+	if found {
+		return cert, nil
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	ca.serial++
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(ca.serial),
+		Subject:      pkix.Name{CommonName: host},
+		DNSNames:     []string{host},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(certificateValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.certificate, &key.PublicKey, ca.key)
+	if err != nil {
+		return nil, err
+	}
+
+	cert = &tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+	}
+	ca.issued[host] = cert
+
+	return cert, nil
+}
+
+func (ca *certificateAuthority) writeCertificate(path string) error {
+	return os.WriteFile(path, ca.certPEM, 0644)
+}
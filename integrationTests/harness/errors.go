@@ -0,0 +1,14 @@
+package harness
+
+import "errors"
+
+var (
+	// ErrUnknownExchange signals that the exchange is not served by the fake exchanges
+	ErrUnknownExchange = errors.New("unknown exchange")
+	// ErrInvalidTransaction signals that a broadcast transaction could not be decoded
+	ErrInvalidTransaction = errors.New("invalid transaction")
+	// ErrInvalidSubmitBatch signals that a transaction data field is not a valid submitBatch call
+	ErrInvalidSubmitBatch = errors.New("invalid submitBatch call")
+	// ErrTimeout signals that the awaited transactions were not received in time
+	ErrTimeout = errors.New("timeout")
+)
@@ -0,0 +1,244 @@
+package harness
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/klever-io/klv-oracles-go/aggregator/fetchers"
+)
+
+// exchangeRoute describes how an exchange ticker API is queried and how it answers
+type exchangeRoute struct {
+	name     string
+	host     string
+	marketID func(base string, quote string) string
+	// markets returns the market IDs requested, or nil if the request is not a ticker request
+	markets func(r *http.Request) []string
+	// response builds the body returned for the requested markets, in the same order
+	response func(r *http.Request, markets []string, prices []float64) interface{}
+}
+
+func formattedMarketID(format string) func(base string, quote string) string {
+	return func(base string, quote string) string {
+		return fmt.Sprintf(format, base, quote)
+	}
+}
+
+func queryMarket(path string, param string) func(r *http.Request) []string {
+	return func(r *http.Request) []string {
+		market := r.URL.Query().Get(param)
+		if r.URL.Path != path || len(market) == 0 {
+			return nil
+		}
+
+		return []string{market}
+	}
+}
+
+func pathMarket(prefix string, suffix string) func(r *http.Request) []string {
+	return func(r *http.Request) []string {
+		if !strings.HasPrefix(r.URL.Path, prefix) || !strings.HasSuffix(r.URL.Path, suffix) {
+			return nil
+		}
+
+		market := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, prefix), suffix)
+		if len(market) == 0 || strings.Contains(market, "/") {
+			return nil
+		}
+
+		return []string{market}
+	}
+}
+
+func formatPrice(price float64) string {
+	return strconv.FormatFloat(price, 'f', -1, 64)
+}
+
+func binanceMarkets(r *http.Request) []string {
+	if r.URL.Path != "/api/v3/ticker/price" {
+		return nil
+	}
+
+	symbols := r.URL.Query().Get("symbols")
+	if len(symbols) == 0 {
+		return queryMarket(r.URL.Path, "symbol")(r)
+	}
+
+	var markets []string
+	err := json.Unmarshal([]byte(symbols), &markets)
+	if err != nil {
+		return nil
+	}
+
+	return markets
+}
+
+func binanceResponse(r *http.Request, markets []string, prices []float64) interface{} {
+	tickers := make([]map[string]string, 0, len(markets))
+	for idx, market := range markets {
+		tickers = append(tickers, map[string]string{
+			"symbol": market,
+			"price":  formatPrice(prices[idx]),
+		})
+	}
+
+	if len(r.URL.Query().Get("symbols")) > 0 {
+		return tickers
+	}
+
+	return tickers[0]
+}
+
+func createExchangeRoutes() []*exchangeRoute {
+	return []*exchangeRoute{
+		{
+			name:     fetchers.BinanceName,
+			host:     "api.binance.com",
+			marketID: formattedMarketID("%s%s"),
+			markets:  binanceMarkets,
+			response: binanceResponse,
+		},
+		{
+			name: fetchers.BitfinexName,
+			host: "api.bitfinex.com",
+			marketID: func(base string, quote string) string {
+				if len(base) > 3 {
+					return fmt.Sprintf("%s:%s", base, quote)
+				}
+				return base + quote
+			},
+			markets: pathMarket("/v1/pubticker/", ""),
+			response: func(_ *http.Request, _ []string, prices []float64) interface{} {
+				return map[string]string{"last_price": formatPrice(prices[0])}
+			},
+		},
+		{
+			name:     fetchers.BybitName,
+			host:     "api.bybit.com",
+			marketID: formattedMarketID("%s%s"),
+			markets:  queryMarket("/v5/market/tickers", "symbol"),
+			response: func(_ *http.Request, markets []string, prices []float64) interface{} {
+				return map[string]interface{}{
+					"retCode": 0,
+					"retMsg":  "OK",
+					"result": map[string]interface{}{
+						"list": []map[string]string{{"symbol": markets[0], "lastPrice": formatPrice(prices[0])}},
+					},
+				}
+			},
+		},
+		{
+			name:     fetchers.CoinbaseName,
+			host:     "api.exchange.coinbase.com",
+			marketID: formattedMarketID("%s-%s"),
+			markets:  pathMarket("/products/", "/ticker"),
+			response: func(_ *http.Request, _ []string, prices []float64) interface{} {
+				return map[string]string{"price": formatPrice(prices[0])}
+			},
+		},
+		{
+			name:     fetchers.CryptocomName,
+			host:     "api.crypto.com",
+			marketID: formattedMarketID("%s_%s"),
+			markets:  queryMarket("/v2/public/get-ticker", "instrument_name"),
+			response: func(_ *http.Request, _ []string, prices []float64) interface{} {
+				return map[string]interface{}{
+					"result": map[string]interface{}{
+						"data": []map[string]string{{"a": formatPrice(prices[0])}},
+					},
+				}
+			},
+		},
+		{
+			name:     fetchers.GateioName,
+			host:     "api.gateio.ws",
+			marketID: formattedMarketID("%s_%s"),
+			markets:  queryMarket("/api/v4/spot/tickers", "currency_pair"),
+			response: func(_ *http.Request, markets []string, prices []float64) interface{} {
+				return []map[string]string{{"currency_pair": markets[0], "last": formatPrice(prices[0])}}
+			},
+		},
+		{
+			name:     fetchers.GeminiName,
+			host:     "api.gemini.com",
+			marketID: formattedMarketID("%s%s"),
+			markets:  pathMarket("/v2/ticker/", ""),
+			response: func(_ *http.Request, _ []string, prices []float64) interface{} {
+				return map[string]string{"close": formatPrice(prices[0])}
+			},
+		},
+		{
+			name:     fetchers.HitbtcName,
+			host:     "api.hitbtc.com",
+			marketID: formattedMarketID("%s%s"),
+			markets:  pathMarket("/api/3/public/ticker/", ""),
+			response: func(_ *http.Request, _ []string, prices []float64) interface{} {
+				return map[string]string{"last": formatPrice(prices[0])}
+			},
+		},
+		{
+			name: fetchers.HTXName,
+			host: "api.htx.com",
+			marketID: func(base string, quote string) string {
+				return strings.ToLower(base + quote)
+			},
+			markets: queryMarket("/market/detail/merged", "symbol"),
+			response: func(_ *http.Request, _ []string, prices []float64) interface{} {
+				return map[string]interface{}{
+					"status": "ok",
+					"tick":   map[string]float64{"close": prices[0]},
+				}
+			},
+		},
+		{
+			name:     fetchers.KrakenName,
+			host:     "api.kraken.com",
+			marketID: formattedMarketID("%s%s"),
+			markets:  queryMarket("/0/public/Ticker", "pair"),
+			response: func(_ *http.Request, markets []string, prices []float64) interface{} {
+				return map[string]interface{}{
+					"error": []string{},
+					"result": map[string]interface{}{
+						markets[0]: map[string][]string{"c": {formatPrice(prices[0]), "1.0"}},
+					},
+				}
+			},
+		},
+		{
+			name:     fetchers.KucoinName,
+			host:     "api.kucoin.com",
+			marketID: formattedMarketID("%s-%s"),
+			markets:  queryMarket("/api/v1/market/orderbook/level1", "symbol"),
+			response: func(_ *http.Request, _ []string, prices []float64) interface{} {
+				return map[string]interface{}{
+					"code": "200000",
+					"data": map[string]string{"price": formatPrice(prices[0])},
+				}
+			},
+		},
+		{
+			name:     fetchers.MexcName,
+			host:     "api.mexc.com",
+			marketID: formattedMarketID("%s%s"),
+			markets:  queryMarket("/api/v3/ticker/price", "symbol"),
+			response: func(_ *http.Request, markets []string, prices []float64) interface{} {
+				return map[string]string{"symbol": markets[0], "price": formatPrice(prices[0])}
+			},
+		},
+		{
+			name:     fetchers.OkxName,
+			host:     "www.okx.com",
+			marketID: formattedMarketID("%s-%s"),
+			markets:  queryMarket("/api/v5/market/ticker", "instId"),
+			response: func(_ *http.Request, _ []string, prices []float64) interface{} {
+				return map[string]interface{}{
+					"code": "0",
+					"data": []map[string]string{{"last": formatPrice(prices[0])}},
+				}
+			},
+		},
+	}
+}
@@ -0,0 +1,360 @@
+package harness
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+
+	logger "github.com/multiversx/mx-chain-logger-go"
+)
+
+const (
+	gasStationPath = "/gas-station"
+	outageMessage  = "scripted outage"
+)
+
+var log = logger.GetOrCreate("integrationTests/harness")
+
+// GasPrices holds the gas prices, in GWEI, returned by the fake EVM gas station
+type GasPrices struct {
+	Safe    float64
+	Propose float64
+	Fast    float64
+}
+
+// FakeExchanges is an in-process HTTP server answering like the public ticker APIs of the implemented exchanges and
+// like an EVM gas station. The prices are scriptable per exchange and each exchange can be put in outage.
+//
+// The fetchers reach it in two ways: in-process, through the round tripper returned by Transport, or from another
+// process, by using the server as HTTP proxy and trusting the CA written by WriteCACertificate. The proxied TLS
+// connections to the exchange hosts are terminated with certificates issued by that CA
+type FakeExchanges struct {
+	mut         sync.RWMutex
+	server      *httptest.Server
+	authority   *certificateAuthority
+	routes      map[string]*exchangeRoute
+	prices      map[string]map[string]float64
+	outages     map[string]int
+	numRequests map[string]int
+	gasPrices   GasPrices
+	tunnels     map[net.Conn]struct{}
+}
+
+// NewFakeExchanges creates and starts a new FakeExchanges instance. Close must be called at the end of the test
+func NewFakeExchanges() (*FakeExchanges, error) {
+	authority, err := newCertificateAuthority()
+	if err != nil {
+		return nil, err
+	}
+
+	fe := &FakeExchanges{
+		authority:   authority,
+		routes:      make(map[string]*exchangeRoute),
+		prices:      make(map[string]map[string]float64),
+		outages:     make(map[string]int),
+		numRequests: make(map[string]int),
+		tunnels:     make(map[net.Conn]struct{}),
+	}
+	for _, route := range createExchangeRoutes() {
+		fe.routes[route.host] = route
+		fe.prices[route.name] = make(map[string]float64)
+	}
+	fe.server = httptest.NewServer(http.HandlerFunc(fe.serveHTTP))
+
+	return fe, nil
+}
+
+// SetPrice sets the price returned by the exchange for the base-quote pair. The base and quote are the exchange
+// symbols, after the quote mappings are applied (e.g. BTC-USDT on Binance)
+func (fe *FakeExchanges) SetPrice(exchange string, base string, quote string, price float64) error {
+	route, err := fe.routeByName(exchange)
+	if err != nil {
+		return err
+	}
+
+	fe.mut.Lock()
+	fe.prices[exchange][route.marketID(base, quote)] = price
+	fe.mut.Unlock()
+
+	return nil
+}
+
+// RemovePrice makes the exchange answer that the base-quote pair is not listed
+func (fe *FakeExchanges) RemovePrice(exchange string, base string, quote string) error {
+	route, err := fe.routeByName(exchange)
+	if err != nil {
+		return err
+	}
+
+	fe.mut.Lock()
+	delete(fe.prices[exchange], route.marketID(base, quote))
+	fe.mut.Unlock()
+
+	return nil
+}
+
+// SetOutage makes all the requests of the exchange fail with the provided HTTP status code. A 0 status code ends
+// the outage
+func (fe *FakeExchanges) SetOutage(exchange string, statusCode int) error {
+	_, err := fe.routeByName(exchange)
+	if err != nil {
+		return err
+	}
+
+	fe.mut.Lock()
+	defer fe.mut.Unlock()
+
+	if statusCode == 0 {
+		delete(fe.outages, exchange)
+		return nil
+	}
+	fe.outages[exchange] = statusCode
+
+	return nil
+}
+
+// SetGasPrices sets the gas prices returned by the gas station
+func (fe *FakeExchanges) SetGasPrices(gasPrices GasPrices) {
+	fe.mut.Lock()
+	fe.gasPrices = gasPrices
+	fe.mut.Unlock()
+}
+
+// NumRequests returns the number of requests received by the exchange, including the ones failed by an outage
+func (fe *FakeExchanges) NumRequests(exchange string) int {
+	fe.mut.RLock()
+	defer fe.mut.RUnlock()
+
+	return fe.numRequests[exchange]
+}
+
+// Exchanges returns the names of all the exchanges served
+func (fe *FakeExchanges) Exchanges() []string {
+	names := make([]string, 0, len(fe.routes))
+	for _, route := range fe.routes {
+		names = append(names, route.name)
+	}
+
+	return names
+}
+
+// GasStationURL returns the URL to be configured as the EVM gas station API
+func (fe *FakeExchanges) GasStationURL() string {
+	return fe.server.URL + gasStationPath
+}
+
+// ProxyURL returns the URL to be configured as HTTP proxy by the processes querying the fake exchanges
+func (fe *FakeExchanges) ProxyURL() string {
+	return fe.server.URL
+}
+
+// WriteCACertificate writes, PEM encoded, the CA that issued the certificates of the exchange hosts
+func (fe *FakeExchanges) WriteCACertificate(path string) error {
+	return fe.authority.writeCertificate(path)
+}
+
+// Transport returns a round tripper sending all the requests to the fake exchanges, whatever the requested host is
+func (fe *FakeExchanges) Transport() http.RoundTripper {
+	target, _ := url.Parse(fe.server.URL)
+
+	return &redirectingTransport{
+		target: target,
+		base:   fe.server.Client().Transport,
+	}
+}
+
+// Close stops the server and closes all the proxied connections
+func (fe *FakeExchanges) Close() {
+	fe.mut.Lock()
+	for conn := range fe.tunnels {
+		_ = conn.Close()
+	}
+	fe.mut.Unlock()
+
+	fe.server.Close()
+}
+
+func (fe *FakeExchanges) routeByName(exchange string) (*exchangeRoute, error) {
+	for _, route := range fe.routes {
+		if route.name == exchange {
+			return route, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownExchange, exchange)
+}
+
+func (fe *FakeExchanges) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodConnect {
+		fe.serveTunnel(w, r)
+		return
+	}
+	if r.URL.Path == gasStationPath {
+		fe.serveGasStation(w)
+		return
+	}
+
+	// a proxied request carries the absolute URL, a redirected one carries the original host
+	host := r.URL.Hostname()
+	if len(host) == 0 {
+		host = hostWithoutPort(r.Host)
+	}
+	route, found := fe.routes[host]
+	if !found {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "unknown host " + host})
+		return
+	}
+
+	fe.serveExchange(w, r, route)
+}
+
+func (fe *FakeExchanges) serveExchange(w http.ResponseWriter, r *http.Request, route *exchangeRoute) {
+	fe.mut.Lock()
+	fe.numRequests[route.name]++
+	outageStatusCode := fe.outages[route.name]
+	fe.mut.Unlock()
+
+	if outageStatusCode != 0 {
+		writeJSON(w, outageStatusCode, map[string]string{"error": outageMessage})
+		return
+	}
+
+	markets := route.markets(r)
+	if len(markets) == 0 {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "unknown route " + r.URL.Path})
+		return
+	}
+
+	prices := make([]float64, 0, len(markets))
+	fe.mut.RLock()
+	for _, market := range markets {
+		price, found := fe.prices[route.name][market]
+		if !found {
+			fe.mut.RUnlock()
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unknown market " + market})
+			return
+		}
+		prices = append(prices, price)
+	}
+	fe.mut.RUnlock()
+
+	writeJSON(w, http.StatusOK, route.response(r, markets, prices))
+}
+
+func (fe *FakeExchanges) serveGasStation(w http.ResponseWriter) {
+	fe.mut.RLock()
+	gasPrices := fe.gasPrices
+	fe.mut.RUnlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status":  "1",
+		"message": "OK",
+		"result": map[string]string{
+			"SafeGasPrice":    formatPrice(gasPrices.Safe),
+			"ProposeGasPrice": formatPrice(gasPrices.Propose),
+			"FastGasPrice":    formatPrice(gasPrices.Fast),
+		},
+	})
+}
+
+// serveTunnel answers a CONNECT request by terminating the TLS connection to the exchange host and serving the
+// requests received through it
+func (fe *FakeExchanges) serveTunnel(w http.ResponseWriter, r *http.Request) {
+	host := hostWithoutPort(r.Host)
+	route, found := fe.routes[host]
+	if !found {
+		http.Error(w, "unknown host "+host, http.StatusBadGateway)
+		return
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "tunneling not supported", http.StatusInternalServerError)
+		return
+	}
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		log.Error("hijack failed", "host", host, "error", err)
+		return
+	}
+
+	fe.mut.Lock()
+	fe.tunnels[conn] = struct{}{}
+	fe.mut.Unlock()
+	defer func() {
+		fe.mut.Lock()
+		delete(fe.tunnels, conn)
+		fe.mut.Unlock()
+		_ = conn.Close()
+	}()
+
+	_, err = conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+	if err != nil {
+		return
+	}
+
+	tlsConn := tls.Server(conn, &tls.Config{
+		GetCertificate: func(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return fe.authority.certificateFor(host)
+		},
+	})
+	reader := bufio.NewReader(tlsConn)
+	for {
+		req, errRead := http.ReadRequest(reader)
+		if errRead != nil {
+			return
+		}
+
+		recorder := httptest.NewRecorder()
+		fe.serveExchange(recorder, req, route)
+		resp := recorder.Result()
+		resp.ContentLength = int64(recorder.Body.Len())
+		errWrite := resp.Write(tlsConn)
+		if errWrite != nil {
+			return
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	buff, err := json.Marshal(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(buff)
+}
+
+func hostWithoutPort(hostPort string) string {
+	host, _, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return hostPort
+	}
+
+	return host
+}
+
+// redirectingTransport sends the requests to the target server, keeping the requested host in the Host header
+type redirectingTransport struct {
+	target *url.URL
+	base   http.RoundTripper
+}
+
+// RoundTrip executes the request against the target server
+func (transport *redirectingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	redirected := req.Clone(req.Context())
+	redirected.URL.Scheme = transport.target.Scheme
+	redirected.URL.Host = transport.target.Host
+	redirected.Host = req.URL.Host
+
+	return transport.base.RoundTrip(redirected)
+}
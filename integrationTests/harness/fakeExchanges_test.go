@@ -0,0 +1,141 @@
+package harness
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+	"github.com/klever-io/klv-oracles-go/aggregator/fetchers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createFetcher(t *testing.T, exchange string, transport http.RoundTripper) aggregator.PriceFetcher {
+	responseGetter, err := aggregator.NewHttpResponseGetter(aggregator.ArgsHttpResponseGetter{
+		Transport: transport,
+		Timeout:   time.Second,
+	})
+	require.Nil(t, err)
+
+	fetcher, err := fetchers.NewPriceFetcher(fetchers.ArgsPriceFetcher{
+		FetcherName:    exchange,
+		ResponseGetter: responseGetter,
+	})
+	require.Nil(t, err)
+	fetcher.AddPair("BTC", "USDT")
+	fetcher.AddPair("ETH", "USDT")
+
+	return fetcher
+}
+
+func TestFakeExchanges_AllFetchersParseTheResponses(t *testing.T) {
+	t.Parallel()
+
+	fe, err := NewFakeExchanges()
+	require.Nil(t, err)
+	defer fe.Close()
+
+	require.Len(t, fe.Exchanges(), len(fetchers.ImplementedFetchers))
+	for _, exchange := range fe.Exchanges() {
+		require.Nil(t, fe.SetPrice(exchange, "BTC", "USDT", 60000.5))
+		require.Nil(t, fe.SetPrice(exchange, "ETH", "USDT", 3000.25))
+	}
+
+	for _, exchange := range fe.Exchanges() {
+		fetcher := createFetcher(t, exchange, fe.Transport())
+		results := fetcher.FetchPrices(context.Background(), []aggregator.BaseQuote{
+			{Base: "BTC", Quote: "USDT"},
+			{Base: "ETH", Quote: "USDT"},
+		})
+
+		btcResult := results[aggregator.BaseQuote{Base: "BTC", Quote: "USDT"}]
+		assert.Nil(t, btcResult.Err, exchange)
+		assert.Equal(t, 60000.5, btcResult.Price, exchange)
+		ethResult := results[aggregator.BaseQuote{Base: "ETH", Quote: "USDT"}]
+		assert.Nil(t, ethResult.Err, exchange)
+		assert.Equal(t, 3000.25, ethResult.Price, exchange)
+	}
+
+	// Binance answers both pairs with one request
+	assert.Equal(t, 1, fe.NumRequests(fetchers.BinanceName))
+	assert.Equal(t, 2, fe.NumRequests(fetchers.KrakenName))
+}
+
+func TestFakeExchanges_OutagesAndUnlistedPairs(t *testing.T) {
+	t.Parallel()
+
+	fe, err := NewFakeExchanges()
+	require.Nil(t, err)
+	defer fe.Close()
+
+	assert.ErrorIs(t, fe.SetPrice("missing", "BTC", "USDT", 1), ErrUnknownExchange)
+	assert.ErrorIs(t, fe.SetOutage("missing", http.StatusBadGateway), ErrUnknownExchange)
+	require.Nil(t, fe.SetPrice(fetchers.OkxName, "BTC", "USDT", 60000))
+
+	fetcher := createFetcher(t, fetchers.OkxName, fe.Transport())
+	price, err := fetcher.FetchPrice(context.Background(), "BTC", "USDT")
+	require.Nil(t, err)
+	assert.Equal(t, float64(60000), price)
+
+	_, err = fetcher.FetchPrice(context.Background(), "ETH", "USDT")
+	assert.ErrorIs(t, err, aggregator.ErrUnexpectedHTTPStatus)
+
+	require.Nil(t, fe.SetOutage(fetchers.OkxName, http.StatusServiceUnavailable))
+	_, err = fetcher.FetchPrice(context.Background(), "BTC", "USDT")
+	statusErr := &aggregator.HTTPStatusError{}
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusServiceUnavailable, statusErr.StatusCode)
+
+	require.Nil(t, fe.SetOutage(fetchers.OkxName, 0))
+	require.Nil(t, fe.RemovePrice(fetchers.OkxName, "BTC", "USDT"))
+	_, err = fetcher.FetchPrice(context.Background(), "BTC", "USDT")
+	assert.ErrorIs(t, err, aggregator.ErrUnexpectedHTTPStatus)
+	assert.Equal(t, 4, fe.NumRequests(fetchers.OkxName))
+}
+
+func TestFakeExchanges_ServesThroughTheProxyWithTheHarnessCA(t *testing.T) {
+	t.Parallel()
+
+	fe, err := NewFakeExchanges()
+	require.Nil(t, err)
+	defer fe.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.Nil(t, fe.WriteCACertificate(caFile))
+	require.Nil(t, fe.SetPrice(fetchers.CoinbaseName, "BTC", "USDT", 61000))
+	fe.SetGasPrices(GasPrices{Safe: 12, Propose: 15, Fast: 20})
+
+	transport, err := aggregator.NewHttpTransport(aggregator.ArgsHttpTransport{
+		ProxyURL:   fe.ProxyURL(),
+		CACertFile: caFile,
+	})
+	require.Nil(t, err)
+
+	fetcher := createFetcher(t, fetchers.CoinbaseName, transport)
+	price, err := fetcher.FetchPrice(context.Background(), "BTC", "USDT")
+	require.Nil(t, err)
+	assert.Equal(t, float64(61000), price)
+
+	responseGetter, err := aggregator.NewHttpResponseGetter(aggregator.ArgsHttpResponseGetter{
+		Transport: transport,
+		Timeout:   time.Second,
+	})
+	require.Nil(t, err)
+	gasFetcher, err := fetchers.NewPriceFetcher(fetchers.ArgsPriceFetcher{
+		FetcherName:    fetchers.EVMGasPriceStation,
+		ResponseGetter: responseGetter,
+		EVMGasConfig: fetchers.EVMGasPriceFetcherConfig{
+			ApiURL:   fe.GasStationURL(),
+			Selector: "ProposeGasPrice",
+		},
+	})
+	require.Nil(t, err)
+	gasFetcher.AddPair("ETH", "GWEI")
+
+	gasPrice, err := gasFetcher.FetchPrice(context.Background(), "ETH", "GWEI")
+	require.Nil(t, err)
+	assert.Equal(t, float64(15), gasPrice)
+}
@@ -0,0 +1,400 @@
+package harness
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	factoryHasher "github.com/klever-io/klever-go/crypto/hashing/factory"
+	"github.com/klever-io/klever-go/data/transaction"
+	"github.com/klever-io/klever-go/tools/marshal"
+	"github.com/klever-io/klv-bridge-eth-go/clients/klever/blockchain/address"
+	"github.com/klever-io/klv-oracles-go/aggregator"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	defaultChainID  = "420"
+	successfulCode  = "successful"
+	addressPrefix   = "/address/"
+	pollingInterval = 10 * time.Millisecond
+)
+
+// SentTransaction is a transaction received by the fake Klever node
+type SentTransaction struct {
+	Hash         string
+	Sender       string
+	Nonce        uint64
+	Data         []byte
+	PriceChanges []*aggregator.ArgsPriceChanged
+	// Contract is the bech32 address of the smart contract invoked, empty for the other transactions
+	Contract   string
+	Signatures [][]byte
+	// SignedHash is the blake2b hash of the proto-marshalled raw data, the one the signatures are checked against
+	SignedHash []byte
+	ReceivedAt time.Time
+	// Raw is the transaction, as JSON, for the checks not covered by the decoded fields
+	Raw json.RawMessage
+}
+
+// FakeKleverNode is an in-process HTTP server answering the routes of a Klever observer used by the oracle: the
+// network config, the account nonces, the fee estimation and the transaction broadcast. Each accepted transaction
+// increments the sender's nonce and its submitBatch arguments are decoded and recorded
+type FakeKleverNode struct {
+	mut          sync.RWMutex
+	server       *httptest.Server
+	chainID      string
+	nonces       map[string]uint64
+	transactions []*SentTransaction
+	broadcastErr string
+}
+
+// NewFakeKleverNode creates and starts a new FakeKleverNode instance. Close must be called at the end of the test
+func NewFakeKleverNode() *FakeKleverNode {
+	node := &FakeKleverNode{
+		chainID: defaultChainID,
+		nonces:  make(map[string]uint64),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/network/config", node.serveNetworkConfig)
+	mux.HandleFunc(addressPrefix, node.serveAccount)
+	mux.HandleFunc("/transaction/estimate-fee", node.serveEstimateFee)
+	mux.HandleFunc("/transaction/broadcast", node.serveBroadcast)
+	node.server = httptest.NewServer(mux)
+
+	return node
+}
+
+// URL returns the URL to be configured as the network address
+func (node *FakeKleverNode) URL() string {
+	return node.server.URL
+}
+
+// SetChainID sets the chain ID returned by the network config route
+func (node *FakeKleverNode) SetChainID(chainID string) {
+	node.mut.Lock()
+	node.chainID = chainID
+	node.mut.Unlock()
+}
+
+// SetNonce sets the current nonce of the account
+func (node *FakeKleverNode) SetNonce(bech32Address string, nonce uint64) {
+	node.mut.Lock()
+	node.nonces[bech32Address] = nonce
+	node.mut.Unlock()
+}
+
+// Nonce returns the current nonce of the account
+func (node *FakeKleverNode) Nonce(bech32Address string) uint64 {
+	node.mut.RLock()
+	defer node.mut.RUnlock()
+
+	return node.nonces[bech32Address]
+}
+
+// SetBroadcastError makes all the following broadcasts fail with the provided error message. An empty message
+// restores the broadcasts
+func (node *FakeKleverNode) SetBroadcastError(message string) {
+	node.mut.Lock()
+	node.broadcastErr = message
+	node.mut.Unlock()
+}
+
+// Transactions returns all the transactions received so far
+func (node *FakeKleverNode) Transactions() []*SentTransaction {
+	node.mut.RLock()
+	defer node.mut.RUnlock()
+
+	return append(make([]*SentTransaction, 0, len(node.transactions)), node.transactions...)
+}
+
+// PriceChanges returns, flattened, the price changes of all the submitBatch transactions received so far
+func (node *FakeKleverNode) PriceChanges() []*aggregator.ArgsPriceChanged {
+	priceChanges := make([]*aggregator.ArgsPriceChanged, 0)
+	for _, tx := range node.Transactions() {
+		priceChanges = append(priceChanges, tx.PriceChanges...)
+	}
+
+	return priceChanges
+}
+
+// WaitForTransactions waits until at least numTransactions transactions were received and returns them
+func (node *FakeKleverNode) WaitForTransactions(numTransactions int, timeout time.Duration) ([]*SentTransaction, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		transactions := node.Transactions()
+		if len(transactions) >= numTransactions {
+			return transactions, nil
+		}
+		if time.Now().After(deadline) {
+			return transactions, fmt.Errorf("%w, received %d transactions out of %d in %v",
+				ErrTimeout, len(transactions), numTransactions, timeout)
+		}
+
+		time.Sleep(pollingInterval)
+	}
+}
+
+// Close stops the server
+func (node *FakeKleverNode) Close() {
+	node.server.Close()
+}
+
+func (node *FakeKleverNode) serveNetworkConfig(w http.ResponseWriter, _ *http.Request) {
+	node.mut.RLock()
+	chainID := node.chainID
+	node.mut.RUnlock()
+
+	writeNodeResponse(w, map[string]interface{}{
+		"config": map[string]interface{}{
+			"klv_chain_id": chainID,
+		},
+	}, "")
+}
+
+func (node *FakeKleverNode) serveAccount(w http.ResponseWriter, r *http.Request) {
+	bech32Address := strings.TrimPrefix(r.URL.Path, addressPrefix)
+	if len(bech32Address) == 0 || strings.Contains(bech32Address, "/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	writeNodeResponse(w, map[string]interface{}{
+		"account": map[string]interface{}{
+			"address": bech32Address,
+			"nonce":   node.Nonce(bech32Address),
+			"balance": 0,
+		},
+	}, "")
+}
+
+func (node *FakeKleverNode) serveEstimateFee(w http.ResponseWriter, r *http.Request) {
+	_, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeNodeResponse(w, map[string]interface{}{
+		"kAppFee":       1,
+		"bandwidthFee":  1,
+		"gasEstimated":  1,
+		"gasMultiplier": 1,
+		"returnMessage": "OK",
+	}, "")
+}
+
+func (node *FakeKleverNode) serveBroadcast(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var broadcast struct {
+		Tx  json.RawMessage   `json:"tx"`
+		Txs []json.RawMessage `json:"txs"`
+	}
+	err = json.Unmarshal(body, &broadcast)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	isBulk := broadcast.Tx == nil
+	rawTransactions := broadcast.Txs
+	if !isBulk {
+		rawTransactions = []json.RawMessage{broadcast.Tx}
+	}
+
+	node.mut.Lock()
+	defer node.mut.Unlock()
+
+	if len(node.broadcastErr) > 0 {
+		writeNodeResponse(w, nil, node.broadcastErr)
+		return
+	}
+
+	hashes := make([]string, 0, len(rawTransactions))
+	for _, rawTx := range rawTransactions {
+		tx, errDecode := decodeTransaction(rawTx)
+		if errDecode != nil {
+			writeNodeResponse(w, nil, errDecode.Error())
+			return
+		}
+
+		currentNonce := node.nonces[tx.Sender]
+		if tx.Nonce < currentNonce {
+			writeNodeResponse(w, nil, fmt.Sprintf("nonce too low: %d, account nonce %d", tx.Nonce, currentNonce))
+			return
+		}
+
+		node.nonces[tx.Sender] = tx.Nonce + 1
+		node.transactions = append(node.transactions, tx)
+		hashes = append(hashes, tx.Hash)
+
+		log.Debug("fake node received transaction", "hash", tx.Hash, "sender", tx.Sender, "nonce", tx.Nonce,
+			"data", string(tx.Data))
+	}
+
+	if isBulk {
+		writeNodeResponse(w, map[string]interface{}{"txHashes": hashes}, "")
+		return
+	}
+
+	writeNodeResponse(w, map[string]interface{}{"txHash": hashes[0], "txCount": 1}, "")
+}
+
+// decodeTransaction extracts the fields checked by the tests from a JSON transaction. The field names are matched
+// ignoring the case, so the decoding does not depend on the JSON tags of the transaction structures
+func decodeTransaction(rawTx json.RawMessage) (*SentTransaction, error) {
+	tx := make(map[string]json.RawMessage)
+	err := json.Unmarshal(rawTx, &tx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTransaction, err)
+	}
+
+	rawData := make(map[string]json.RawMessage)
+	err = json.Unmarshal(fieldIgnoringCase(tx, "RawData"), &rawData)
+	if err != nil {
+		return nil, fmt.Errorf("%w, raw data: %v", ErrInvalidTransaction, err)
+	}
+
+	var nonce uint64
+	nonceField := fieldIgnoringCase(rawData, "Nonce")
+	if nonceField != nil {
+		err = json.Unmarshal(nonceField, &nonce)
+		if err != nil {
+			return nil, fmt.Errorf("%w, nonce: %v", ErrInvalidTransaction, err)
+		}
+	}
+
+	var sender []byte
+	err = json.Unmarshal(fieldIgnoringCase(rawData, "Sender"), &sender)
+	if err != nil || len(sender) == 0 {
+		return nil, fmt.Errorf("%w, missing sender", ErrInvalidTransaction)
+	}
+
+	var dataFields [][]byte
+	dataField := fieldIgnoringCase(rawData, "Data")
+	if dataField != nil {
+		err = json.Unmarshal(dataField, &dataFields)
+		if err != nil {
+			return nil, fmt.Errorf("%w, data: %v", ErrInvalidTransaction, err)
+		}
+	}
+
+	senderAddress, err := address.NewAddressFromBytes(sender)
+	if err != nil {
+		return nil, fmt.Errorf("%w, sender: %v", ErrInvalidTransaction, err)
+	}
+
+	hash := sha256.Sum256(rawTx)
+	sentTx := &SentTransaction{
+		Hash:       hex.EncodeToString(hash[:]),
+		Sender:     senderAddress.Bech32(),
+		Nonce:      nonce,
+		ReceivedAt: time.Now(),
+		Raw:        rawTx,
+	}
+	if len(dataFields) > 0 {
+		sentTx.Data = dataFields[0]
+		// the transactions that are not submitBatch calls are recorded without decoded price changes
+		sentTx.PriceChanges, _ = DecodeSubmitBatch(sentTx.Data)
+	}
+
+	err = decodeSignedTransaction(rawTx, sentTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return sentTx, nil
+}
+
+// decodeSignedTransaction decodes the transaction the way the Klever node does, recording the invoked contract, the
+// signatures and the hash they sign
+func decodeSignedTransaction(rawTx json.RawMessage, sentTx *SentTransaction) error {
+	tx := &transaction.Transaction{}
+	err := json.Unmarshal(rawTx, tx)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidTransaction, err)
+	}
+
+	for _, contract := range tx.GetRawData().GetContract() {
+		if contract.GetType() != transaction.TXContract_SmartContractType {
+			continue
+		}
+		smartContract := &transaction.SmartContract{}
+		err = anypb.UnmarshalTo(contract.GetParameter(), smartContract, proto.UnmarshalOptions{})
+		if err != nil {
+			return fmt.Errorf("%w, smart contract: %v", ErrInvalidTransaction, err)
+		}
+		contractAddress, errAddress := address.NewAddressFromBytes(smartContract.GetAddress())
+		if errAddress != nil {
+			return fmt.Errorf("%w, smart contract address: %v", ErrInvalidTransaction, errAddress)
+		}
+		sentTx.Contract = contractAddress.Bech32()
+	}
+
+	rawData, err := marshal.NewProtoMarshalizer().Marshal(tx.GetRawData())
+	if err != nil {
+		return fmt.Errorf("%w, raw data: %v", ErrInvalidTransaction, err)
+	}
+	hasher, err := factoryHasher.NewHasher("blake2b")
+	if err != nil {
+		return err
+	}
+	sentTx.SignedHash = hasher.Compute(string(rawData))
+	sentTx.Signatures = tx.GetSignature()
+
+	return nil
+}
+
+func fieldIgnoringCase(fields map[string]json.RawMessage, name string) json.RawMessage {
+	for key, value := range fields {
+		if strings.EqualFold(strings.ReplaceAll(key, "_", ""), name) {
+			return value
+		}
+	}
+
+	return nil
+}
+
+// EncodeTransactionJSON builds a broadcast request body of an unsigned transaction, as marshalled by the Klever SDK,
+// useful to drive the fake node without a wallet
+func EncodeTransactionJSON(sender []byte, nonce uint64, data []byte) []byte {
+	buff, _ := json.Marshal(map[string]interface{}{
+		"tx": map[string]interface{}{
+			"RawData": map[string]interface{}{
+				"Nonce":  nonce,
+				"Sender": base64.StdEncoding.EncodeToString(sender),
+				"Data":   []string{base64.StdEncoding.EncodeToString(data)},
+			},
+		},
+	})
+
+	return buff
+}
+
+func writeNodeResponse(w http.ResponseWriter, data interface{}, errMessage string) {
+	code := successfulCode
+	if len(errMessage) > 0 {
+		code = "internal_issue"
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data":  data,
+		"error": errMessage,
+		"code":  code,
+	})
+}
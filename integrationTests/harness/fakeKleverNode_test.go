@@ -0,0 +1,88 @@
+package harness
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSender, _ = hex.DecodeString("29fb51e57e91e10b26c15e8939625e3127fb8d55f4414262f200561a4099f5cb")
+
+const testSenderAddress = "klv198a4ret7j8sskfkpt6ynjcj7xynlhr2473q5ychjqptp5sye7h9slk9fn2"
+
+func postJSON(t *testing.T, url string, body []byte) map[string]interface{} {
+	resp, err := http.Post(url, "application/json", bytes.NewReader(body))
+	require.Nil(t, err)
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	response := make(map[string]interface{})
+	require.Nil(t, json.NewDecoder(resp.Body).Decode(&response))
+
+	return response
+}
+
+func TestSubmitBatch_Decode(t *testing.T) {
+	t.Parallel()
+
+	priceChanges := []*aggregator.ArgsPriceChanged{
+		{Base: "BTC", Quote: "USD", DenominatedPrice: 6000012, Decimals: 2, Timestamp: 1700000000},
+		{Base: "ETH", Quote: "USD", DenominatedPrice: 0, Decimals: 0, Timestamp: 1700000000},
+	}
+	data := []byte("submitBatch@425443@555344@6553f100@5b8d8c@02@455448@555344@6553f100@00@00")
+
+	decoded, err := DecodeSubmitBatch(data)
+	require.Nil(t, err)
+	assert.Equal(t, priceChanges, decoded)
+
+	_, err = DecodeSubmitBatch([]byte("transfer@00"))
+	assert.ErrorIs(t, err, ErrInvalidSubmitBatch)
+	_, err = DecodeSubmitBatch([]byte("submitBatch@425443@555344"))
+	assert.ErrorIs(t, err, ErrInvalidSubmitBatch)
+	_, err = DecodeSubmitBatch([]byte("submitBatch@zz@555344@00@00@00"))
+	assert.ErrorIs(t, err, ErrInvalidSubmitBatch)
+}
+
+func TestFakeKleverNode_BroadcastRecordsTheTransactionsAndIncrementsTheNonce(t *testing.T) {
+	t.Parallel()
+
+	node := NewFakeKleverNode()
+	defer node.Close()
+
+	sender := testSenderAddress
+	node.SetNonce(sender, 7)
+	priceChanges := []*aggregator.ArgsPriceChanged{
+		{Base: "BTC", Quote: "USD", DenominatedPrice: 6000012, Decimals: 2, Timestamp: 1700000000},
+	}
+	data := []byte("submitBatch@425443@555344@6553f100@5b8d8c@02")
+
+	response := postJSON(t, node.URL()+"/transaction/broadcast", EncodeTransactionJSON(testSender, 7, data))
+	assert.Equal(t, "", response["error"])
+	assert.Equal(t, uint64(8), node.Nonce(sender))
+
+	// a replayed nonce is rejected
+	response = postJSON(t, node.URL()+"/transaction/broadcast", EncodeTransactionJSON(testSender, 7, data))
+	assert.Contains(t, response["error"], "nonce too low")
+
+	transactions, err := node.WaitForTransactions(1, time.Second)
+	require.Nil(t, err)
+	require.Len(t, transactions, 1)
+	assert.Equal(t, sender, transactions[0].Sender)
+	assert.Equal(t, uint64(7), transactions[0].Nonce)
+	assert.Equal(t, priceChanges, transactions[0].PriceChanges)
+	assert.Equal(t, priceChanges, node.PriceChanges())
+	assert.Empty(t, transactions[0].Contract)
+	assert.Empty(t, transactions[0].Signatures)
+
+	_, err = node.WaitForTransactions(2, 50*time.Millisecond)
+	assert.ErrorIs(t, err, ErrTimeout)
+}
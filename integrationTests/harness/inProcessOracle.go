@@ -0,0 +1,176 @@
+package harness
+
+import (
+	"context"
+	"time"
+
+	"github.com/klever-io/klv-bridge-eth-go/clients/klever/blockchain/address"
+	nonceHandler "github.com/klever-io/klv-bridge-eth-go/clients/klever/interactors/nonceHandlerV2"
+	"github.com/klever-io/klv-bridge-eth-go/clients/klever/proxy"
+	"github.com/klever-io/klv-bridge-eth-go/clients/klever/proxy/models"
+	"github.com/klever-io/klv-oracles-go/aggregator"
+	"github.com/klever-io/klv-oracles-go/aggregator/fetchers"
+	gas "github.com/klever-io/klv-oracles-go/aggregator/gasStation"
+	"github.com/klever-io/klv-oracles-go/aggregator/notifees"
+	"github.com/klever-io/klv-oracles-go/tools/wallet"
+)
+
+const (
+	requestTimeout           = 5 * time.Second
+	proxyCacheExpirationTime = time.Minute
+	intervalToResend         = time.Minute
+)
+
+// ArgsInProcessOracle is the argument DTO used to create an in-process oracle
+type ArgsInProcessOracle struct {
+	Exchanges *FakeExchanges
+	Node      *FakeKleverNode
+	// Wallet signs the transactions sent to the aggregator contract, given by its bech32 address
+	Wallet           wallet.Wallet
+	ContractAddress  string
+	Pairs            []*aggregator.ArgsPair
+	QuoteMappings    map[string]map[string]string
+	MinResultsNum    int
+	AutoSendInterval time.Duration
+	// HeartbeatCoalesceWindow anticipates the heartbeats due soon when a transaction is sent anyway
+	HeartbeatCoalesceWindow time.Duration
+	// TimeHandler drives the timestamps and the heartbeats, the wall clock is used when nil
+	TimeHandler func() time.Time
+}
+
+// InProcessOracle is the oracle pipeline wired like cmd/oracle: the real fetchers, aggregator, notifier and Klever
+// notifee, querying the fake exchanges and sending the signed transactions to the fake Klever node. Each Execute call
+// is one polling round. Close must be called at the end of the test
+type InProcessOracle struct {
+	notifier     executor
+	nonceHandler notifees.ClosableNonceHandler
+}
+
+type executor interface {
+	Execute(ctx context.Context) error
+}
+
+// NewInProcessOracle creates a new InProcessOracle instance
+func NewInProcessOracle(args ArgsInProcessOracle) (*InProcessOracle, error) {
+	responseGetter, err := aggregator.NewHttpResponseGetter(aggregator.ArgsHttpResponseGetter{
+		Transport: args.Exchanges.Transport(),
+		Timeout:   requestTimeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	priceFetchers := make([]aggregator.PriceFetcher, 0, len(args.Exchanges.Exchanges()))
+	for _, exchange := range args.Exchanges.Exchanges() {
+		priceFetcher, errCreate := fetchers.NewPriceFetcher(fetchers.ArgsPriceFetcher{
+			FetcherName:    exchange,
+			ResponseGetter: responseGetter,
+			QuoteMappings:  args.QuoteMappings[exchange],
+		})
+		if errCreate != nil {
+			return nil, errCreate
+		}
+
+		for _, pair := range args.Pairs {
+			_, found := pair.Exchanges[exchange]
+			if found {
+				priceFetcher.AddPair(pair.Base, pair.Quote)
+			}
+		}
+		priceFetchers = append(priceFetchers, priceFetcher)
+	}
+
+	priceAggregator, err := aggregator.NewPriceAggregator(aggregator.ArgsPriceAggregator{
+		PriceFetchers: priceFetchers,
+		MinResultsNum: args.MinResultsNum,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	nodeProxy, err := proxy.NewProxy(proxy.ArgsProxy{
+		ProxyURL:            args.Node.URL(),
+		CacheExpirationTime: proxyCacheExpirationTime,
+		EntityType:          models.ObserverNode,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	contractAddress, err := address.NewAddress(args.ContractAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	txNonceHandler, err := notifees.NewResettableNonceHandler(func() (notifees.ClosableNonceHandler, error) {
+		return nonceHandler.NewNonceTransactionHandlerV2(nonceHandler.ArgsNonceTransactionsHandlerV2{
+			Proxy:            nodeProxy,
+			IntervalToResend: intervalToResend,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	kcNotifee, err := notifees.NewKCNotifee(notifees.ArgsKCNotifee{
+		Proxy: nodeProxy,
+		Keys: []notifees.OracleKey{
+			{
+				Wallet:         args.Wallet,
+				TxNonceHandler: txNonceHandler,
+			},
+		},
+		ContractAddress: contractAddress,
+	})
+	if err != nil {
+		_ = txNonceHandler.Close()
+		return nil, err
+	}
+
+	notifier, err := aggregator.NewPriceNotifier(aggregator.ArgsPriceNotifier{
+		Pairs:                   args.Pairs,
+		Aggregator:              priceAggregator,
+		GasPriceService:         &noGasPriceService{},
+		Notifee:                 kcNotifee,
+		AutoSendInterval:        args.AutoSendInterval,
+		HeartbeatCoalesceWindow: args.HeartbeatCoalesceWindow,
+		TimeHandler:             args.TimeHandler,
+	})
+	if err != nil {
+		_ = txNonceHandler.Close()
+		return nil, err
+	}
+
+	return &InProcessOracle{
+		notifier:     notifier,
+		nonceHandler: txNonceHandler,
+	}, nil
+}
+
+// Execute runs one polling round
+func (oracle *InProcessOracle) Execute(ctx context.Context) error {
+	return oracle.notifier.Execute(ctx)
+}
+
+// Close stops the nonce handler of the oracle key
+func (oracle *InProcessOracle) Close() {
+	_ = oracle.nonceHandler.Close()
+}
+
+// noGasPriceService leaves the prices unchanged, the in-process oracle does not publish gas price pairs
+type noGasPriceService struct{}
+
+// ConvertGasPrices returns the provided pairs
+func (service *noGasPriceService) ConvertGasPrices(_ context.Context, pairs []gas.ArgsPairInfo) ([]gas.ArgsPairInfo, error) {
+	return pairs, nil
+}
+
+// VerifyRequiredPairs returns nil
+func (service *noGasPriceService) VerifyRequiredPairs(_ []gas.ArgsPairInfo) error {
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (service *noGasPriceService) IsInterfaceNil() bool {
+	return service == nil
+}
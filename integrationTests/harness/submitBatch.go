@@ -0,0 +1,52 @@
+package harness
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+)
+
+const (
+	submitBatchFunction = "submitBatch"
+	dataSeparator       = "@"
+	argsPerPriceChange  = 5
+)
+
+// DecodeSubmitBatch decodes the data field of a submitBatch call into the price changes it carries. The arguments are
+// base@quote@timestamp@price@decimals for each change, all of them hex encoded
+func DecodeSubmitBatch(data []byte) ([]*aggregator.ArgsPriceChanged, error) {
+	parts := strings.Split(string(data), dataSeparator)
+	if parts[0] != submitBatchFunction {
+		return nil, fmt.Errorf("%w, function %q", ErrInvalidSubmitBatch, parts[0])
+	}
+
+	args := parts[1:]
+	if len(args) == 0 || len(args)%argsPerPriceChange != 0 {
+		return nil, fmt.Errorf("%w, %d arguments", ErrInvalidSubmitBatch, len(args))
+	}
+
+	decoded := make([][]byte, 0, len(args))
+	for idx, arg := range args {
+		value, err := hex.DecodeString(arg)
+		if err != nil {
+			return nil, fmt.Errorf("%w, argument %d: %v", ErrInvalidSubmitBatch, idx, err)
+		}
+		decoded = append(decoded, value)
+	}
+
+	priceChanges := make([]*aggregator.ArgsPriceChanged, 0, len(decoded)/argsPerPriceChange)
+	for i := 0; i < len(decoded); i += argsPerPriceChange {
+		priceChanges = append(priceChanges, &aggregator.ArgsPriceChanged{
+			Base:             string(decoded[i]),
+			Quote:            string(decoded[i+1]),
+			Timestamp:        new(big.Int).SetBytes(decoded[i+2]).Int64(),
+			DenominatedPrice: new(big.Int).SetBytes(decoded[i+3]).Uint64(),
+			Decimals:         new(big.Int).SetBytes(decoded[i+4]).Uint64(),
+		})
+	}
+
+	return priceChanges, nil
+}
@@ -0,0 +1,160 @@
+//go:build integration
+
+package oracle
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klever-io/klv-oracles-go/aggregator/fetchers"
+	"github.com/klever-io/klv-oracles-go/integrationTests/harness"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	binaryTimeout   = 30 * time.Second
+	ethSources      = `["Binance", "Coinbase", "Kraken"]`
+	gasStationPair  = "EVM gas price station when using selector SafeGasPrice"
+	oracleConfigTpl = `[GeneralConfig]
+    NetworkAddress = "%s"
+    GasStationAPI = "%s"
+    PrivateKeyFile = "%s"
+    IntervalToResendTxsInSeconds = 60
+    ProxyCacherExpirationSeconds = 600
+    AggregatorContractAddress = "klv1qqqqqqqqqqqqqpgqvzsgch5appevk26vuz3w6cwp2mh84ugsk3cs4pvvqn"
+    BaseGasLimit = 25000000
+    GasLimitForEach = 2000000
+    MinResultsNum = 2
+    PollIntervalInSeconds = 1
+    AutoSendIntervalInSeconds = %d
+    ProxyRestAPIEntityType = "observer"
+    ProxyFinalityCheck = false
+    ProxyMaxNoncesDelta = 7
+
+[AuthenticationConfig]
+    TokenExpiryInSeconds = 86400
+    Host = "oracle"
+
+[HTTPClient]
+    TimeoutInMilliseconds = 2000
+    ProxyURL = "%s"
+    CACertFile = "%s"
+
+[[Pairs]]
+    Base = "ETH"
+    Quote = "USD"
+    PercentDifferenceToNotify = 1
+    Decimals = 4
+    Exchanges = %s
+
+[[GasStationPair]]
+    Quote = "USD"
+    PercentDifferenceToNotify = 1
+    Decimals = 9
+    Exchanges = ["%s"]
+`
+)
+
+// runningOracle is a cmd/oracle process querying the fake exchanges through their proxy and sending the
+// transactions to the fake Klever node
+type runningOracle struct {
+	exchanges *harness.FakeExchanges
+	node      *harness.FakeKleverNode
+}
+
+func buildOracle(t *testing.T) string {
+	binary := filepath.Join(t.TempDir(), "oracle")
+	cmd := exec.Command("go", "build", "-o", binary, ".")
+	cmd.Dir = filepath.Join("..", "..", "cmd", "oracle")
+	output, err := cmd.CombinedOutput()
+	require.Nil(t, err, string(output))
+
+	return binary
+}
+
+func startOracle(t *testing.T, autoSendIntervalInSeconds int) *runningOracle {
+	exchanges, err := harness.NewFakeExchanges()
+	require.Nil(t, err)
+	node := harness.NewFakeKleverNode()
+
+	workingDir := t.TempDir()
+	caFile := filepath.Join(workingDir, "ca.pem")
+	require.Nil(t, exchanges.WriteCACertificate(caFile))
+	keyFile, err := filepath.Abs(filepath.Join("..", "..", "cmd", "oracle", "keys", "oracle.pem"))
+	require.Nil(t, err)
+
+	configFile := filepath.Join(workingDir, "config.toml")
+	configContent := fmt.Sprintf(oracleConfigTpl, node.URL(), exchanges.GasStationURL(), keyFile,
+		autoSendIntervalInSeconds, exchanges.ProxyURL(), caFile, ethSources, gasStationPair)
+	require.Nil(t, os.WriteFile(configFile, []byte(configContent), 0644))
+
+	ctx, cancel := context.WithTimeout(context.Background(), binaryTimeout)
+	cmd := exec.CommandContext(ctx, buildOracle(t),
+		"--config", configFile,
+		"--working-directory", workingDir,
+		"--rest-api-interface", "localhost:0",
+		"--log-level", "*:DEBUG",
+	)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	oracle := &runningOracle{
+		exchanges: exchanges,
+		node:      node,
+	}
+	oracle.setETHPrice(3000)
+	exchanges.SetGasPrices(harness.GasPrices{Safe: 20, Propose: 25, Fast: 30})
+
+	require.Nil(t, cmd.Start())
+	t.Cleanup(func() {
+		cancel()
+		_ = cmd.Wait()
+		exchanges.Close()
+		node.Close()
+	})
+
+	return oracle
+}
+
+func (oracle *runningOracle) setETHPrice(price float64) {
+	for _, exchange := range []string{fetchers.BinanceName, fetchers.CoinbaseName, fetchers.KrakenName} {
+		_ = oracle.exchanges.SetPrice(exchange, "ETH", "USD", price)
+	}
+}
+
+func TestOracleBinary_DeviationAndOutage(t *testing.T) {
+	oracle := startOracle(t, 3600)
+
+	transactions, err := oracle.node.WaitForTransactions(1, binaryTimeout)
+	require.Nil(t, err)
+	require.Len(t, transactions[0].PriceChanges, 2)
+	assert.Equal(t, "ETH", transactions[0].PriceChanges[0].Base)
+	assert.Equal(t, uint64(30000000), transactions[0].PriceChanges[0].DenominatedPrice)
+	assert.Equal(t, "GWEI", transactions[0].PriceChanges[1].Base)
+
+	// one source down, the two others are enough for the 5% move to be submitted
+	require.Nil(t, oracle.exchanges.SetOutage(fetchers.KrakenName, 503))
+	oracle.setETHPrice(3150)
+	transactions, err = oracle.node.WaitForTransactions(2, binaryTimeout)
+	require.Nil(t, err)
+	assert.Equal(t, uint64(1), transactions[1].Nonce)
+	assert.Equal(t, transactions[0].Sender, transactions[1].Sender)
+	assert.Equal(t, uint64(31500000), transactions[1].PriceChanges[0].DenominatedPrice)
+}
+
+func TestOracleBinary_Heartbeats(t *testing.T) {
+	oracle := startOracle(t, 2)
+
+	transactions, err := oracle.node.WaitForTransactions(3, binaryTimeout)
+	require.Nil(t, err)
+	for idx, tx := range transactions {
+		assert.Equal(t, uint64(idx), tx.Nonce)
+		assert.Equal(t, uint64(30000000), tx.PriceChanges[0].DenominatedPrice)
+	}
+}
@@ -0,0 +1,197 @@
+package oracle
+
+import (
+	"context"
+	"crypto/ed25519"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+	"github.com/klever-io/klv-oracles-go/aggregator/fetchers"
+	"github.com/klever-io/klv-oracles-go/integrationTests/harness"
+	"github.com/klever-io/klv-oracles-go/tools/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const aggregatorContract = "klv1qqqqqqqqqqqqqpgqvzsgch5appevk26vuz3w6cwp2mh84ugsk3cs4pvvqn"
+
+var (
+	oracleSeed      = []byte("oracle-private-key-seed-32-bytes")
+	scenarioStart   = time.Unix(1700000000, 0)
+	scenarioSources = []string{fetchers.BinanceName, fetchers.KrakenName, fetchers.OkxName}
+)
+
+type scenario struct {
+	t         *testing.T
+	exchanges *harness.FakeExchanges
+	node      *harness.FakeKleverNode
+	wallet    wallet.Wallet
+	oracle    *harness.InProcessOracle
+	now       time.Time
+	// lastSubmission is the round time of the last transaction checked
+	lastSubmission time.Time
+	numChecked     int
+}
+
+func newScenario(t *testing.T, minResultsNum int, autoSendInterval time.Duration) *scenario {
+	exchanges, err := harness.NewFakeExchanges()
+	require.Nil(t, err)
+	node := harness.NewFakeKleverNode()
+	t.Cleanup(func() {
+		exchanges.Close()
+		node.Close()
+	})
+
+	oracleWallet, err := wallet.NewWallet(oracleSeed)
+	require.Nil(t, err)
+
+	s := &scenario{
+		t:         t,
+		exchanges: exchanges,
+		node:      node,
+		wallet:    oracleWallet,
+		now:       scenarioStart,
+	}
+	s.oracle, err = harness.NewInProcessOracle(harness.ArgsInProcessOracle{
+		Exchanges:       exchanges,
+		Node:            node,
+		Wallet:          oracleWallet,
+		ContractAddress: aggregatorContract,
+		Pairs: []*aggregator.ArgsPair{
+			{
+				Base:                      "BTC",
+				Quote:                     "USDT",
+				PercentDifferenceToNotify: 1,
+				Decimals:                  2,
+				Exchanges:                 map[string]struct{}{fetchers.BinanceName: {}, fetchers.KrakenName: {}, fetchers.OkxName: {}},
+			},
+		},
+		MinResultsNum:    minResultsNum,
+		AutoSendInterval: autoSendInterval,
+		TimeHandler: func() time.Time {
+			return s.now
+		},
+	})
+	require.Nil(t, err)
+	t.Cleanup(s.oracle.Close)
+
+	return s
+}
+
+func (s *scenario) setPrices(prices ...float64) {
+	for idx, exchange := range scenarioSources {
+		require.Nil(s.t, s.exchanges.SetPrice(exchange, "BTC", "USDT", prices[idx]))
+	}
+}
+
+func (s *scenario) round(elapsed time.Duration) error {
+	s.now = s.now.Add(elapsed)
+
+	return s.oracle.Execute(context.Background())
+}
+
+func (s *scenario) requireLastPrice(numTransactions int, denominatedPrice uint64) {
+	transactions := s.node.Transactions()
+	require.Len(s.t, transactions, numTransactions)
+	if numTransactions > s.numChecked {
+		s.lastSubmission = s.now
+		s.numChecked = numTransactions
+	}
+
+	lastTx := transactions[len(transactions)-1]
+	require.Len(s.t, lastTx.PriceChanges, 1)
+	assert.Equal(s.t, &aggregator.ArgsPriceChanged{
+		Base:             "BTC",
+		Quote:            "USDT",
+		DenominatedPrice: denominatedPrice,
+		Decimals:         2,
+		Timestamp:        s.lastSubmission.Unix(),
+	}, lastTx.PriceChanges[0])
+	assert.Equal(s.t, uint64(numTransactions-1), lastTx.Nonce)
+	s.requireSignedByOracle(lastTx)
+}
+
+// requireSignedByOracle checks the broadcast transaction, as decoded by the node, is the oracle's submitBatch call
+func (s *scenario) requireSignedByOracle(tx *harness.SentTransaction) {
+	oracleAddress, err := s.wallet.Address()
+	require.Nil(s.t, err)
+
+	assert.Equal(s.t, oracleAddress.Bech32(), tx.Sender)
+	assert.Equal(s.t, aggregatorContract, tx.Contract)
+	require.Len(s.t, tx.Signatures, 1)
+	assert.True(s.t, ed25519.Verify(s.wallet.PublicKey(), tx.SignedHash, tx.Signatures[0]))
+}
+
+func TestScenario_DeviationTriggersTheSubmission(t *testing.T) {
+	t.Parallel()
+
+	s := newScenario(t, 3, time.Hour)
+	s.setPrices(60000, 60010, 59990.55)
+
+	require.Nil(t, s.round(time.Second))
+	s.requireLastPrice(1, 6000000)
+
+	// the median moved 0.5%, under the 1% threshold
+	s.setPrices(60300, 60310, 60290)
+	require.Nil(t, s.round(time.Second))
+	s.requireLastPrice(1, 6000000)
+
+	// the median moved 1.5% from the last submitted price
+	s.setPrices(60900, 60910, 60890)
+	require.Nil(t, s.round(time.Second))
+	s.requireLastPrice(2, 6090000)
+
+	s.setPrices(59000, 59010, 58990)
+	require.Nil(t, s.round(time.Second))
+	s.requireLastPrice(3, 5900000)
+}
+
+func TestScenario_ExchangeOutage(t *testing.T) {
+	t.Parallel()
+
+	s := newScenario(t, 2, time.Hour)
+	s.setPrices(60000, 61000, 62000)
+	require.Nil(t, s.exchanges.SetOutage(fetchers.KrakenName, http.StatusServiceUnavailable))
+
+	// the two remaining sources are enough, the median is their mean
+	require.Nil(t, s.round(time.Second))
+	s.requireLastPrice(1, 6100000)
+
+	require.Nil(t, s.exchanges.SetOutage(fetchers.OkxName, http.StatusBadGateway))
+	s.setPrices(65000, 65000, 65000)
+	err := s.round(time.Second)
+	assert.ErrorIs(t, err, aggregator.ErrNotEnoughResponses)
+	s.requireLastPrice(1, 6100000)
+	assert.True(t, s.exchanges.NumRequests(fetchers.KrakenName) >= 2)
+
+	require.Nil(t, s.exchanges.SetOutage(fetchers.KrakenName, 0))
+	require.Nil(t, s.exchanges.SetOutage(fetchers.OkxName, 0))
+	require.Nil(t, s.round(time.Second))
+	s.requireLastPrice(2, 6500000)
+}
+
+func TestScenario_Heartbeats(t *testing.T) {
+	t.Parallel()
+
+	s := newScenario(t, 3, time.Minute)
+	s.setPrices(60000, 60000, 60000)
+
+	require.Nil(t, s.round(time.Second))
+	s.requireLastPrice(1, 6000000)
+
+	// stable prices are not submitted again before the auto send interval elapses
+	for i := 0; i < 5; i++ {
+		require.Nil(t, s.round(10*time.Second))
+	}
+	s.requireLastPrice(1, 6000000)
+
+	require.Nil(t, s.round(15*time.Second))
+	s.requireLastPrice(2, 6000000)
+
+	require.Nil(t, s.round(30*time.Second))
+	s.requireLastPrice(2, 6000000)
+	require.Nil(t, s.round(31*time.Second))
+	s.requireLastPrice(3, 6000000)
+}
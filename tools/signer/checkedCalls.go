@@ -0,0 +1,90 @@
+package signer
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/klever-io/klv-oracles-go/tools/wallet"
+)
+
+// checkedCallTTL bounds the time between the check of a call and the signing of its transaction
+const checkedCallTTL = time.Minute
+
+type checkedCall struct {
+	fee       uint64
+	checkedAt time.Time
+}
+
+// checkedCalls remembers the calls accepted by the policy before their transaction is built. Once the nonce is applied
+// the transaction is only matched against its checked call, so no network check or fee cap can reject it anymore
+type checkedCalls struct {
+	mut   sync.Mutex
+	calls map[string]checkedCall
+}
+
+func newCheckedCalls() *checkedCalls {
+	return &checkedCalls{
+		calls: make(map[string]checkedCall),
+	}
+}
+
+// add records the call accepted by the policy, forgetting the expired ones
+func (cc *checkedCalls) add(request *wallet.SignRequest) {
+	key := callKey(request)
+	now := time.Now()
+
+	cc.mut.Lock()
+	defer cc.mut.Unlock()
+
+	for callKey, call := range cc.calls {
+		if now.Sub(call.checkedAt) > checkedCallTTL {
+			delete(cc.calls, callKey)
+		}
+	}
+	cc.calls[key] = checkedCall{
+		fee:       request.Fee,
+		checkedAt: now,
+	}
+}
+
+// consume accepts the decoded call of a transaction matching a checked call, with a fee not above the checked one.
+// The matched call is forgotten, so each check allows a single signature
+func (cc *checkedCalls) consume(request *wallet.SignRequest) error {
+	key := callKey(request)
+
+	cc.mut.Lock()
+	defer cc.mut.Unlock()
+
+	call, found := cc.calls[key]
+	if !found || time.Since(call.checkedAt) > checkedCallTTL {
+		return fmt.Errorf("%w, the %s call of %s was not checked beforehand", ErrSignRequestRejected,
+			request.Function, request.Contract)
+	}
+	if request.Fee > call.fee {
+		return fmt.Errorf("%w, fee %d over the checked fee %d", ErrSignRequestRejected, request.Fee, call.fee)
+	}
+	delete(cc.calls, key)
+
+	return nil
+}
+
+func callKey(request *wallet.SignRequest) string {
+	args := request.Args
+	if len(args) == 0 {
+		args = nil
+	}
+
+	key, _ := json.Marshal(struct {
+		Contract string
+		Function string
+		Args     []wallet.SubmitBatchArg
+	}{
+		Contract: request.Contract,
+		Function: request.Function,
+		Args:     args,
+	})
+
+	return string(key)
+}
@@ -0,0 +1,26 @@
+package signer
+
+import "errors"
+
+var (
+	// ErrInvalidRemoteSignerConfig signals that an invalid remote signer configuration was provided
+	ErrInvalidRemoteSignerConfig = errors.New("invalid remote signer configuration")
+	// ErrRemoteSignerFailure signals that the remote signer did not sign the request
+	ErrRemoteSignerFailure = errors.New("remote signer failure")
+	// ErrInvalidSignature signals that the remote signer returned a signature not matching its public key
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrInvalidTransaction signals that the raw transaction could not be decoded as a smart contract call
+	ErrInvalidTransaction = errors.New("invalid transaction")
+	// ErrSignRequestRejected signals that the signing policy rejected the request
+	ErrSignRequestRejected = errors.New("sign request rejected")
+	// ErrNilWallet signals that a nil wallet was provided
+	ErrNilWallet = errors.New("nil wallet")
+	// ErrNilSignPolicy signals that a nil signing policy was provided
+	ErrNilSignPolicy = errors.New("nil sign policy")
+	// ErrInvalidSignPolicy signals that an invalid signing policy configuration was provided
+	ErrInvalidSignPolicy = errors.New("invalid sign policy")
+	// ErrNilReferencePriceProvider signals that a nil reference price provider was provided
+	ErrNilReferencePriceProvider = errors.New("nil reference price provider")
+	// ErrNilAlerter signals that a nil alerter was provided
+	ErrNilAlerter = errors.New("nil alerter")
+)
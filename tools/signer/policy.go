@@ -0,0 +1,208 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+	"github.com/klever-io/klv-oracles-go/tools/wallet"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+)
+
+// SubmitBatchFunction is the function of the aggregator contract the oracle calls
+const SubmitBatchFunction = "submitBatch"
+
+// NewSubmitBatchPolicy returns the policy only signing the submitBatch calls of the aggregator contract, given by its
+// bech32 address. The signed raw transaction is decoded and matched against the checked call, so the policy holds
+// against a compromised oracle
+func NewSubmitBatchPolicy(contractAddress string) (SignPolicy, error) {
+	if len(contractAddress) == 0 {
+		return nil, fmt.Errorf("%w, empty aggregator contract address", ErrInvalidRemoteSignerConfig)
+	}
+
+	return func(_ context.Context, request *wallet.SignRequest) error {
+		if request.Contract != contractAddress {
+			return fmt.Errorf("%w, destination %q is not the aggregator contract", ErrSignRequestRejected, request.Contract)
+		}
+		if request.Function != SubmitBatchFunction {
+			return fmt.Errorf("%w, function %q is not %s", ErrSignRequestRejected, request.Function, SubmitBatchFunction)
+		}
+		if len(request.Args) == 0 {
+			return fmt.Errorf("%w, no price changes", ErrSignRequestRejected)
+		}
+
+		return nil
+	}, nil
+}
+
+// NewPairsCountPolicy returns the policy only signing the batches holding between minPairs and maxPairs price changes
+func NewPairsCountPolicy(minPairs int, maxPairs int) (SignPolicy, error) {
+	if minPairs < 1 || maxPairs < minPairs {
+		return nil, fmt.Errorf("%w, invalid pairs bounds [%d, %d]", ErrInvalidSignPolicy, minPairs, maxPairs)
+	}
+
+	return func(_ context.Context, request *wallet.SignRequest) error {
+		numPairs := len(request.Args)
+		if numPairs < minPairs || numPairs > maxPairs {
+			return fmt.Errorf("%w, %d price changes out of the bounds [%d, %d]",
+				ErrSignRequestRejected, numPairs, minPairs, maxPairs)
+		}
+
+		return nil
+	}, nil
+}
+
+// ReferencePriceProvider defines the component able to fetch the reference prices of several pairs at once, like a
+// price aggregator built over other sources than the oracle's
+type ReferencePriceProvider interface {
+	FetchPrices(ctx context.Context, pairs []aggregator.BaseQuote) map[aggregator.BaseQuote]aggregator.PriceResult
+	IsInterfaceNil() bool
+}
+
+// ArgsReferencePricePolicy is the DTO used to create the policy comparing the prices with their references
+type ArgsReferencePricePolicy struct {
+	Provider ReferencePriceProvider
+	// Pairs are the published pairs checked against their reference. The other pairs, like the gas prices, are not
+	// checked
+	Pairs []aggregator.BaseQuote
+	// MaxDeviationPercent is the max difference, in percents of the reference, between a price and its reference
+	MaxDeviationPercent float64
+}
+
+// NewReferencePricePolicy returns the policy only signing the batches whose checked prices are within the max
+// deviation of their reference prices. A reference price that can not be fetched rejects the request
+func NewReferencePricePolicy(args ArgsReferencePricePolicy) (SignPolicy, error) {
+	if check.IfNil(args.Provider) {
+		return nil, ErrNilReferencePriceProvider
+	}
+	if len(args.Pairs) == 0 {
+		return nil, fmt.Errorf("%w, no reference pairs", ErrInvalidSignPolicy)
+	}
+	if args.MaxDeviationPercent <= 0 || math.IsInf(args.MaxDeviationPercent, 0) {
+		return nil, fmt.Errorf("%w, invalid max deviation percent %v", ErrInvalidSignPolicy, args.MaxDeviationPercent)
+	}
+
+	checkedPairs := make(map[aggregator.BaseQuote]struct{}, len(args.Pairs))
+	for _, pair := range args.Pairs {
+		checkedPairs[pair] = struct{}{}
+	}
+
+	return func(ctx context.Context, request *wallet.SignRequest) error {
+		pairs := make([]aggregator.BaseQuote, 0, len(request.Args))
+		for _, arg := range request.Args {
+			pair := aggregator.BaseQuote{Base: arg.Base, Quote: arg.Quote}
+			if _, found := checkedPairs[pair]; found {
+				pairs = append(pairs, pair)
+			}
+		}
+		if len(pairs) == 0 {
+			return nil
+		}
+
+		references := args.Provider.FetchPrices(ctx, pairs)
+		for _, arg := range request.Args {
+			pair := aggregator.BaseQuote{Base: arg.Base, Quote: arg.Quote}
+			if _, found := checkedPairs[pair]; !found {
+				continue
+			}
+
+			err := checkReferencePrice(arg, references[pair], args.MaxDeviationPercent)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, nil
+}
+
+func checkReferencePrice(arg wallet.SubmitBatchArg, reference aggregator.PriceResult, maxDeviationPercent float64) error {
+	if reference.Err != nil {
+		return fmt.Errorf("%w, no reference price for %s/%s: %s", ErrSignRequestRejected, arg.Base, arg.Quote,
+			reference.Err.Error())
+	}
+	if reference.Price <= 0 {
+		return fmt.Errorf("%w, invalid reference price %v for %s/%s", ErrSignRequestRejected, reference.Price,
+			arg.Base, arg.Quote)
+	}
+
+	price := float64(arg.Price) / math.Pow10(int(arg.Decimals))
+	deviationPercent := math.Abs(price-reference.Price) / reference.Price * 100
+	if deviationPercent > maxDeviationPercent {
+		return fmt.Errorf("%w, price %v of %s/%s deviates %.2f%% from the reference %v, max %v%%",
+			ErrSignRequestRejected, price, arg.Base, arg.Quote, deviationPercent, reference.Price, maxDeviationPercent)
+	}
+
+	return nil
+}
+
+// ArgsDailyFeeCapPolicy is the DTO used to create the policy capping the fees signed each day
+type ArgsDailyFeeCapPolicy struct {
+	// DailyFeeCap is the max sum of the fees of the transactions signed during a UTC day
+	DailyFeeCap uint64
+	// TimeHandler returns the current time. The wall clock is used when nil
+	TimeHandler func() time.Time
+}
+
+// NewDailyFeeCapPolicy returns the policy only accepting the calls while the fees accepted during the current UTC day
+// stay under the cap. The fee of each accepted call is counted, even when its transaction is not sent afterward, so
+// the policy should be the last one evaluated
+func NewDailyFeeCapPolicy(args ArgsDailyFeeCapPolicy) (SignPolicy, error) {
+	if args.DailyFeeCap == 0 {
+		return nil, fmt.Errorf("%w, zero daily fee cap", ErrInvalidSignPolicy)
+	}
+
+	timeHandler := args.TimeHandler
+	if timeHandler == nil {
+		timeHandler = time.Now
+	}
+
+	var mut sync.Mutex
+	var day time.Time
+	var spent uint64
+
+	return func(_ context.Context, request *wallet.SignRequest) error {
+		today := timeHandler().UTC().Truncate(24 * time.Hour)
+
+		mut.Lock()
+		defer mut.Unlock()
+
+		if !today.Equal(day) {
+			day = today
+			spent = 0
+		}
+		if request.Fee > args.DailyFeeCap-spent {
+			return fmt.Errorf("%w, fee %d over the daily cap %d, %d already signed today",
+				ErrSignRequestRejected, request.Fee, args.DailyFeeCap, spent)
+		}
+		spent += request.Fee
+
+		return nil
+	}, nil
+}
+
+// NewPolicies returns the policy accepting the requests accepted by all the provided policies, evaluated in order
+func NewPolicies(policies ...SignPolicy) (SignPolicy, error) {
+	if len(policies) == 0 {
+		return nil, fmt.Errorf("%w, no policies", ErrInvalidSignPolicy)
+	}
+	for _, policy := range policies {
+		if policy == nil {
+			return nil, ErrNilSignPolicy
+		}
+	}
+
+	return func(ctx context.Context, request *wallet.SignRequest) error {
+		for _, policy := range policies {
+			err := policy(ctx, request)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, nil
+}
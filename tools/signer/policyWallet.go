@@ -0,0 +1,210 @@
+package signer
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/klever-io/klv-bridge-eth-go/clients/klever/blockchain/address"
+	"github.com/klever-io/klv-oracles-go/tools/wallet"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+)
+
+// PolicyViolation describes a sign request rejected by the signing policy
+type PolicyViolation struct {
+	Time     time.Time `json:"time"`
+	TxHash   string    `json:"txHash"`
+	Contract string    `json:"contract"`
+	Function string    `json:"function"`
+	NumPairs int       `json:"numPairs"`
+	Fee      uint64    `json:"fee"`
+	Reason   string    `json:"reason"`
+}
+
+// Alerter defines a component raising an alert for each policy violation
+type Alerter interface {
+	Alert(ctx context.Context, violation *PolicyViolation)
+	IsInterfaceNil() bool
+}
+
+// ArgsPolicyWallet is the DTO used to create a new policy wallet
+type ArgsPolicyWallet struct {
+	Wallet wallet.Wallet
+	Policy SignPolicy
+	// Alerters are notified, besides the error log, of each policy violation
+	Alerters []Alerter
+}
+
+// policyWallet is a wallet only signing the transactions accepted by its policy
+type policyWallet struct {
+	wallet   wallet.Wallet
+	policy   SignPolicy
+	alerters []Alerter
+	decoder  *transactionDecoder
+	checked  *checkedCalls
+
+	mut           sync.RWMutex
+	numSigned     uint64
+	numRejected   uint64
+	lastViolation *PolicyViolation
+}
+
+// NewPolicyWallet creates a wallet checking the calls against the policy before their transaction is built, and only
+// signing the decoded raw transactions matching a checked call. The plain messages, lacking the raw transaction, are
+// refused
+func NewPolicyWallet(args ArgsPolicyWallet) (*policyWallet, error) {
+	if check.IfNil(args.Wallet) {
+		return nil, ErrNilWallet
+	}
+	if args.Policy == nil {
+		return nil, ErrNilSignPolicy
+	}
+	for _, alerter := range args.Alerters {
+		if check.IfNil(alerter) {
+			return nil, ErrNilAlerter
+		}
+	}
+
+	decoder, err := newTransactionDecoder()
+	if err != nil {
+		return nil, err
+	}
+
+	return &policyWallet{
+		wallet:   args.Wallet,
+		policy:   args.Policy,
+		alerters: args.Alerters,
+		decoder:  decoder,
+		checked:  newCheckedCalls(),
+	}, nil
+}
+
+// CheckTransaction checks the call against the policy, before its transaction is built, raising an alert when rejected
+func (pw *policyWallet) CheckTransaction(ctx context.Context, request *wallet.SignRequest) error {
+	err := pw.policy(ctx, request)
+	if err != nil {
+		pw.raiseAlert(request, err)
+		return err
+	}
+
+	signer, ok := pw.wallet.(wallet.TransactionSigner)
+	if ok {
+		err = signer.CheckTransaction(ctx, request)
+		if err != nil {
+			return err
+		}
+	}
+	pw.checked.add(request)
+
+	return nil
+}
+
+// SignTransaction decodes the raw transaction and signs its hash when its call matches a checked one, raising an alert
+// otherwise. The policy is not run again, as the nonce of the transaction is already applied
+func (pw *policyWallet) SignTransaction(ctx context.Context, rawTx []byte) ([]byte, error) {
+	request, hash, err := pw.decoder.decode(rawTx)
+	if err != nil {
+		pw.raiseAlert(&wallet.SignRequest{TxHash: hex.EncodeToString(pw.decoder.hash(rawTx))}, err)
+		return nil, err
+	}
+
+	err = pw.checked.consume(request)
+	if err != nil {
+		pw.raiseAlert(request, err)
+		return nil, err
+	}
+
+	var signature []byte
+	signer, ok := pw.wallet.(wallet.TransactionSigner)
+	if ok {
+		signature, err = signer.SignTransaction(ctx, rawTx)
+	} else {
+		signature, err = pw.wallet.Sign(hash)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pw.mut.Lock()
+	pw.numSigned++
+	pw.mut.Unlock()
+
+	return signature, nil
+}
+
+func (pw *policyWallet) raiseAlert(request *wallet.SignRequest, err error) {
+	violation := &PolicyViolation{
+		Time:     time.Now(),
+		TxHash:   request.TxHash,
+		Contract: request.Contract,
+		Function: request.Function,
+		NumPairs: len(request.Args),
+		Fee:      request.Fee,
+		Reason:   err.Error(),
+	}
+
+	pw.mut.Lock()
+	pw.numRejected++
+	pw.lastViolation = violation
+	pw.mut.Unlock()
+
+	log.Error("signing policy violation", "tx hash", violation.TxHash, "contract", violation.Contract,
+		"function", violation.Function, "num pairs", violation.NumPairs, "fee", violation.Fee, "reason", violation.Reason)
+
+	// the alerts are raised in the background so a slow alerter does not delay the oracle
+	for _, alerter := range pw.alerters {
+		go alerter.Alert(context.Background(), violation)
+	}
+}
+
+// Metrics returns the number of signed and rejected requests along with the last policy violation
+func (pw *policyWallet) Metrics() map[string]interface{} {
+	pw.mut.RLock()
+	defer pw.mut.RUnlock()
+
+	return map[string]interface{}{
+		"signed":         pw.numSigned,
+		"rejected":       pw.numRejected,
+		"last violation": pw.lastViolation,
+	}
+}
+
+// PrivateKey returns the private key of the wrapped wallet
+func (pw *policyWallet) PrivateKey() []byte {
+	return pw.wallet.PrivateKey()
+}
+
+// PublicKey returns the public key of the wrapped wallet
+func (pw *policyWallet) PublicKey() []byte {
+	return pw.wallet.PublicKey()
+}
+
+// Address returns the address of the wrapped wallet
+func (pw *policyWallet) Address() (address.Address, error) {
+	return pw.wallet.Address()
+}
+
+// Sign refuses to sign the message, as the policy can not be checked without the raw transaction
+func (pw *policyWallet) Sign(msg []byte) ([]byte, error) {
+	err := fmt.Errorf("%w, the message comes without its raw transaction", ErrSignRequestRejected)
+	pw.raiseAlert(&wallet.SignRequest{TxHash: hex.EncodeToString(msg)}, err)
+
+	return nil, err
+}
+
+// SignHex refuses to sign the message, as the policy can not be checked without the raw transaction
+func (pw *policyWallet) SignHex(msg string) ([]byte, error) {
+	data, err := hex.DecodeString(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return pw.Sign(data)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (pw *policyWallet) IsInterfaceNil() bool {
+	return pw == nil
+}
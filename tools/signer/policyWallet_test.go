@@ -0,0 +1,241 @@
+package signer_test
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/klever-io/klv-oracles-go/tools/signer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type alerterStub struct {
+	violations chan *signer.PolicyViolation
+}
+
+func (stub *alerterStub) Alert(_ context.Context, violation *signer.PolicyViolation) {
+	stub.violations <- violation
+}
+
+func (stub *alerterStub) IsInterfaceNil() bool {
+	return stub == nil
+}
+
+func createArgsPolicyWallet(t *testing.T) signer.ArgsPolicyWallet {
+	policy, _ := signer.NewSubmitBatchPolicy(testContract)
+
+	return signer.ArgsPolicyWallet{
+		Wallet: createTestWallet(t),
+		Policy: policy,
+	}
+}
+
+func TestNewPolicyWallet(t *testing.T) {
+	t.Parallel()
+
+	args := createArgsPolicyWallet(t)
+	args.Wallet = nil
+	pw, err := signer.NewPolicyWallet(args)
+	assert.Nil(t, pw)
+	assert.Equal(t, signer.ErrNilWallet, err)
+
+	args = createArgsPolicyWallet(t)
+	args.Policy = nil
+	pw, err = signer.NewPolicyWallet(args)
+	assert.Nil(t, pw)
+	assert.Equal(t, signer.ErrNilSignPolicy, err)
+
+	args = createArgsPolicyWallet(t)
+	args.Alerters = []signer.Alerter{nil}
+	pw, err = signer.NewPolicyWallet(args)
+	assert.Nil(t, pw)
+	assert.Equal(t, signer.ErrNilAlerter, err)
+
+	args = createArgsPolicyWallet(t)
+	pw, err = signer.NewPolicyWallet(args)
+	require.Nil(t, err)
+	assert.False(t, pw.IsInterfaceNil())
+	assert.Equal(t, args.Wallet.PublicKey(), pw.PublicKey())
+	assert.Equal(t, args.Wallet.PrivateKey(), pw.PrivateKey())
+}
+
+func TestPolicyWallet_SignTransaction(t *testing.T) {
+	t.Parallel()
+
+	rawTx := createRawTx(t, testContract, signer.SubmitBatchFunction, createTestArgs(), 0)
+
+	t.Run("transaction of a checked call should be signed once", func(t *testing.T) {
+		t.Parallel()
+
+		args := createArgsPolicyWallet(t)
+		pw, _ := signer.NewPolicyWallet(args)
+		require.Nil(t, pw.CheckTransaction(context.Background(), createCheckRequest(createTestArgs(), 0)))
+
+		signature, err := pw.SignTransaction(context.Background(), rawTx)
+		require.Nil(t, err)
+
+		expectedSignature, _ := args.Wallet.Sign(txHash(t, rawTx))
+		assert.Equal(t, expectedSignature, signature)
+		assert.Equal(t, uint64(1), pw.Metrics()["signed"])
+		assert.Equal(t, uint64(0), pw.Metrics()["rejected"])
+
+		signature, err = pw.SignTransaction(context.Background(), rawTx)
+		assert.Nil(t, signature)
+		assert.ErrorIs(t, err, signer.ErrSignRequestRejected)
+	})
+	t.Run("transaction over the checked fee should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createArgsPolicyWallet(t)
+		pw, _ := signer.NewPolicyWallet(args)
+		require.Nil(t, pw.CheckTransaction(context.Background(), createCheckRequest(createTestArgs(), 10)))
+
+		expensiveRawTx := createRawTx(t, testContract, signer.SubmitBatchFunction, createTestArgs(), 11)
+		signature, err := pw.SignTransaction(context.Background(), expensiveRawTx)
+		assert.Nil(t, signature)
+		assert.ErrorIs(t, err, signer.ErrSignRequestRejected)
+		assert.Contains(t, err.Error(), "over the checked fee")
+	})
+	t.Run("transaction of an unchecked call should raise an alert", func(t *testing.T) {
+		t.Parallel()
+
+		alerter := &alerterStub{violations: make(chan *signer.PolicyViolation, 1)}
+		args := createArgsPolicyWallet(t)
+		args.Alerters = []signer.Alerter{alerter}
+		pw, _ := signer.NewPolicyWallet(args)
+		require.Nil(t, pw.CheckTransaction(context.Background(), createCheckRequest(createTestArgs(), 42)))
+
+		anotherAddress, _ := createTestWallet(t).Address()
+		anotherContract := anotherAddress.Bech32()
+		anotherRawTx := createRawTx(t, anotherContract, signer.SubmitBatchFunction, createTestArgs(), 42)
+		signature, err := pw.SignTransaction(context.Background(), anotherRawTx)
+		assert.Nil(t, signature)
+		assert.ErrorIs(t, err, signer.ErrSignRequestRejected)
+
+		select {
+		case violation := <-alerter.violations:
+			assert.Equal(t, hex.EncodeToString(txHash(t, anotherRawTx)), violation.TxHash)
+			assert.Equal(t, anotherContract, violation.Contract)
+			assert.Equal(t, 1, violation.NumPairs)
+			assert.Equal(t, uint64(42), violation.Fee)
+			assert.Equal(t, err.Error(), violation.Reason)
+		case <-time.After(time.Second):
+			assert.Fail(t, "the alert was not raised")
+		}
+		assert.Equal(t, uint64(0), pw.Metrics()["signed"])
+		assert.Equal(t, uint64(1), pw.Metrics()["rejected"])
+	})
+	t.Run("invalid raw transaction should raise an alert", func(t *testing.T) {
+		t.Parallel()
+
+		alerter := &alerterStub{violations: make(chan *signer.PolicyViolation, 1)}
+		args := createArgsPolicyWallet(t)
+		args.Alerters = []signer.Alerter{alerter}
+		pw, _ := signer.NewPolicyWallet(args)
+
+		signature, err := pw.SignTransaction(context.Background(), []byte("not a transaction"))
+		assert.Nil(t, signature)
+		assert.ErrorIs(t, err, signer.ErrInvalidTransaction)
+
+		select {
+		case violation := <-alerter.violations:
+			assert.Equal(t, err.Error(), violation.Reason)
+		case <-time.After(time.Second):
+			assert.Fail(t, "the alert was not raised")
+		}
+	})
+	t.Run("wrapped transaction signer should receive the request", func(t *testing.T) {
+		t.Parallel()
+
+		pki := createTestPKI(t)
+		policy, _ := signer.NewSubmitBatchPolicy(testContract)
+		remoteKey := createTestWallet(t)
+		handler, _ := signer.NewSignerHandler(signer.ArgsSignerHandler{Wallet: remoteKey, Policy: policy})
+		server := startTestSigner(t, pki, handler)
+		rw, err := signer.NewRemoteWallet(context.Background(), createArgsRemoteWallet(pki, server.URL))
+		require.Nil(t, err)
+
+		pw, _ := signer.NewPolicyWallet(signer.ArgsPolicyWallet{Wallet: rw, Policy: policy})
+		require.Nil(t, pw.CheckTransaction(context.Background(), createCheckRequest(createTestArgs(), 0)))
+		signature, err := pw.SignTransaction(context.Background(), rawTx)
+		require.Nil(t, err)
+
+		expectedSignature, _ := remoteKey.Sign(txHash(t, rawTx))
+		assert.Equal(t, expectedSignature, signature)
+	})
+}
+
+func TestPolicyWallet_CheckTransaction(t *testing.T) {
+	t.Parallel()
+
+	alerter := &alerterStub{violations: make(chan *signer.PolicyViolation, 1)}
+	args := createArgsPolicyWallet(t)
+	args.Alerters = []signer.Alerter{alerter}
+	pw, _ := signer.NewPolicyWallet(args)
+
+	request := createSignRequest(nil)
+	request.TxHash = ""
+	assert.Nil(t, pw.CheckTransaction(context.Background(), request))
+
+	request.Args = nil
+	err := pw.CheckTransaction(context.Background(), request)
+	assert.ErrorIs(t, err, signer.ErrSignRequestRejected)
+
+	select {
+	case violation := <-alerter.violations:
+		assert.Equal(t, err.Error(), violation.Reason)
+	case <-time.After(time.Second):
+		assert.Fail(t, "the alert was not raised")
+	}
+	assert.Equal(t, uint64(1), pw.Metrics()["rejected"])
+}
+
+func TestPolicyWallet_Sign(t *testing.T) {
+	t.Parallel()
+
+	pw, _ := signer.NewPolicyWallet(createArgsPolicyWallet(t))
+
+	signature, err := pw.Sign(make([]byte, 32))
+	assert.Nil(t, signature)
+	assert.ErrorIs(t, err, signer.ErrSignRequestRejected)
+
+	signature, err = pw.SignHex("0102")
+	assert.Nil(t, signature)
+	assert.ErrorIs(t, err, signer.ErrSignRequestRejected)
+	assert.Equal(t, uint64(2), pw.Metrics()["rejected"])
+}
+
+func TestWebhookAlerter_Alert(t *testing.T) {
+	t.Parallel()
+
+	alerter, err := signer.NewWebhookAlerter(signer.ArgsWebhookAlerter{URL: "ftp://alerts"})
+	assert.Nil(t, alerter)
+	assert.ErrorIs(t, err, signer.ErrInvalidSignPolicy)
+
+	received := make(chan *signer.PolicyViolation, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		violation := &signer.PolicyViolation{}
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(violation))
+		received <- violation
+	}))
+	defer server.Close()
+
+	alerter, err = signer.NewWebhookAlerter(signer.ArgsWebhookAlerter{URL: server.URL})
+	require.Nil(t, err)
+	assert.False(t, alerter.IsInterfaceNil())
+
+	alerter.Alert(context.Background(), &signer.PolicyViolation{
+		TxHash:   "aabb",
+		Function: "transfer",
+		Reason:   "sign request rejected",
+	})
+
+	violation := <-received
+	assert.Equal(t, "aabb", violation.TxHash)
+	assert.Equal(t, "transfer", violation.Function)
+}
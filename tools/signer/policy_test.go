@@ -0,0 +1,200 @@
+package signer_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/klever-io/klv-oracles-go/aggregator"
+	"github.com/klever-io/klv-oracles-go/aggregator/mock"
+	"github.com/klever-io/klv-oracles-go/tools/signer"
+	"github.com/klever-io/klv-oracles-go/tools/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var klvUSD = aggregator.BaseQuote{Base: "KLV", Quote: "USD"}
+
+func createReferenceProvider(price float64, err error) *mock.PriceFetcherStub {
+	return &mock.PriceFetcherStub{
+		FetchPricesCalled: func(ctx context.Context, pairs []aggregator.BaseQuote) map[aggregator.BaseQuote]aggregator.PriceResult {
+			results := make(map[aggregator.BaseQuote]aggregator.PriceResult)
+			for _, pair := range pairs {
+				results[pair] = aggregator.PriceResult{Price: price, Err: err}
+			}
+			return results
+		},
+	}
+}
+
+func TestNewPairsCountPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy, err := signer.NewPairsCountPolicy(0, 2)
+	assert.Nil(t, policy)
+	assert.ErrorIs(t, err, signer.ErrInvalidSignPolicy)
+
+	policy, err = signer.NewPairsCountPolicy(2, 1)
+	assert.Nil(t, policy)
+	assert.ErrorIs(t, err, signer.ErrInvalidSignPolicy)
+
+	policy, err = signer.NewPairsCountPolicy(1, 2)
+	require.Nil(t, err)
+
+	request := createSignRequest(make([]byte, 32))
+	assert.Nil(t, policy(context.Background(), request))
+
+	request.Args = append(request.Args, request.Args[0], request.Args[0])
+	assert.ErrorIs(t, policy(context.Background(), request), signer.ErrSignRequestRejected)
+
+	request.Args = nil
+	assert.ErrorIs(t, policy(context.Background(), request), signer.ErrSignRequestRejected)
+}
+
+func TestNewReferencePricePolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid arguments should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := signer.ArgsReferencePricePolicy{
+			Pairs:               []aggregator.BaseQuote{klvUSD},
+			MaxDeviationPercent: 5,
+		}
+		policy, err := signer.NewReferencePricePolicy(args)
+		assert.Nil(t, policy)
+		assert.Equal(t, signer.ErrNilReferencePriceProvider, err)
+
+		args.Provider = createReferenceProvider(0.0025, nil)
+		args.Pairs = nil
+		policy, err = signer.NewReferencePricePolicy(args)
+		assert.Nil(t, policy)
+		assert.ErrorIs(t, err, signer.ErrInvalidSignPolicy)
+
+		args.Pairs = []aggregator.BaseQuote{klvUSD}
+		args.MaxDeviationPercent = 0
+		policy, err = signer.NewReferencePricePolicy(args)
+		assert.Nil(t, policy)
+		assert.ErrorIs(t, err, signer.ErrInvalidSignPolicy)
+	})
+	t.Run("price within the deviation should be accepted", func(t *testing.T) {
+		t.Parallel()
+
+		// the signed price is 250000 with 8 decimals, so 0.0025
+		policy, err := signer.NewReferencePricePolicy(signer.ArgsReferencePricePolicy{
+			Provider:            createReferenceProvider(0.0026, nil),
+			Pairs:               []aggregator.BaseQuote{klvUSD},
+			MaxDeviationPercent: 5,
+		})
+		require.Nil(t, err)
+
+		assert.Nil(t, policy(context.Background(), createSignRequest(make([]byte, 32))))
+	})
+	t.Run("price over the deviation should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		policy, _ := signer.NewReferencePricePolicy(signer.ArgsReferencePricePolicy{
+			Provider:            createReferenceProvider(0.003, nil),
+			Pairs:               []aggregator.BaseQuote{klvUSD},
+			MaxDeviationPercent: 5,
+		})
+
+		err := policy(context.Background(), createSignRequest(make([]byte, 32)))
+		assert.ErrorIs(t, err, signer.ErrSignRequestRejected)
+	})
+	t.Run("missing reference price should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		policy, _ := signer.NewReferencePricePolicy(signer.ArgsReferencePricePolicy{
+			Provider:            createReferenceProvider(0, errors.New("not enough responses")),
+			Pairs:               []aggregator.BaseQuote{klvUSD},
+			MaxDeviationPercent: 5,
+		})
+
+		err := policy(context.Background(), createSignRequest(make([]byte, 32)))
+		assert.ErrorIs(t, err, signer.ErrSignRequestRejected)
+	})
+	t.Run("pairs without reference should not be checked", func(t *testing.T) {
+		t.Parallel()
+
+		provider := createReferenceProvider(0.003, nil)
+		provider.FetchPricesCalled = func(ctx context.Context, pairs []aggregator.BaseQuote) map[aggregator.BaseQuote]aggregator.PriceResult {
+			assert.Fail(t, "should have not fetched the reference prices")
+			return nil
+		}
+		policy, _ := signer.NewReferencePricePolicy(signer.ArgsReferencePricePolicy{
+			Provider:            provider,
+			Pairs:               []aggregator.BaseQuote{{Base: "ETH", Quote: "USD"}},
+			MaxDeviationPercent: 5,
+		})
+
+		assert.Nil(t, policy(context.Background(), createSignRequest(make([]byte, 32))))
+	})
+}
+
+func TestNewDailyFeeCapPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy, err := signer.NewDailyFeeCapPolicy(signer.ArgsDailyFeeCapPolicy{})
+	assert.Nil(t, policy)
+	assert.ErrorIs(t, err, signer.ErrInvalidSignPolicy)
+
+	now := time.Date(2024, 5, 1, 23, 0, 0, 0, time.UTC)
+	policy, err = signer.NewDailyFeeCapPolicy(signer.ArgsDailyFeeCapPolicy{
+		DailyFeeCap: 100,
+		TimeHandler: func() time.Time {
+			return now
+		},
+	})
+	require.Nil(t, err)
+
+	request := createSignRequest(make([]byte, 32))
+	request.Fee = 60
+	assert.Nil(t, policy(context.Background(), request))
+
+	request.Fee = 50
+	assert.ErrorIs(t, policy(context.Background(), request), signer.ErrSignRequestRejected)
+
+	request.Fee = 40
+	assert.Nil(t, policy(context.Background(), request))
+
+	request.Fee = 1
+	assert.ErrorIs(t, policy(context.Background(), request), signer.ErrSignRequestRejected)
+
+	now = now.Add(2 * time.Hour)
+	request.Fee = 100
+	assert.Nil(t, policy(context.Background(), request))
+}
+
+func TestNewPolicies(t *testing.T) {
+	t.Parallel()
+
+	policy, err := signer.NewPolicies()
+	assert.Nil(t, policy)
+	assert.ErrorIs(t, err, signer.ErrInvalidSignPolicy)
+
+	policy, err = signer.NewPolicies(nil)
+	assert.Nil(t, policy)
+	assert.Equal(t, signer.ErrNilSignPolicy, err)
+
+	submitBatchPolicy, _ := signer.NewSubmitBatchPolicy(testContract)
+	rejectedErr := errors.New("rejected")
+	lastCalled := false
+	policy, err = signer.NewPolicies(
+		submitBatchPolicy,
+		func(ctx context.Context, request *wallet.SignRequest) error {
+			lastCalled = true
+			return rejectedErr
+		},
+	)
+	require.Nil(t, err)
+
+	request := createSignRequest(make([]byte, 32))
+	request.Function = "transfer"
+	assert.ErrorIs(t, policy(context.Background(), request), signer.ErrSignRequestRejected)
+	assert.False(t, lastCalled)
+
+	assert.Equal(t, rejectedErr, policy(context.Background(), createSignRequest(make([]byte, 32))))
+	assert.True(t, lastCalled)
+}
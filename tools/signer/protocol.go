@@ -0,0 +1,41 @@
+package signer
+
+import "github.com/klever-io/klv-oracles-go/tools/wallet"
+
+const (
+	publicKeyPath = "/v1/publickey"
+	signPath      = "/v1/sign"
+	checkPath     = "/v1/check"
+
+	// maxRequestSize bounds the size of the sign requests accepted by the signer
+	maxRequestSize = 1 << 20
+)
+
+// signRequest holds the hex encoded, proto-marshalled, raw data of the transaction to sign
+type signRequest struct {
+	RawTx string `json:"rawTx"`
+}
+
+// checkRequest holds the call of a transaction not built yet, checked against the policy without being signed
+type checkRequest struct {
+	Contract string                  `json:"contract"`
+	Function string                  `json:"function"`
+	Args     []wallet.SubmitBatchArg `json:"args"`
+	Fee      uint64                  `json:"fee"`
+}
+
+type checkResponse struct {
+	Accepted bool `json:"accepted"`
+}
+
+type publicKeyResponse struct {
+	PublicKey string `json:"publicKey"`
+}
+
+type signResponse struct {
+	Signature string `json:"signature"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
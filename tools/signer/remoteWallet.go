@@ -0,0 +1,203 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/klever-io/klv-bridge-eth-go/clients/klever/blockchain/address"
+	"github.com/klever-io/klv-oracles-go/aggregator"
+	"github.com/klever-io/klv-oracles-go/tools/wallet"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// ArgsRemoteWallet is the DTO used to create a new remote wallet
+type ArgsRemoteWallet struct {
+	// URL is the https address of the signer daemon
+	URL string
+	// CACertFile is the PEM bundle with the certificate authority of the signer
+	CACertFile string
+	// ClientCertFile and ClientKeyFile hold the certificate and key the oracle authenticates with
+	ClientCertFile string
+	ClientKeyFile  string
+	// Timeout bounds each request to the signer, a default is used when 0
+	Timeout time.Duration
+}
+
+// remoteWallet is a wallet whose key is held by a signer daemon, reached over HTTP with mutual TLS
+type remoteWallet struct {
+	url       string
+	client    *http.Client
+	decoder   *transactionDecoder
+	publicKey []byte
+}
+
+// NewRemoteWallet creates a wallet signing through the signer daemon, whose public key is fetched on creation
+func NewRemoteWallet(ctx context.Context, args ArgsRemoteWallet) (*remoteWallet, error) {
+	err := checkArgsRemoteWallet(args)
+	if err != nil {
+		return nil, err
+	}
+
+	transport, err := aggregator.NewHttpTransport(aggregator.ArgsHttpTransport{
+		CACertFile:     args.CACertFile,
+		ClientCertFile: args.ClientCertFile,
+		ClientKeyFile:  args.ClientKeyFile,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrInvalidRemoteSignerConfig, err.Error())
+	}
+
+	decoder, err := newTransactionDecoder()
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := args.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	rw := &remoteWallet{
+		url: strings.TrimRight(args.URL, "/"),
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   timeout,
+		},
+		decoder: decoder,
+	}
+
+	response := &publicKeyResponse{}
+	err = rw.do(ctx, http.MethodGet, publicKeyPath, nil, response)
+	if err != nil {
+		return nil, fmt.Errorf("%w while fetching the public key", err)
+	}
+	rw.publicKey, err = hex.DecodeString(response.PublicKey)
+	if err != nil || len(rw.publicKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("%w, invalid public key %q", ErrRemoteSignerFailure, response.PublicKey)
+	}
+
+	return rw, nil
+}
+
+func checkArgsRemoteWallet(args ArgsRemoteWallet) error {
+	parsedURL, err := url.Parse(args.URL)
+	if err != nil || parsedURL.Scheme != "https" || len(parsedURL.Host) == 0 {
+		return fmt.Errorf("%w, the URL must be an https address, got %q", ErrInvalidRemoteSignerConfig, args.URL)
+	}
+	if len(args.CACertFile) == 0 || len(args.ClientCertFile) == 0 || len(args.ClientKeyFile) == 0 {
+		return fmt.Errorf("%w, the CA certificate, the client certificate and the client key are required",
+			ErrInvalidRemoteSignerConfig)
+	}
+	if args.Timeout < 0 {
+		return fmt.Errorf("%w, negative timeout", ErrInvalidRemoteSignerConfig)
+	}
+
+	return nil
+}
+
+// SignTransaction sends the raw transaction to the signer, which decodes it for its policy and signs its hash. The
+// returned signature is checked against the hash of the raw transaction and the public key of the signer
+func (rw *remoteWallet) SignTransaction(ctx context.Context, rawTx []byte) ([]byte, error) {
+	response := &signResponse{}
+	err := rw.do(ctx, http.MethodPost, signPath, &signRequest{RawTx: hex.EncodeToString(rawTx)}, response)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := hex.DecodeString(response.Signature)
+	if err != nil || !ed25519.Verify(rw.publicKey, rw.decoder.hash(rawTx), signature) {
+		return nil, ErrInvalidSignature
+	}
+
+	return signature, nil
+}
+
+// CheckTransaction has the signer check the call against its policy, before its transaction is built
+func (rw *remoteWallet) CheckTransaction(ctx context.Context, request *wallet.SignRequest) error {
+	return rw.do(ctx, http.MethodPost, checkPath, &checkRequest{
+		Contract: request.Contract,
+		Function: request.Function,
+		Args:     request.Args,
+		Fee:      request.Fee,
+	}, &checkResponse{})
+}
+
+func (rw *remoteWallet) do(ctx context.Context, method string, path string, request interface{}, response interface{}) error {
+	var body io.Reader
+	if request != nil {
+		buff, err := json.Marshal(request)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(buff)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, rw.url+path, body)
+	if err != nil {
+		return err
+	}
+	if request != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := rw.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w, %s", ErrRemoteSignerFailure, err.Error())
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		failure := &errorResponse{}
+		_ = json.NewDecoder(io.LimitReader(resp.Body, maxRequestSize)).Decode(failure)
+		return fmt.Errorf("%w, status %d: %s", ErrRemoteSignerFailure, resp.StatusCode, failure.Error)
+	}
+
+	return json.NewDecoder(io.LimitReader(resp.Body, maxRequestSize)).Decode(response)
+}
+
+// PrivateKey returns nil as the key never leaves the signer
+func (rw *remoteWallet) PrivateKey() []byte {
+	return nil
+}
+
+// PublicKey returns the public key of the signer
+func (rw *remoteWallet) PublicKey() []byte {
+	return append([]byte{}, rw.publicKey...)
+}
+
+// Address returns the address of the signer
+func (rw *remoteWallet) Address() (address.Address, error) {
+	return address.NewAddressFromBytes(rw.publicKey)
+}
+
+// Sign refuses to sign the message, as the signer only signs the raw transactions it is able to decode
+func (rw *remoteWallet) Sign(_ []byte) ([]byte, error) {
+	return nil, fmt.Errorf("%w, only the raw transactions are signed", ErrRemoteSignerFailure)
+}
+
+// SignHex signs the hex encoded message
+func (rw *remoteWallet) SignHex(msg string) ([]byte, error) {
+	data, err := hex.DecodeString(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return rw.Sign(data)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (rw *remoteWallet) IsInterfaceNil() bool {
+	return rw == nil
+}
@@ -0,0 +1,473 @@
+package signer_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	factoryHasher "github.com/klever-io/klever-go/crypto/hashing/factory"
+	"github.com/klever-io/klever-go/data/transaction"
+	"github.com/klever-io/klever-go/tools/marshal"
+	"github.com/klever-io/klv-bridge-eth-go/clients/klever/blockchain/address"
+	"github.com/klever-io/klv-bridge-eth-go/clients/klever/blockchain/builders"
+	"github.com/klever-io/klv-oracles-go/aggregator"
+	"github.com/klever-io/klv-oracles-go/tools/signer"
+	"github.com/klever-io/klv-oracles-go/tools/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testContract = "klv1qqqqqqqqqqqqqpgq3cz5jjr74ztusal7ttjj3qftjcl3s4e383vqa2r4al"
+
+type testCertificate struct {
+	cert     *x509.Certificate
+	key      *ecdsa.PrivateKey
+	certFile string
+	keyFile  string
+}
+
+func createTestCertificate(t *testing.T, name string, template *x509.Certificate, parent *testCertificate) *testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+
+	template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	template.Subject = pkix.Name{CommonName: name}
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+
+	parentCert, parentKey := template, key
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, &key.PublicKey, parentKey)
+	require.Nil(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.Nil(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.Nil(t, err)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+	require.Nil(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.Nil(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+
+	return &testCertificate{cert: cert, key: key, certFile: certFile, keyFile: keyFile}
+}
+
+type testPKI struct {
+	ca     *testCertificate
+	server *testCertificate
+	client *testCertificate
+}
+
+func createTestPKI(t *testing.T) *testPKI {
+	ca := createTestCertificate(t, "ca", &x509.Certificate{
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil)
+	server := createTestCertificate(t, "server", &x509.Certificate{
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca)
+	client := createTestCertificate(t, "oracle", &x509.Certificate{
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca)
+
+	return &testPKI{ca: ca, server: server, client: client}
+}
+
+func createTestWallet(t *testing.T) wallet.Wallet {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.Nil(t, err)
+	w, err := wallet.NewWallet(privateKey.Seed())
+	require.Nil(t, err)
+
+	return w
+}
+
+func startTestSigner(t *testing.T, pki *testPKI, handler http.Handler) *httptest.Server {
+	tlsConfig, err := signer.NewMutualTLSConfig(pki.server.certFile, pki.server.keyFile, pki.ca.certFile)
+	require.Nil(t, err)
+
+	server := httptest.NewUnstartedServer(handler)
+	server.TLS = tlsConfig
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func createArgsRemoteWallet(pki *testPKI, url string) signer.ArgsRemoteWallet {
+	return signer.ArgsRemoteWallet{
+		URL:            url,
+		CACertFile:     pki.ca.certFile,
+		ClientCertFile: pki.client.certFile,
+		ClientKeyFile:  pki.client.keyFile,
+	}
+}
+
+func createTestArgs() []wallet.SubmitBatchArg {
+	return createPricedArgs(250000)
+}
+
+func createPricedArgs(price uint64) []wallet.SubmitBatchArg {
+	return []wallet.SubmitBatchArg{
+		{Base: "KLV", Quote: "USD", Timestamp: 1700000000, Price: price, Decimals: 8},
+	}
+}
+
+// createCheckRequest returns the submitBatch call checked before the transaction of the args is built
+func createCheckRequest(args []wallet.SubmitBatchArg, fee uint64) *wallet.SignRequest {
+	return &wallet.SignRequest{
+		Contract: testContract,
+		Function: signer.SubmitBatchFunction,
+		Args:     args,
+		Fee:      fee,
+	}
+}
+
+func createSignRequest(txHash []byte) *wallet.SignRequest {
+	return &wallet.SignRequest{
+		TxHash:   hex.EncodeToString(txHash),
+		Contract: testContract,
+		Function: signer.SubmitBatchFunction,
+		Args:     createTestArgs(),
+	}
+}
+
+// createRawTx returns the proto-marshalled raw data of a transaction calling the function of the contract, the way
+// the oracle builds it
+func createRawTx(t *testing.T, contract string, function string, args []wallet.SubmitBatchArg, fee int64) []byte {
+	contractAddress, err := address.NewAddress(contract)
+	require.Nil(t, err)
+
+	txDataBuilder := builders.NewTxDataBuilder().Function(function)
+	for _, arg := range args {
+		txDataBuilder.ArgBytes([]byte(arg.Base)).
+			ArgBytes([]byte(arg.Quote)).
+			ArgInt64(arg.Timestamp).
+			ArgInt64(int64(arg.Price)).
+			ArgInt64(int64(arg.Decimals))
+	}
+	txData, err := txDataBuilder.ToDataBytes()
+	require.Nil(t, err)
+
+	tx := transaction.NewBaseTransaction(bytes.Repeat([]byte{1}, 32), 7, [][]byte{txData}, 0, 0)
+	tx.PushContract(transaction.TXContract_SmartContractType, &transaction.SmartContract{
+		Type:    transaction.SmartContract_SCInvoke,
+		Address: contractAddress.Bytes(),
+	})
+	tx.RawData.KAppFee = fee
+
+	rawTx, err := marshal.NewProtoMarshalizer().Marshal(tx.GetRawData())
+	require.Nil(t, err)
+
+	return rawTx
+}
+
+func txHash(t *testing.T, rawTx []byte) []byte {
+	hasher, err := factoryHasher.NewHasher("blake2b")
+	require.Nil(t, err)
+
+	return hasher.Compute(string(rawTx))
+}
+
+func TestNewRemoteWallet(t *testing.T) {
+	t.Parallel()
+
+	pki := createTestPKI(t)
+
+	t.Run("invalid arguments should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createArgsRemoteWallet(pki, "http://127.0.0.1:8443")
+		rw, err := signer.NewRemoteWallet(context.Background(), args)
+		assert.Nil(t, rw)
+		assert.ErrorIs(t, err, signer.ErrInvalidRemoteSignerConfig)
+
+		args = createArgsRemoteWallet(pki, "https://127.0.0.1:8443")
+		args.ClientKeyFile = ""
+		rw, err = signer.NewRemoteWallet(context.Background(), args)
+		assert.Nil(t, rw)
+		assert.ErrorIs(t, err, signer.ErrInvalidRemoteSignerConfig)
+
+		args = createArgsRemoteWallet(pki, "https://127.0.0.1:8443")
+		args.Timeout = -time.Second
+		rw, err = signer.NewRemoteWallet(context.Background(), args)
+		assert.Nil(t, rw)
+		assert.ErrorIs(t, err, signer.ErrInvalidRemoteSignerConfig)
+	})
+	t.Run("should fetch the public key of the signer", func(t *testing.T) {
+		t.Parallel()
+
+		w := createTestWallet(t)
+		policy, _ := signer.NewSubmitBatchPolicy(testContract)
+		handler, err := signer.NewSignerHandler(signer.ArgsSignerHandler{Wallet: w, Policy: policy})
+		require.Nil(t, err)
+		server := startTestSigner(t, pki, handler)
+
+		rw, err := signer.NewRemoteWallet(context.Background(), createArgsRemoteWallet(pki, server.URL))
+		require.Nil(t, err)
+		assert.False(t, rw.IsInterfaceNil())
+		assert.Equal(t, w.PublicKey(), rw.PublicKey())
+		assert.Nil(t, rw.PrivateKey())
+
+		expectedAddress, _ := w.Address()
+		address, err := rw.Address()
+		require.Nil(t, err)
+		assert.Equal(t, expectedAddress.Bech32(), address.Bech32())
+	})
+}
+
+func TestRemoteWallet_SignTransaction(t *testing.T) {
+	t.Parallel()
+
+	pki := createTestPKI(t)
+	w := createTestWallet(t)
+	policy, _ := signer.NewSubmitBatchPolicy(testContract)
+	handler, _ := signer.NewSignerHandler(signer.ArgsSignerHandler{Wallet: w, Policy: policy})
+	server := startTestSigner(t, pki, handler)
+
+	rw, err := signer.NewRemoteWallet(context.Background(), createArgsRemoteWallet(pki, server.URL))
+	require.Nil(t, err)
+
+	t.Run("transaction of a checked call should be signed once", func(t *testing.T) {
+		t.Parallel()
+
+		args := createPricedArgs(1)
+		require.Nil(t, rw.CheckTransaction(context.Background(), createCheckRequest(args, 10)))
+		rawTx := createRawTx(t, testContract, signer.SubmitBatchFunction, args, 10)
+
+		signature, err := rw.SignTransaction(context.Background(), rawTx)
+		require.Nil(t, err)
+
+		expectedSignature, _ := w.Sign(txHash(t, rawTx))
+		assert.Equal(t, expectedSignature, signature)
+
+		signature, err = rw.SignTransaction(context.Background(), rawTx)
+		assert.Nil(t, signature)
+		assert.ErrorIs(t, err, signer.ErrRemoteSignerFailure)
+		assert.True(t, strings.Contains(err.Error(), "status 403"))
+	})
+	t.Run("transaction not matching the checked call should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createPricedArgs(2)
+		require.Nil(t, rw.CheckTransaction(context.Background(), createCheckRequest(args, 10)))
+
+		signature, err := rw.SignTransaction(context.Background(), createRawTx(t, testContract, "setPairDecimals", nil, 0))
+		assert.Nil(t, signature)
+		assert.ErrorIs(t, err, signer.ErrRemoteSignerFailure)
+		assert.True(t, strings.Contains(err.Error(), "status 403"))
+
+		signature, err = rw.SignTransaction(context.Background(), createRawTx(t, testContract, signer.SubmitBatchFunction,
+			createPricedArgs(3), 10))
+		assert.Nil(t, signature)
+		assert.ErrorIs(t, err, signer.ErrRemoteSignerFailure)
+
+		// the real fee is over the checked one
+		signature, err = rw.SignTransaction(context.Background(), createRawTx(t, testContract, signer.SubmitBatchFunction,
+			args, 11))
+		assert.Nil(t, signature)
+		assert.ErrorIs(t, err, signer.ErrRemoteSignerFailure)
+		assert.True(t, strings.Contains(err.Error(), "over the checked fee"))
+
+		signature, err = rw.Sign(make([]byte, 32))
+		assert.Nil(t, signature)
+		assert.ErrorIs(t, err, signer.ErrRemoteSignerFailure)
+	})
+	t.Run("invalid raw transaction should error", func(t *testing.T) {
+		t.Parallel()
+
+		signature, err := rw.SignTransaction(context.Background(), []byte("not a transaction"))
+		assert.Nil(t, signature)
+		assert.ErrorIs(t, err, signer.ErrRemoteSignerFailure)
+		assert.True(t, strings.Contains(err.Error(), "status 400"))
+	})
+	t.Run("signature of another key should error", func(t *testing.T) {
+		t.Parallel()
+
+		// the public key is served by another signer than the one signing
+		otherHandler, _ := signer.NewSignerHandler(signer.ArgsSignerHandler{Wallet: createTestWallet(t), Policy: policy})
+		mux := http.NewServeMux()
+		mux.Handle("GET /v1/publickey", otherHandler)
+		mux.Handle("POST /v1/check", handler)
+		mux.Handle("POST /v1/sign", handler)
+		otherServer := startTestSigner(t, pki, mux)
+		otherWallet, err := signer.NewRemoteWallet(context.Background(), createArgsRemoteWallet(pki, otherServer.URL))
+		require.Nil(t, err)
+
+		args := createPricedArgs(4)
+		require.Nil(t, otherWallet.CheckTransaction(context.Background(), createCheckRequest(args, 0)))
+		rawTx := createRawTx(t, testContract, signer.SubmitBatchFunction, args, 0)
+		signature, err := otherWallet.SignTransaction(context.Background(), rawTx)
+		assert.Nil(t, signature)
+		assert.ErrorIs(t, err, signer.ErrInvalidSignature)
+	})
+	t.Run("client without a certificate should be refused", func(t *testing.T) {
+		t.Parallel()
+
+		transport, err := aggregator.NewHttpTransport(aggregator.ArgsHttpTransport{CACertFile: pki.ca.certFile})
+		require.Nil(t, err)
+		client := &http.Client{Transport: transport}
+		resp, err := client.Get(server.URL + "/v1/publickey")
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		assert.NotNil(t, err)
+	})
+}
+
+func TestRemoteWallet_CheckTransaction(t *testing.T) {
+	t.Parallel()
+
+	pki := createTestPKI(t)
+	policy, _ := signer.NewSubmitBatchPolicy(testContract)
+	handler, _ := signer.NewSignerHandler(signer.ArgsSignerHandler{Wallet: createTestWallet(t), Policy: policy})
+	server := startTestSigner(t, pki, handler)
+
+	rw, err := signer.NewRemoteWallet(context.Background(), createArgsRemoteWallet(pki, server.URL))
+	require.Nil(t, err)
+
+	request := createCheckRequest(createTestArgs(), 0)
+	assert.Nil(t, rw.CheckTransaction(context.Background(), request))
+
+	request.Function = "setPairDecimals"
+	err = rw.CheckTransaction(context.Background(), request)
+	assert.ErrorIs(t, err, signer.ErrRemoteSignerFailure)
+	assert.True(t, strings.Contains(err.Error(), "status 403"))
+}
+
+func TestNewSignerHandler(t *testing.T) {
+	t.Parallel()
+
+	policy, _ := signer.NewSubmitBatchPolicy(testContract)
+
+	handler, err := signer.NewSignerHandler(signer.ArgsSignerHandler{Policy: policy})
+	assert.Nil(t, handler)
+	assert.Equal(t, signer.ErrNilWallet, err)
+
+	handler, err = signer.NewSignerHandler(signer.ArgsSignerHandler{Wallet: createTestWallet(t)})
+	assert.Nil(t, handler)
+	assert.Equal(t, signer.ErrNilSignPolicy, err)
+}
+
+func TestSignerHandler_ShouldOnlySignTheDecodedRawTransaction(t *testing.T) {
+	t.Parallel()
+
+	pki := createTestPKI(t)
+	w := createTestWallet(t)
+	policy, _ := signer.NewSubmitBatchPolicy(testContract)
+	handler, _ := signer.NewSignerHandler(signer.ArgsSignerHandler{Wallet: w, Policy: policy})
+	server := startTestSigner(t, pki, handler)
+
+	transport, err := aggregator.NewHttpTransport(aggregator.ArgsHttpTransport{
+		CACertFile:     pki.ca.certFile,
+		ClientCertFile: pki.client.certFile,
+		ClientKeyFile:  pki.client.keyFile,
+	})
+	require.Nil(t, err)
+	client := &http.Client{Transport: transport}
+	post := func(path string, request string) (int, map[string]interface{}) {
+		resp, errPost := client.Post(server.URL+path, "application/json", strings.NewReader(request))
+		require.Nil(t, errPost)
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+
+		response := make(map[string]interface{})
+		require.Nil(t, json.NewDecoder(resp.Body).Decode(&response))
+		return resp.StatusCode, response
+	}
+
+	// the transaction of a compromised oracle, calling another function of the aggregator contract
+	maliciousRawTx := createRawTx(t, testContract, "setPairDecimals", nil, 0)
+
+	t.Run("hash declared along with arguments it does not match should be refused", func(t *testing.T) {
+		t.Parallel()
+
+		declaredArgs, _ := json.Marshal(createTestArgs())
+		status, response := post("/v1/sign", fmt.Sprintf(`{"txHash":%q,"contract":%q,"function":%q,"args":%s}`,
+			hex.EncodeToString(txHash(t, maliciousRawTx)), testContract, signer.SubmitBatchFunction, declaredArgs))
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Contains(t, response["error"], "invalid sign request")
+	})
+	t.Run("raw transaction with another call should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		// the checked call does not vouch for another call
+		declaredArgs, _ := json.Marshal(createPricedArgs(5))
+		status, _ := post("/v1/check", fmt.Sprintf(`{"contract":%q,"function":%q,"args":%s,"fee":0}`,
+			testContract, signer.SubmitBatchFunction, declaredArgs))
+		require.Equal(t, http.StatusOK, status)
+
+		status, response := post("/v1/sign", fmt.Sprintf(`{"rawTx":%q}`, hex.EncodeToString(maliciousRawTx)))
+		assert.Equal(t, http.StatusForbidden, status)
+		assert.Contains(t, response["error"], "setPairDecimals")
+	})
+	t.Run("raw transaction should be signed by its hash", func(t *testing.T) {
+		t.Parallel()
+
+		args := createPricedArgs(6)
+		declaredArgs, _ := json.Marshal(args)
+		status, _ := post("/v1/check", fmt.Sprintf(`{"contract":%q,"function":%q,"args":%s,"fee":0}`,
+			testContract, signer.SubmitBatchFunction, declaredArgs))
+		require.Equal(t, http.StatusOK, status)
+
+		rawTx := createRawTx(t, testContract, signer.SubmitBatchFunction, args, 0)
+		status, response := post("/v1/sign", fmt.Sprintf(`{"rawTx":%q}`, hex.EncodeToString(rawTx)))
+		require.Equal(t, http.StatusOK, status)
+
+		signature, errDecode := hex.DecodeString(response["signature"].(string))
+		require.Nil(t, errDecode)
+		assert.True(t, ed25519.Verify(w.PublicKey(), txHash(t, rawTx), signature))
+	})
+}
+
+func TestNewSubmitBatchPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy, err := signer.NewSubmitBatchPolicy("")
+	assert.Nil(t, policy)
+	assert.ErrorIs(t, err, signer.ErrInvalidRemoteSignerConfig)
+
+	policy, err = signer.NewSubmitBatchPolicy(testContract)
+	require.Nil(t, err)
+
+	request := createSignRequest(make([]byte, 32))
+	assert.Nil(t, policy(context.Background(), request))
+
+	request = createSignRequest(make([]byte, 32))
+	request.Contract = "klv1another"
+	assert.ErrorIs(t, policy(context.Background(), request), signer.ErrSignRequestRejected)
+
+	request = createSignRequest(make([]byte, 32))
+	request.Function = "transfer"
+	assert.ErrorIs(t, policy(context.Background(), request), signer.ErrSignRequestRejected)
+
+	request = createSignRequest(make([]byte, 32))
+	request.Args = nil
+	assert.ErrorIs(t, policy(context.Background(), request), signer.ErrSignRequestRejected)
+}
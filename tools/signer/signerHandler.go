@@ -0,0 +1,163 @@
+package signer
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/klever-io/klv-oracles-go/tools/wallet"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	logger "github.com/multiversx/mx-chain-logger-go"
+)
+
+var log = logger.GetOrCreate("klv-oracle-go/tools/signer")
+
+// SignPolicy decides whether a sign request may be signed, returning an error wrapping ErrSignRequestRejected when not
+type SignPolicy func(ctx context.Context, request *wallet.SignRequest) error
+
+// ArgsSignerHandler is the DTO used to create the http handler of a signer daemon
+type ArgsSignerHandler struct {
+	Wallet wallet.Wallet
+	Policy SignPolicy
+}
+
+type signerHandler struct {
+	wallet    wallet.Wallet
+	policy    SignPolicy
+	decoder   *transactionDecoder
+	checked   *checkedCalls
+	publicKey string
+}
+
+// NewSignerHandler creates the http handler serving the public key of the wallet, checking the calls against the
+// policy before their transaction is built, and signing the raw transactions whose decoded call matches a checked one.
+// The signed hash is computed over the decoded raw transaction, nothing declared by the client being trusted
+func NewSignerHandler(args ArgsSignerHandler) (http.Handler, error) {
+	if check.IfNil(args.Wallet) {
+		return nil, ErrNilWallet
+	}
+	if args.Policy == nil {
+		return nil, ErrNilSignPolicy
+	}
+
+	decoder, err := newTransactionDecoder()
+	if err != nil {
+		return nil, err
+	}
+
+	handler := &signerHandler{
+		wallet:    args.Wallet,
+		policy:    args.Policy,
+		decoder:   decoder,
+		checked:   newCheckedCalls(),
+		publicKey: hex.EncodeToString(args.Wallet.PublicKey()),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+publicKeyPath, handler.getPublicKey)
+	mux.HandleFunc("POST "+signPath, handler.sign)
+	mux.HandleFunc("POST "+checkPath, handler.check)
+
+	return mux, nil
+}
+
+func (handler *signerHandler) getPublicKey(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, &publicKeyResponse{PublicKey: handler.publicKey})
+}
+
+func (handler *signerHandler) sign(w http.ResponseWriter, r *http.Request) {
+	request := &signRequest{}
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(request)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid sign request: %w", err))
+		return
+	}
+
+	rawTx, err := hex.DecodeString(request.RawTx)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("%w, the raw transaction is not hex encoded", ErrInvalidTransaction))
+		return
+	}
+
+	call, hash, err := handler.decoder.decode(rawTx)
+	if err != nil {
+		log.Warn("rejected sign request", "client", clientName(r), "err", err.Error())
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = handler.checked.consume(call)
+	if err != nil {
+		log.Warn("rejected sign request", "tx hash", call.TxHash, "client", clientName(r), "err", err.Error())
+		writeError(w, policyErrorStatus(err), err)
+		return
+	}
+
+	signature, err := handler.wallet.Sign(hash)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	log.Info("signed transaction", "tx hash", call.TxHash, "client", clientName(r), "num pairs", len(call.Args))
+	writeJSON(w, http.StatusOK, &signResponse{Signature: hex.EncodeToString(signature)})
+}
+
+func (handler *signerHandler) check(w http.ResponseWriter, r *http.Request) {
+	request := &checkRequest{}
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(request)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid check request: %w", err))
+		return
+	}
+
+	call := &wallet.SignRequest{
+		Contract: request.Contract,
+		Function: request.Function,
+		Args:     request.Args,
+		Fee:      request.Fee,
+	}
+	err = handler.policy(r.Context(), call)
+	if err != nil {
+		log.Warn("rejected check request", "client", clientName(r), "err", err.Error())
+		writeError(w, policyErrorStatus(err), err)
+		return
+	}
+	handler.checked.add(call)
+
+	writeJSON(w, http.StatusOK, &checkResponse{Accepted: true})
+}
+
+func policyErrorStatus(err error) int {
+	if errors.Is(err, ErrSignRequestRejected) {
+		return http.StatusForbidden
+	}
+
+	return http.StatusBadRequest
+}
+
+// clientName returns the common name of the client certificate
+func clientName(r *http.Request) string {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return ""
+	}
+
+	return r.TLS.PeerCertificates[0].Subject.CommonName
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, &errorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, response interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(response)
+}
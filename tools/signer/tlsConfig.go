@@ -0,0 +1,34 @@
+package signer
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// NewMutualTLSConfig creates the TLS configuration of a signer daemon, only accepting the clients presenting a
+// certificate issued by the authorities of the clientCAFile bundle
+func NewMutualTLSConfig(certFile string, keyFile string, clientCAFile string) (*tls.Config, error) {
+	certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("%w while loading the signer certificate", err)
+	}
+
+	content, err := os.ReadFile(filepath.Clean(clientCAFile))
+	if err != nil {
+		return nil, fmt.Errorf("%w while reading the client CA file", err)
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(content) {
+		return nil, fmt.Errorf("%w, no certificate found in the client CA file %s", ErrInvalidRemoteSignerConfig, clientCAFile)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
@@ -0,0 +1,141 @@
+package signer
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/klever-io/klever-go/crypto/hashing"
+	factoryHasher "github.com/klever-io/klever-go/crypto/hashing/factory"
+	"github.com/klever-io/klever-go/data/transaction"
+	"github.com/klever-io/klever-go/tools/marshal"
+	"github.com/klever-io/klv-bridge-eth-go/clients/klever/blockchain/address"
+	"github.com/klever-io/klv-oracles-go/tools/wallet"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	dataSeparator        = "@"
+	numArgsPerPriceEntry = 5
+)
+
+// transactionDecoder decodes the submitBatch call out of the proto-marshalled raw data of a transaction and computes
+// the hash signed for it, the way the oracle and the Klever Blockchain do
+type transactionDecoder struct {
+	hasher      hashing.Hasher
+	marshalizer marshal.Marshalizer
+}
+
+func newTransactionDecoder() (*transactionDecoder, error) {
+	hasher, err := factoryHasher.NewHasher("blake2b")
+	if err != nil {
+		return nil, err
+	}
+
+	return &transactionDecoder{
+		hasher:      hasher,
+		marshalizer: marshal.NewProtoMarshalizer(),
+	}, nil
+}
+
+// hash returns the blake2b hash of the raw transaction, the one being signed
+func (decoder *transactionDecoder) hash(rawTx []byte) []byte {
+	return decoder.hasher.Compute(string(rawTx))
+}
+
+// decode returns the call of the raw transaction, along with its hash. Only the transactions holding a single smart
+// contract invocation and a single data field can be decoded
+func (decoder *transactionDecoder) decode(rawTx []byte) (*wallet.SignRequest, []byte, error) {
+	rawData := &transaction.Transaction_Raw{}
+	err := decoder.marshalizer.Unmarshal(rawData, rawTx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrInvalidTransaction, err.Error())
+	}
+
+	contracts := rawData.GetContract()
+	if len(contracts) != 1 || contracts[0].GetType() != transaction.TXContract_SmartContractType {
+		return nil, nil, fmt.Errorf("%w, the transaction should hold a single smart contract call", ErrInvalidTransaction)
+	}
+	smartContract := &transaction.SmartContract{}
+	err = anypb.UnmarshalTo(contracts[0].GetParameter(), smartContract, proto.UnmarshalOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrInvalidTransaction, err.Error())
+	}
+	if smartContract.GetType() != transaction.SmartContract_SCInvoke {
+		return nil, nil, fmt.Errorf("%w, the smart contract call is not an invocation", ErrInvalidTransaction)
+	}
+	contractAddress, err := address.NewAddressFromBytes(smartContract.GetAddress())
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrInvalidTransaction, err.Error())
+	}
+
+	if len(rawData.GetData()) != 1 {
+		return nil, nil, fmt.Errorf("%w, the transaction should hold a single data field", ErrInvalidTransaction)
+	}
+	function, args, err := decodeSubmitBatchData(string(rawData.GetData()[0]))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if rawData.GetKAppFee() < 0 || rawData.GetBandwidthFee() < 0 {
+		return nil, nil, fmt.Errorf("%w, negative fees", ErrInvalidTransaction)
+	}
+
+	hash := decoder.hash(rawTx)
+
+	return &wallet.SignRequest{
+		TxHash:   hex.EncodeToString(hash),
+		Contract: contractAddress.Bech32(),
+		Function: function,
+		Args:     args,
+		Fee:      uint64(rawData.GetKAppFee()) + uint64(rawData.GetBandwidthFee()),
+	}, hash, nil
+}
+
+// decodeSubmitBatchData splits the data field of the transaction in the called function and its arguments, decoded as
+// price changes when the function is submitBatch
+func decodeSubmitBatchData(data string) (string, []wallet.SubmitBatchArg, error) {
+	parts := strings.Split(data, dataSeparator)
+	function := parts[0]
+	if function != SubmitBatchFunction {
+		return function, nil, nil
+	}
+
+	encodedArgs := parts[1:]
+	if len(encodedArgs)%numArgsPerPriceEntry != 0 {
+		return "", nil, fmt.Errorf("%w, %d submitBatch arguments is not a multiple of %d",
+			ErrInvalidTransaction, len(encodedArgs), numArgsPerPriceEntry)
+	}
+
+	args := make([]wallet.SubmitBatchArg, 0, len(encodedArgs)/numArgsPerPriceEntry)
+	for idx := 0; idx < len(encodedArgs); idx += numArgsPerPriceEntry {
+		values := make([][]byte, numArgsPerPriceEntry)
+		for i := range values {
+			value, err := hex.DecodeString(encodedArgs[idx+i])
+			if err != nil {
+				return "", nil, fmt.Errorf("%w, submitBatch argument %d: %s", ErrInvalidTransaction, idx+i, err.Error())
+			}
+			values[i] = value
+		}
+
+		timestamp := big.NewInt(0).SetBytes(values[2])
+		price := big.NewInt(0).SetBytes(values[3])
+		decimals := big.NewInt(0).SetBytes(values[4])
+		if !timestamp.IsInt64() || !price.IsUint64() || !decimals.IsUint64() {
+			return "", nil, fmt.Errorf("%w, submitBatch price entry %d out of range", ErrInvalidTransaction,
+				idx/numArgsPerPriceEntry)
+		}
+
+		args = append(args, wallet.SubmitBatchArg{
+			Base:      string(values[0]),
+			Quote:     string(values[1]),
+			Timestamp: timestamp.Int64(),
+			Price:     price.Uint64(),
+			Decimals:  decimals.Uint64(),
+		})
+	}
+
+	return function, args, nil
+}
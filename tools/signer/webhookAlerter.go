@@ -0,0 +1,91 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const defaultAlertTimeout = 5 * time.Second
+
+// ArgsWebhookAlerter is the DTO used to create a new webhook alerter
+type ArgsWebhookAlerter struct {
+	URL string
+	// Transport sends the alerts, http.DefaultTransport is used when nil
+	Transport http.RoundTripper
+	// Timeout bounds each alert, a default is used when 0
+	Timeout time.Duration
+}
+
+// webhookAlerter posts the policy violations, as JSON, to a webhook
+type webhookAlerter struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookAlerter creates an alerter posting each policy violation to the webhook URL
+func NewWebhookAlerter(args ArgsWebhookAlerter) (*webhookAlerter, error) {
+	parsedURL, err := url.Parse(args.URL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || len(parsedURL.Host) == 0 {
+		return nil, fmt.Errorf("%w, invalid alert webhook URL %q", ErrInvalidSignPolicy, args.URL)
+	}
+
+	timeout := args.Timeout
+	if timeout <= 0 {
+		timeout = defaultAlertTimeout
+	}
+	transport := args.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &webhookAlerter{
+		url: args.URL,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   timeout,
+		},
+	}, nil
+}
+
+// Alert posts the violation to the webhook, logging the failures
+func (alerter *webhookAlerter) Alert(ctx context.Context, violation *PolicyViolation) {
+	err := alerter.post(ctx, violation)
+	if err != nil {
+		log.Error("failed to post the signing policy alert", "tx hash", violation.TxHash, "err", err.Error())
+	}
+}
+
+func (alerter *webhookAlerter) post(ctx context.Context, violation *PolicyViolation) error {
+	body, err := json.Marshal(violation)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, alerter.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := alerter.client.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("alert webhook returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (alerter *webhookAlerter) IsInterfaceNil() bool {
+	return alerter == nil
+}
@@ -0,0 +1,34 @@
+package wallet
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"strconv"
+)
+
+// signedMessagePrefix is prepended to the signed messages, so a signed message can never be a transaction hash
+const signedMessagePrefix = "\x17Klever Signed Message:\n"
+
+// MessageHash returns the hash signed for the message: the SHA256 of the prefix, the message length and the message
+func MessageHash(message []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte(signedMessagePrefix))
+	h.Write([]byte(strconv.Itoa(len(message))))
+	h.Write(message)
+
+	return h.Sum(nil)
+}
+
+// SignMessage signs the message with the wallet, proving the ownership of its address
+func SignMessage(w Wallet, message []byte) ([]byte, error) {
+	return w.Sign(MessageHash(message))
+}
+
+// VerifyMessage returns whether the signature of the message was made by the public key
+func VerifyMessage(publicKey []byte, message []byte, signature []byte) bool {
+	if len(publicKey) != ed25519.PublicKeySize {
+		return false
+	}
+
+	return ed25519.Verify(publicKey, MessageHash(message), signature)
+}
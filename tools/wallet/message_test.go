@@ -0,0 +1,28 @@
+package wallet_test
+
+import (
+	"testing"
+
+	"github.com/klever-io/klv-oracles-go/tools/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignMessage(t *testing.T) {
+	w, err := wallet.NewWalletFroHex("8734062c1158f26a3ca8a4a0da87b527a7c168653f7f4c77045e5cf571497d9d")
+	require.Nil(t, err)
+
+	message := []byte("oracle node eu-1")
+	signature, err := wallet.SignMessage(w, message)
+	require.Nil(t, err)
+	assert.True(t, wallet.VerifyMessage(w.PublicKey(), message, signature))
+
+	rawSignature, _ := w.Sign(message)
+	assert.NotEqual(t, rawSignature, signature)
+
+	assert.False(t, wallet.VerifyMessage(w.PublicKey(), []byte("oracle node eu-2"), signature))
+	assert.False(t, wallet.VerifyMessage(w.PublicKey()[1:], message, signature))
+
+	other, _ := wallet.NewWalletFroHex("1111111111111111111111111111111111111111111111111111111111111111")
+	assert.False(t, wallet.VerifyMessage(other.PublicKey(), message, signature))
+}
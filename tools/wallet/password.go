@@ -0,0 +1,74 @@
+package wallet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// ReadPEMPassword returns the PEM password read from the password file when provided, from the envVariable
+// environment variable otherwise, and prompts for it on the terminal as a last resort. The prompted password is
+// asked twice when confirming
+func ReadPEMPassword(passwordFile string, envVariable string, confirm bool) (string, error) {
+	if len(passwordFile) > 0 {
+		content, err := os.ReadFile(filepath.Clean(passwordFile))
+		if err != nil {
+			return "", fmt.Errorf("%w while reading the PEM password file", err)
+		}
+
+		return nonEmptyPassword(strings.TrimRight(string(content), "\r\n"), passwordFile)
+	}
+
+	password, found := os.LookupEnv(envVariable)
+	if found {
+		return nonEmptyPassword(password, envVariable)
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("the PEM file is encrypted, provide its password with a password file or the %s "+
+			"environment variable when not running in a terminal", envVariable)
+	}
+
+	password, err := promptPassword(fd, "PEM password: ")
+	if err != nil {
+		return "", err
+	}
+	if confirm {
+		confirmation, errPrompt := promptPassword(fd, "Confirm the PEM password: ")
+		if errPrompt != nil {
+			return "", errPrompt
+		}
+		if confirmation != password {
+			return "", fmt.Errorf("the PEM passwords do not match")
+		}
+	}
+
+	return nonEmptyPassword(password, "prompt")
+}
+
+func nonEmptyPassword(password string, source string) (string, error) {
+	if len(password) == 0 {
+		return "", fmt.Errorf("empty PEM password provided by %s", source)
+	}
+
+	return password, nil
+}
+
+// promptPassword reads a line from the terminal without echoing the typed characters
+func promptPassword(fd int, prompt string) (string, error) {
+	_, _ = fmt.Fprint(os.Stderr, prompt)
+	defer func() {
+		_, _ = fmt.Fprintln(os.Stderr)
+	}()
+
+	password, err := term.ReadPassword(fd)
+	if err != nil {
+		return "", fmt.Errorf("%w while reading the PEM password", err)
+	}
+
+	return string(password), nil
+}
@@ -0,0 +1,59 @@
+package wallet_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klever-io/klv-oracles-go/tools/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPasswordEnvVariable = "KLV_WALLET_TEST_PEM_PASSWORD"
+
+func TestReadPEMPassword(t *testing.T) {
+	t.Run("password file should be trimmed", func(t *testing.T) {
+		passwordFile := filepath.Join(t.TempDir(), "password")
+		require.Nil(t, os.WriteFile(passwordFile, []byte("secret\r\n"), 0600))
+		t.Setenv(testPasswordEnvVariable, "ignored")
+
+		password, err := wallet.ReadPEMPassword(passwordFile, testPasswordEnvVariable, false)
+		assert.Nil(t, err)
+		assert.Equal(t, "secret", password)
+	})
+	t.Run("missing password file should error", func(t *testing.T) {
+		_, err := wallet.ReadPEMPassword(filepath.Join(t.TempDir(), "missing"), testPasswordEnvVariable, false)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+	t.Run("empty password file should error", func(t *testing.T) {
+		passwordFile := filepath.Join(t.TempDir(), "password")
+		require.Nil(t, os.WriteFile(passwordFile, []byte("\n"), 0600))
+
+		_, err := wallet.ReadPEMPassword(passwordFile, testPasswordEnvVariable, false)
+		assert.ErrorContains(t, err, "empty PEM password")
+	})
+	t.Run("environment variable should be used without a password file", func(t *testing.T) {
+		t.Setenv(testPasswordEnvVariable, "secret")
+
+		password, err := wallet.ReadPEMPassword("", testPasswordEnvVariable, true)
+		assert.Nil(t, err)
+		assert.Equal(t, "secret", password)
+	})
+	t.Run("no terminal should error", func(t *testing.T) {
+		stdin := os.Stdin
+		defer func() {
+			os.Stdin = stdin
+		}()
+		reader, writer, err := os.Pipe()
+		require.Nil(t, err)
+		defer func() {
+			_ = reader.Close()
+			_ = writer.Close()
+		}()
+		os.Stdin = reader
+
+		_, err = wallet.ReadPEMPassword("", testPasswordEnvVariable+"_UNSET", false)
+		assert.ErrorContains(t, err, testPasswordEnvVariable+"_UNSET")
+	})
+}
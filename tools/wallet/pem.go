@@ -96,6 +96,131 @@ func LoadSkPkFromPemFile(relativePath string, skIndex int, pwd string) ([]byte,
 	return blkRecovered.Bytes, blockTypeString, nil
 }
 
+// IsEncryptedPEMFile returns whether the first PEM block of the file is password encrypted
+func IsEncryptedPEMFile(relativePath string) (bool, error) {
+	buff, err := os.ReadFile(filepath.Clean(relativePath))
+	if err != nil {
+		return false, err
+	}
+
+	blk, _ := pem.Decode(buff)
+	if blk == nil {
+		return false, fmt.Errorf("invalid pem file while reading %s file, error decoding", relativePath)
+	}
+
+	return IsEncryptedPEMBlock(blk), nil
+}
+
+// EncryptPEMFile encrypts in place all the blocks of a plaintext PEM file with the password. The file is replaced
+// only once all the blocks are encrypted, keeping its permissions
+func EncryptPEMFile(relativePath string, pwd string) error {
+	if len(pwd) == 0 {
+		return errors.New("empty password provided")
+	}
+
+	path := filepath.Clean(relativePath)
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	buff, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%w while reading %s file", err, relativePath)
+	}
+
+	encrypted := make([]byte, 0, 2*len(buff))
+	numBlocks := 0
+	for {
+		var blk *pem.Block
+		blk, buff = pem.Decode(buff)
+		if blk == nil {
+			break
+		}
+		if IsEncryptedPEMBlock(blk) {
+			return fmt.Errorf("the block %d of the %s file is already encrypted", numBlocks, relativePath)
+		}
+
+		encryptedBlk, errEncrypt := EncryptPEMBlock(blk.Type, blk.Bytes, pwd)
+		if errEncrypt != nil {
+			return errEncrypt
+		}
+		encrypted = append(encrypted, pem.EncodeToMemory(encryptedBlk)...)
+		numBlocks++
+	}
+	if numBlocks == 0 {
+		return fmt.Errorf("invalid pem file while reading %s file, error decoding", relativePath)
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmpFile.Name())
+	}()
+
+	_, err = tmpFile.Write(encrypted)
+	if err == nil {
+		err = tmpFile.Chmod(info.Mode().Perm())
+	}
+	if errClose := tmpFile.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile.Name(), path)
+}
+
+// WritePEMFile writes the keys of the wallets to a new PEM file readable only by its owner, in the format loaded by
+// LoadSkPkFromPemFile. The blocks are encrypted with the password when not empty. An existing file is never replaced
+func WritePEMFile(relativePath string, wallets []Wallet, pwd string) error {
+	if len(wallets) == 0 {
+		return errors.New("no key to write")
+	}
+
+	content := make([]byte, 0)
+	for _, w := range wallets {
+		blk, err := NewPEMBlock(w, pwd)
+		if err != nil {
+			return err
+		}
+		content = append(content, pem.EncodeToMemory(blk)...)
+	}
+
+	file, err := os.OpenFile(filepath.Clean(relativePath), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(content)
+	if errClose := file.Close(); err == nil {
+		err = errClose
+	}
+
+	return err
+}
+
+// NewPEMBlock returns the PEM block holding the key of the wallet, encrypted with the password when not empty
+func NewPEMBlock(w Wallet, pwd string) (*pem.Block, error) {
+	walletAddress, err := w.Address()
+	if err != nil {
+		return nil, err
+	}
+
+	blockType := "PRIVATE KEY for " + walletAddress.Bech32()
+	data := []byte(hex.EncodeToString(w.PrivateKey()))
+	if len(pwd) == 0 {
+		return &pem.Block{
+			Type:  blockType,
+			Bytes: data,
+		}, nil
+	}
+
+	return EncryptPEMBlock(blockType, data, pwd)
+}
+
 // OpenFile method opens the file from given path - does not close the file
 func OpenFile(relativePath string) (*os.File, error) {
 	path, err := filepath.Abs(relativePath)
@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"log"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/klever-io/klv-oracles-go/tools/wallet"
@@ -184,3 +185,100 @@ func TestEncryptPEMBlock_ShouldWork(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Len(t, pemBlock.Bytes, 92)
 }
+
+func TestEncryptPEMFile_ShouldWork(t *testing.T) {
+	fileName := tempPemFile()
+	defer func() {
+		_ = os.Remove(fileName)
+	}()
+	assert.Nil(t, os.Chmod(fileName, 0600))
+
+	encrypted, err := wallet.IsEncryptedPEMFile(fileName)
+	assert.Nil(t, err)
+	assert.False(t, encrypted)
+
+	err = wallet.EncryptPEMFile(fileName, "123")
+	assert.Nil(t, err)
+
+	encrypted, err = wallet.IsEncryptedPEMFile(fileName)
+	assert.Nil(t, err)
+	assert.True(t, encrypted)
+	info, err := os.Stat(fileName)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	pk, pub, err := wallet.LoadKey(fileName, 0, "123")
+	assert.Nil(t, err)
+	assert.Equal(t, "klv1usdnywjhrlv4tcyu6stxpl6yvhplg35nepljlt4y5r7yppe8er4qujlazy", pub)
+	assert.Equal(t, "8734062c1158f26a3ca8a4a0da87b527a7c168653f7f4c77045e5cf571497d9d", hex.EncodeToString(pk))
+
+	w, err := wallet.NewWalletFromEncryptedPEM(fileName, "123")
+	assert.Nil(t, err)
+	assert.Equal(t, "8734062c1158f26a3ca8a4a0da87b527a7c168653f7f4c77045e5cf571497d9d", hex.EncodeToString(w.PrivateKey()))
+}
+
+func TestEncryptPEMFile_Errors(t *testing.T) {
+	err := wallet.EncryptPEMFile(tempPemFile(), "")
+	assert.Contains(t, err.Error(), "empty password provided")
+
+	err = wallet.EncryptPEMFile(tempPemFileEncrypted(), "123")
+	assert.Contains(t, err.Error(), "already encrypted")
+
+	err = wallet.EncryptPEMFile(tempEmptyPemFile(), "123")
+	assert.Contains(t, err.Error(), "invalid pem file")
+
+	err = wallet.EncryptPEMFile("missing.pem", "123")
+	assert.NotNil(t, err)
+}
+
+func TestWritePEMFile(t *testing.T) {
+	first, _ := wallet.NewWalletFroHex("8734062c1158f26a3ca8a4a0da87b527a7c168653f7f4c77045e5cf571497d9d")
+	second, _ := wallet.NewWalletFroHex("1111111111111111111111111111111111111111111111111111111111111111")
+	wallets := []wallet.Wallet{first, second}
+
+	t.Run("plaintext", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "oracle.pem")
+		err := wallet.WritePEMFile(fileName, wallets, "")
+		assert.Nil(t, err)
+
+		info, err := os.Stat(fileName)
+		assert.Nil(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+		encrypted, err := wallet.IsEncryptedPEMFile(fileName)
+		assert.Nil(t, err)
+		assert.False(t, encrypted)
+
+		pk, pub, err := wallet.LoadKey(fileName, 0, "")
+		assert.Nil(t, err)
+		assert.Equal(t, "klv1usdnywjhrlv4tcyu6stxpl6yvhplg35nepljlt4y5r7yppe8er4qujlazy", pub)
+		assert.Equal(t, first.PrivateKey(), pk)
+
+		loaded, err := wallet.NewWalletFromPEMIndex(fileName, 1, "")
+		assert.Nil(t, err)
+		assert.Equal(t, second.PublicKey(), loaded.PublicKey())
+
+		err = wallet.WritePEMFile(fileName, wallets, "")
+		assert.True(t, os.IsExist(err))
+	})
+	t.Run("encrypted", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "oracle.pem")
+		err := wallet.WritePEMFile(fileName, wallets, "123")
+		assert.Nil(t, err)
+
+		encrypted, err := wallet.IsEncryptedPEMFile(fileName)
+		assert.Nil(t, err)
+		assert.True(t, encrypted)
+
+		loaded, err := wallet.NewWalletFromPEMIndex(fileName, 1, "123")
+		assert.Nil(t, err)
+		assert.Equal(t, second.PublicKey(), loaded.PublicKey())
+
+		_, err = wallet.NewWalletFromPEMIndex(fileName, 1, "")
+		assert.NotNil(t, err)
+	})
+	t.Run("no keys", func(t *testing.T) {
+		err := wallet.WritePEMFile(filepath.Join(t.TempDir(), "oracle.pem"), nil, "")
+		assert.Contains(t, err.Error(), "no key to write")
+	})
+}
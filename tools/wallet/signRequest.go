@@ -0,0 +1,34 @@
+package wallet
+
+import "context"
+
+// SubmitBatchArg is a decoded price change argument of the submitBatch function
+type SubmitBatchArg struct {
+	Base      string `json:"base"`
+	Quote     string `json:"quote"`
+	Timestamp int64  `json:"timestamp"`
+	Price     uint64 `json:"price"`
+	Decimals  uint64 `json:"decimals"`
+}
+
+// SignRequest is the call of a transaction, decoded by the signer from the raw transaction it signs, so its policy
+// checks the signed content rather than a content declared along with a hash
+type SignRequest struct {
+	// TxHash is the hash the signer computed over the raw transaction. It is empty when the call is checked, before its
+	// transaction is built
+	TxHash   string
+	Contract string
+	Function string
+	Args     []SubmitBatchArg
+	// Fee is the sum of the KApp and bandwidth fees of the transaction
+	Fee uint64
+}
+
+// TransactionSigner defines a wallet signing the proto-marshalled raw data of the transactions, like a remote signer,
+// decoding them to enforce its own policy and hashing them itself. The wallets implementing it are preferred to the
+// plain Sign call. CheckTransaction runs the policy over a call before its transaction is built, so the rejected calls
+// do not consume a nonce, SignTransaction only signing the transactions of the checked calls
+type TransactionSigner interface {
+	CheckTransaction(ctx context.Context, request *SignRequest) error
+	SignTransaction(ctx context.Context, rawTx []byte) ([]byte, error)
+}
@@ -55,8 +55,34 @@ func NewWalletFromMnemonic(mnemonic string, option ...WOHDPath) (Wallet, error)
 	return NewWallet(private[:])
 }
 
+// NewMnemonic returns a new random 24 words mnemonic
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(256)
+	if err != nil {
+		return "", err
+	}
+
+	return bip39.NewMnemonic(entropy)
+}
+
+// IsMnemonicValid returns whether the mnemonic is made of valid words with a valid checksum
+func IsMnemonicValid(mnemonic string) bool {
+	return bip39.IsMnemonicValid(mnemonic)
+}
+
 func NewWalletFromPEM(path string) (Wallet, error) {
-	pk, _, err := LoadKey(path, 0, "")
+	return NewWalletFromEncryptedPEM(path, "")
+}
+
+// NewWalletFromEncryptedPEM loads the wallet from a PEM file, decrypting its key with the password when encrypted
+func NewWalletFromEncryptedPEM(path string, password string) (Wallet, error) {
+	return NewWalletFromPEMIndex(path, 0, password)
+}
+
+// NewWalletFromPEMIndex loads the wallet from the key at skIndex of a PEM file holding several keys, decrypting it
+// with the password when encrypted
+func NewWalletFromPEMIndex(path string, skIndex int, password string) (Wallet, error) {
+	pk, _, err := LoadKey(path, skIndex, password)
 	if err != nil {
 		return nil, err
 	}
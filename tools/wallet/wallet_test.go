@@ -2,10 +2,14 @@ package wallet_test
 
 import (
 	"encoding/hex"
+	"encoding/pem"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/klever-io/klv-oracles-go/tools/wallet"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWallet_WalletFromPem(t *testing.T) {
@@ -17,6 +21,34 @@ func TestWallet_WalletFromPem(t *testing.T) {
 	assert.Equal(t, "e41b323a571fd955e09cd41660ff4465c3f44693c87f2faea4a0fc408727c8ea", hex.EncodeToString(wallet.PublicKey()))
 }
 
+func TestWallet_WalletFromPemIndex(t *testing.T) {
+	secondSk := "1111111111111111111111111111111111111111111111111111111111111111"
+	second, err := wallet.NewWalletFroHex(secondSk)
+	require.Nil(t, err)
+	secondAddress, _ := second.Address()
+
+	fileName := tempPemFile()
+	content, err := os.ReadFile(fileName)
+	require.Nil(t, err)
+	content = append(content, '\n')
+	content = append(content, pem.EncodeToMemory(&pem.Block{
+		Type:  "PRIVATE KEY for " + secondAddress.Bech32(),
+		Bytes: []byte(secondSk),
+	})...)
+	require.Nil(t, os.WriteFile(fileName, content, 0600))
+
+	first, err := wallet.NewWalletFromPEMIndex(fileName, 0, "")
+	assert.Nil(t, err)
+	assert.Equal(t, "8734062c1158f26a3ca8a4a0da87b527a7c168653f7f4c77045e5cf571497d9d", hex.EncodeToString(first.PrivateKey()))
+
+	loaded, err := wallet.NewWalletFromPEMIndex(fileName, 1, "")
+	assert.Nil(t, err)
+	assert.Equal(t, secondSk, hex.EncodeToString(loaded.PrivateKey()))
+
+	_, err = wallet.NewWalletFromPEMIndex(fileName, 2, "")
+	assert.NotNil(t, err)
+}
+
 func TestWallet_Mnemonic(t *testing.T) {
 	wallet, err := wallet.NewWalletFromMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about")
 	assert.Nil(t, err)
@@ -47,3 +79,17 @@ func TestWallet_Sign(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, signature, hexSign)
 }
+
+func TestWallet_NewMnemonic(t *testing.T) {
+	mnemonic, err := wallet.NewMnemonic()
+	require.Nil(t, err)
+	assert.Len(t, strings.Fields(mnemonic), 24)
+	assert.True(t, wallet.IsMnemonicValid(mnemonic))
+	assert.False(t, wallet.IsMnemonicValid("abandon abandon abandon"))
+
+	first, err := wallet.NewWalletFromMnemonic(mnemonic, wallet.WOHDPath{Prefix: 690, Index: 0})
+	require.Nil(t, err)
+	second, err := wallet.NewWalletFromMnemonic(mnemonic, wallet.WOHDPath{Prefix: 690, Index: 1})
+	require.Nil(t, err)
+	assert.NotEqual(t, first.PublicKey(), second.PublicKey())
+}